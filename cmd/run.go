@@ -17,6 +17,7 @@ import (
 
 	"github.com/telekom/sparrow/internal/logger"
 	"github.com/telekom/sparrow/pkg/config"
+	"github.com/telekom/sparrow/pkg/db"
 	"github.com/telekom/sparrow/pkg/sparrow"
 )
 
@@ -25,6 +26,7 @@ const (
 	defaultLoaderInterval    = 300 * time.Second
 	defaultHttpRetryCount    = 3
 	defaultHttpRetryDelay    = 1 * time.Second
+	defaultLoaderGitTimeout  = 60 * time.Second
 )
 
 // NewCmdRun creates a new run command
@@ -39,6 +41,7 @@ func NewCmdRun() *cobra.Command {
 	NewFlag("api.address", "apiAddress").String().Bind(cmd, ":8080", "api: The address the server is listening on")
 	NewFlag("name", "sparrowName").String().Bind(cmd, "", "The DNS name of the sparrow")
 	NewFlag("loader.type", "loaderType").StringP("l").Bind(cmd, "http", "Defines the loader type that will load the checks configuration during the runtime. The fallback is the fileLoader")
+	NewFlag("loader.cachePath", "loaderCachePath").String().Bind(cmd, "", "The path of the file the last successfully loaded runtime configuration is cached in. Used if the configuration cannot be loaded")
 	NewFlag("loader.interval", "loaderInterval").Duration().Bind(cmd, defaultLoaderInterval, "defines the interval the loader reloads the configuration in seconds")
 	NewFlag("loader.http.url", "loaderHttpUrl").String().Bind(cmd, "", "http loader: The url where to get the remote configuration")
 	NewFlag("loader.http.token", "loaderHttpToken").String().Bind(cmd, "", "http loader: Bearer token to authenticate the http endpoint")
@@ -46,6 +49,17 @@ func NewCmdRun() *cobra.Command {
 	NewFlag("loader.http.retry.count", "loaderHttpRetryCount").Int().Bind(cmd, defaultHttpRetryCount, "http loader: Amount of retries trying to load the configuration")
 	NewFlag("loader.http.retry.delay", "loaderHttpRetryDelay").Duration().Bind(cmd, defaultHttpRetryDelay, "http loader: The initial delay between retries in seconds")
 	NewFlag("loader.file.path", "loaderFilePath").String().Bind(cmd, "config.yaml", "file loader: The path to the file to read the runtime config from")
+	NewFlag("loader.git.url", "loaderGitUrl").String().Bind(cmd, "", "git loader: The url or local path of the repository to get the runtime config from")
+	NewFlag("loader.git.ref", "loaderGitRef").String().Bind(cmd, "", "git loader: The branch or tag to get the runtime config from. Defaults to the default branch")
+	NewFlag("loader.git.path", "loaderGitPath").String().Bind(cmd, "config.yaml", "git loader: The path of the runtime config file in the repository")
+	NewFlag("loader.git.username", "loaderGitUsername").String().Bind(cmd, "", "git loader: The username for the token authentication. Defaults to oauth2")
+	NewFlag("loader.git.token", "loaderGitToken").String().Bind(cmd, "", "git loader: The token to authenticate at the repository")
+	NewFlag("loader.git.timeout", "loaderGitTimeout").Duration().Bind(cmd, defaultLoaderGitTimeout, "git loader: The timeout for fetching the repository")
+	NewFlag("db.type", "dbType").String().Bind(cmd, string(db.TypeMemory), "db: Defines where the check results are stored. Options: memory, file")
+	NewFlag("db.historySize", "dbHistorySize").Int().Bind(cmd, db.DefaultHistorySize, "db: The number of results kept per check")
+	NewFlag("db.file.path", "dbFilePath").String().Bind(cmd, "sparrow.db", "file db: The path to the file to store the check results in")
+	NewFlag("db.file.retention", "dbFileRetention").Duration().Bind(cmd, 0, "file db: The maximum age of stored results. 0 keeps all results within the history size")
+	NewFlag("db.file.compactionInterval", "dbFileCompactionInterval").Duration().Bind(cmd, db.DefaultCompactionInterval, "file db: The interval in which the file is compacted")
 
 	return cmd
 }
@@ -70,7 +84,10 @@ func run() func(cmd *cobra.Command, args []string) error {
 		sigChan := make(chan os.Signal, 1)
 		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
-		s := sparrow.New(cfg)
+		s, err := sparrow.New(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to create sparrow: %w", err)
+		}
 		cErr := make(chan error, 1)
 		log.InfoContext(ctx, "Running sparrow")
 		go func() {
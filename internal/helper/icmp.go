@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/net/icmp"
+)
+
+// ListenICMP opens a raw icmp socket for IPv4 or IPv6.
+// If raw sockets are not permitted and unprivileged is set, an unprivileged datagram
+// icmp socket is opened instead and true is returned. Unprivileged sockets only receive
+// echo replies, so callers which need other icmp messages must not set unprivileged.
+// The returned error matches [os.ErrPermission] if no socket is permitted.
+func ListenICMP(v6, unprivileged bool) (*icmp.PacketConn, bool, error) {
+	network, address := "ip4:icmp", "0.0.0.0"
+	if v6 {
+		network, address = "ip6:ipv6-icmp", "::"
+	}
+	conn, err := icmp.ListenPacket(network, address)
+	if err == nil {
+		return conn, false, nil
+	}
+	// EPERM and EACCES both match os.ErrPermission
+	if !unprivileged || !errors.Is(err, os.ErrPermission) {
+		return nil, false, err
+	}
+
+	network = "udp4"
+	if v6 {
+		network = "udp6"
+	}
+	conn, err = icmp.ListenPacket(network, address)
+	if err != nil {
+		return nil, false, err
+	}
+	return conn, true, nil
+}
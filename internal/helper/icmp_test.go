@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestListenICMP(t *testing.T) {
+	for _, v6 := range []bool{false, true} {
+		conn, unprivileged, err := ListenICMP(v6, false)
+		if err == nil {
+			if unprivileged {
+				t.Errorf("ListenICMP(%v, false) opened an unprivileged socket", v6)
+			}
+			_ = conn.Close()
+			continue
+		}
+		if !errors.Is(err, os.ErrPermission) {
+			t.Logf("ListenICMP(%v, false) error = %v", v6, err)
+			continue
+		}
+
+		conn, unprivileged, err = ListenICMP(v6, true)
+		if errors.Is(err, os.ErrPermission) {
+			t.Logf("Neither raw nor unprivileged icmp sockets are permitted")
+			continue
+		}
+		if err != nil {
+			t.Logf("ListenICMP(%v, true) error = %v", v6, err)
+			continue
+		}
+		if !unprivileged {
+			t.Errorf("ListenICMP(%v, true) = privileged socket, want unprivileged fallback", v6)
+		}
+		_ = conn.Close()
+	}
+}
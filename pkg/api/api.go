@@ -26,12 +26,14 @@ type api struct {
 	server    *http.Server
 	router    chi.Router
 	tlsConfig TLSConfig
+	auth      AuthConfig
 }
 
 // Config is the configuration for the data API
 type Config struct {
-	ListeningAddress string    `yaml:"address" mapstructure:"address"`
-	Tls              TLSConfig `yaml:"tls" mapstructure:"tls"`
+	ListeningAddress string     `yaml:"address" mapstructure:"address"`
+	Tls              TLSConfig  `yaml:"tls" mapstructure:"tls"`
+	Auth             AuthConfig `yaml:"auth" mapstructure:"auth"`
 }
 
 type TLSConfig struct {
@@ -57,7 +59,7 @@ func (a *Config) Validate() error {
 			return fmt.Errorf("tls key path cannot be empty")
 		}
 	}
-	return nil
+	return a.Auth.Validate(a.Tls.Enabled)
 }
 
 // New creates a new api
@@ -68,6 +70,7 @@ func New(cfg Config) API {
 		server:    &http.Server{Addr: cfg.ListeningAddress, Handler: r, ReadHeaderTimeout: readHeaderTimeout},
 		router:    r,
 		tlsConfig: cfg.Tls,
+		auth:      cfg.Auth,
 	}
 }
 
@@ -81,6 +84,14 @@ func (a *api) Run(ctx context.Context) error {
 		return fmt.Errorf("failed serving API: no routes initialized")
 	}
 
+	if a.tlsConfig.Enabled {
+		tlsCfg, err := a.auth.tlsConfig()
+		if err != nil {
+			return fmt.Errorf("failed serving API: %w", err)
+		}
+		a.server.TLSConfig = tlsCfg
+	}
+
 	// run http server in goroutine
 	go func(cErr chan error) {
 		defer close(cErr)
@@ -136,6 +147,9 @@ type Route struct {
 // RegisterRoutes sets up all endpoint handlers for the given routes
 func (a *api) RegisterRoutes(ctx context.Context, routes ...Route) error {
 	a.router.Use(logger.Middleware(ctx))
+	if a.auth.Enabled() {
+		a.router.Use(authMiddleware(a.auth))
+	}
 	for _, route := range routes {
 		if route.Method == "*" {
 			a.router.HandleFunc(route.Path, route.Handler)
@@ -238,6 +238,11 @@ func TestConfig_Validate(t *testing.T) {
 		{"Valid config", Config{ListeningAddress: ":8080"}, false},
 		{"Valid tls config", Config{ListeningAddress: ":8080", Tls: TLSConfig{Enabled: true, CertPath: "./mycert.pem", KeyPath: "mykey.key"}}, false},
 		{"Valid tls config without tls", Config{ListeningAddress: ":8080", Tls: TLSConfig{Enabled: false}}, false},
+		{"Valid auth config", Config{ListeningAddress: ":8080", Auth: AuthConfig{Bearer: BearerConfig{Tokens: []string{"secret"}}, Routes: []RoutePolicy{{Path: "/metrics", Policy: PolicyPublic}}}}, false},
+		{"Mtls without tls", Config{ListeningAddress: ":8080", Auth: AuthConfig{MTLS: MTLSConfig{CaPath: "ca.pem"}}}, true},
+		{"Bearer policy without tokens", Config{ListeningAddress: ":8080", Auth: AuthConfig{Default: PolicyBearer}}, true},
+		{"Unknown route policy", Config{ListeningAddress: ":8080", Auth: AuthConfig{Bearer: BearerConfig{Tokens: []string{"secret"}}, Routes: []RoutePolicy{{Path: "/metrics", Policy: "basic"}}}}, true},
+		{"Route path without slash", Config{ListeningAddress: ":8080", Auth: AuthConfig{Bearer: BearerConfig{Tokens: []string{"secret"}}, Routes: []RoutePolicy{{Path: "metrics", Policy: PolicyPublic}}}}, true},
 	}
 
 	for _, c := range cases {
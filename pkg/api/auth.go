@@ -0,0 +1,236 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/telekom/sparrow/internal/logger"
+)
+
+// Policy defines how requests to a route are authenticated
+type Policy string
+
+const (
+	// PolicyPublic allows all requests
+	PolicyPublic Policy = "public"
+	// PolicyBearer requires a valid bearer token
+	PolicyBearer Policy = "bearer"
+	// PolicyMTLS requires a client certificate signed by the configured CA
+	PolicyMTLS Policy = "mtls"
+	// PolicyAny requires either a valid bearer token or a valid client certificate
+	PolicyAny Policy = "any"
+)
+
+// rootPath is the path of the ok handler, which is public unless configured otherwise,
+// since it is used by the health checks of other sparrows
+const rootPath = "/"
+
+// AuthConfig is the configuration of the API authentication
+type AuthConfig struct {
+	// Bearer configures the bearer token authentication
+	Bearer BearerConfig `yaml:"bearer" mapstructure:"bearer"`
+	// MTLS configures the client certificate authentication.
+	// Requires tls to be enabled
+	MTLS MTLSConfig `yaml:"mtls" mapstructure:"mtls"`
+	// Default is the policy of all routes without a route policy.
+	// Defaults to PolicyAny if an authentication method is configured, otherwise PolicyPublic
+	Default Policy `yaml:"default" mapstructure:"default"`
+	// Routes are the policies of single routes
+	Routes []RoutePolicy `yaml:"routes" mapstructure:"routes"`
+}
+
+// BearerConfig is the configuration of the bearer token authentication
+type BearerConfig struct {
+	// Tokens are the accepted bearer tokens
+	Tokens []string `yaml:"tokens" mapstructure:"tokens"`
+}
+
+// MTLSConfig is the configuration of the client certificate authentication
+type MTLSConfig struct {
+	// CaPath is the path to the CA certificates used to verify the client certificates
+	CaPath string `yaml:"caPath" mapstructure:"caPath"`
+}
+
+// RoutePolicy defines the policy of a route
+type RoutePolicy struct {
+	// Path is the path of the route. A trailing "*" matches all paths with the given prefix,
+	// e.g. "/v1/metrics/*". If multiple paths match, the longest one is used
+	Path string `yaml:"path" mapstructure:"path"`
+	// Policy is the policy of the route
+	Policy Policy `yaml:"policy" mapstructure:"policy"`
+}
+
+// Enabled returns true if any authentication method is configured
+func (c *AuthConfig) Enabled() bool {
+	return len(c.Bearer.Tokens) > 0 || c.MTLS.CaPath != ""
+}
+
+// Validate validates the authentication configuration
+func (c *AuthConfig) Validate(tlsEnabled bool) error {
+	if c.MTLS.CaPath != "" && !tlsEnabled {
+		return errors.New("mtls authentication requires tls to be enabled")
+	}
+	if slices.Contains(c.Bearer.Tokens, "") {
+		return errors.New("bearer tokens cannot be empty")
+	}
+
+	if err := c.validatePolicy(c.Default); err != nil {
+		return fmt.Errorf("invalid default auth policy: %w", err)
+	}
+	for i, r := range c.Routes {
+		if !strings.HasPrefix(r.Path, "/") {
+			return fmt.Errorf("path of auth route %d must start with '/'", i)
+		}
+		if r.Policy == "" {
+			return fmt.Errorf("policy of auth route %q cannot be empty", r.Path)
+		}
+		if err := c.validatePolicy(r.Policy); err != nil {
+			return fmt.Errorf("invalid policy of auth route %q: %w", r.Path, err)
+		}
+	}
+	return nil
+}
+
+// validatePolicy checks that the policy is known and its authentication method is configured
+func (c *AuthConfig) validatePolicy(p Policy) error {
+	switch p {
+	case "", PolicyPublic:
+		return nil
+	case PolicyBearer:
+		if len(c.Bearer.Tokens) == 0 {
+			return errors.New("policy bearer requires bearer tokens")
+		}
+	case PolicyMTLS:
+		if c.MTLS.CaPath == "" {
+			return errors.New("policy mtls requires a mtls ca path")
+		}
+	case PolicyAny:
+		if !c.Enabled() {
+			return errors.New("policy any requires bearer tokens or a mtls ca path")
+		}
+	default:
+		return fmt.Errorf("unknown policy %q, must be one of: public, bearer, mtls, any", p)
+	}
+	return nil
+}
+
+// Protects returns true if requests to the given path must be authenticated
+func (c *AuthConfig) Protects(path string) bool {
+	return c.policyFor(path) != PolicyPublic
+}
+
+// policyFor returns the policy of the given request path
+func (c *AuthConfig) policyFor(path string) Policy {
+	policy, matched := c.Default, -1
+	if policy == "" {
+		policy = PolicyPublic
+		if c.Enabled() {
+			policy = PolicyAny
+		}
+	}
+	if path == rootPath {
+		policy = PolicyPublic
+	}
+
+	for _, r := range c.Routes {
+		prefix, wildcard := strings.CutSuffix(r.Path, "*")
+		if (wildcard && strings.HasPrefix(path, prefix) || path == r.Path) && len(r.Path) > matched {
+			policy, matched = r.Policy, len(r.Path)
+		}
+	}
+	return policy
+}
+
+// tlsConfig returns the server tls configuration for the client certificate authentication.
+// Client certificates are optional on the tls level, so routes without mtls policy stay reachable.
+func (c *AuthConfig) tlsConfig() (*tls.Config, error) {
+	if c.MTLS.CaPath == "" {
+		return nil, nil
+	}
+
+	pem, err := os.ReadFile(c.MTLS.CaPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mtls ca: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("failed to parse mtls ca: no certificates found")
+	}
+
+	return &tls.Config{
+		ClientAuth: tls.VerifyClientCertIfGiven,
+		ClientCAs:  pool,
+		MinVersion: tls.VersionTLS12,
+	}, nil
+}
+
+// authMiddleware returns a middleware that authenticates the requests according to the route policies
+func authMiddleware(cfg AuthConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			policy := cfg.policyFor(r.URL.Path)
+			if authenticated(cfg, policy, r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			logger.FromContext(r.Context()).Debug("Unauthenticated request", "path", r.URL.Path, "policy", policy)
+			if policy != PolicyMTLS {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+			}
+			w.WriteHeader(http.StatusUnauthorized)
+			_, err := w.Write([]byte(http.StatusText(http.StatusUnauthorized)))
+			if err != nil {
+				logger.FromContext(r.Context()).Error("Failed to write response", "error", err)
+			}
+		})
+	}
+}
+
+// authenticated returns true if the request fulfills the policy
+func authenticated(cfg AuthConfig, policy Policy, r *http.Request) bool {
+	switch policy {
+	case PolicyPublic:
+		return true
+	case PolicyBearer:
+		return hasValidToken(cfg.Bearer.Tokens, r)
+	case PolicyMTLS:
+		return hasVerifiedCert(r)
+	case PolicyAny:
+		return hasValidToken(cfg.Bearer.Tokens, r) || hasVerifiedCert(r)
+	default:
+		return false
+	}
+}
+
+// hasValidToken returns true if the request's bearer token is one of the accepted tokens
+func hasValidToken(tokens []string, r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return false
+	}
+	valid := false
+	for _, t := range tokens {
+		// Compare all tokens in constant time to not leak which token matched
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
+
+// hasVerifiedCert returns true if the request has a client certificate verified against the mtls ca
+func hasVerifiedCert(r *http.Request) bool {
+	return r.TLS != nil && len(r.TLS.VerifiedChains) > 0
+}
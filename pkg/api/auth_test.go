@@ -0,0 +1,205 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthConfig_policyFor(t *testing.T) {
+	cfg := AuthConfig{
+		Bearer: BearerConfig{Tokens: []string{"secret"}},
+		Routes: []RoutePolicy{
+			{Path: "/metrics", Policy: PolicyPublic},
+			{Path: "/v1/metrics/*", Policy: PolicyBearer},
+			{Path: "/v1/metrics/traceroute*", Policy: PolicyMTLS},
+		},
+	}
+
+	tests := []struct {
+		path string
+		want Policy
+	}{
+		{path: "/", want: PolicyPublic},
+		{path: "/openapi", want: PolicyAny},
+		{path: "/metrics", want: PolicyPublic},
+		{path: "/metrics/other", want: PolicyAny},
+		{path: "/v1/metrics/health", want: PolicyBearer},
+		{path: "/v1/metrics/traceroute", want: PolicyMTLS},
+		{path: "/v1/metrics/traceroute/history", want: PolicyMTLS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, cfg.policyFor(tt.path))
+		})
+	}
+
+	t.Run("root can be protected explicitly", func(t *testing.T) {
+		c := AuthConfig{Bearer: cfg.Bearer, Routes: []RoutePolicy{{Path: "/", Policy: PolicyBearer}}}
+		assert.Equal(t, PolicyBearer, c.policyFor("/"))
+	})
+
+	t.Run("public without authentication methods", func(t *testing.T) {
+		c := AuthConfig{}
+		assert.Equal(t, PolicyPublic, c.policyFor("/openapi"))
+		assert.False(t, c.Protects("/openapi"))
+	})
+
+	t.Run("protects", func(t *testing.T) {
+		assert.True(t, cfg.Protects("/openapi"))
+		assert.True(t, cfg.Protects("/v1/metrics/health"))
+		assert.False(t, cfg.Protects("/metrics"))
+		assert.False(t, cfg.Protects("/"))
+	})
+}
+
+func TestAuthMiddleware_Bearer(t *testing.T) {
+	cfg := AuthConfig{
+		Bearer:  BearerConfig{Tokens: []string{"first", "second"}},
+		Default: PolicyBearer,
+	}
+	r := chi.NewRouter()
+	r.Use(authMiddleware(cfg))
+	r.Get("/*", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	tests := []struct {
+		name   string
+		path   string
+		header string
+		want   int
+	}{
+		{name: "valid token", path: "/openapi", header: "Bearer second", want: http.StatusOK},
+		{name: "invalid token", path: "/openapi", header: "Bearer third", want: http.StatusUnauthorized},
+		{name: "missing token", path: "/openapi", want: http.StatusUnauthorized},
+		{name: "wrong scheme", path: "/openapi", header: "Basic Zmlyc3Q=", want: http.StatusUnauthorized},
+		{name: "root stays public", path: "/", want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, tt.path, http.NoBody)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.want, rec.Code)
+			if tt.want == http.StatusUnauthorized {
+				assert.Equal(t, "Bearer", rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_MTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca, caKey, caPEM := newTestCert(t, nil, nil, true)
+	caPath := filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(caPath, caPEM, 0o600))
+	client, clientKey, _ := newTestCert(t, ca, caKey, false)
+	untrusted, untrustedKey, _ := newTestCert(t, nil, nil, false)
+
+	cfg := AuthConfig{
+		MTLS:   MTLSConfig{CaPath: caPath},
+		Routes: []RoutePolicy{{Path: "/v1/*", Policy: PolicyMTLS}},
+	}
+	tlsCfg, err := cfg.tlsConfig()
+	require.NoError(t, err)
+
+	r := chi.NewRouter()
+	r.Use(authMiddleware(cfg))
+	r.Get("/*", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	srv := httptest.NewUnstartedServer(r)
+	srv.TLS = tlsCfg
+	srv.StartTLS()
+	defer srv.Close()
+
+	tests := []struct {
+		name string
+		cert *x509.Certificate
+		key  *ecdsa.PrivateKey
+		path string
+		want int
+	}{
+		{name: "trusted client certificate", cert: client, key: clientKey, path: "/v1/metrics/health", want: http.StatusOK},
+		{name: "no client certificate", path: "/v1/metrics/health", want: http.StatusUnauthorized},
+		{name: "root without client certificate", path: "/", want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := clientWithCert(srv, tt.cert, tt.key)
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL+tt.path, http.NoBody)
+			require.NoError(t, err)
+			resp, err := c.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tt.want, resp.StatusCode)
+		})
+	}
+
+	t.Run("untrusted client certificate", func(t *testing.T) {
+		c := clientWithCert(srv, untrusted, untrustedKey)
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL+"/", http.NoBody)
+		require.NoError(t, err)
+		resp, err := c.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		assert.Error(t, err, "the tls handshake should fail for untrusted client certificates")
+	})
+}
+
+// clientWithCert returns a client of the test server that authenticates with the given certificate
+func clientWithCert(srv *httptest.Server, cert *x509.Certificate, key *ecdsa.PrivateKey) *http.Client {
+	transport := srv.Client().Transport.(*http.Transport).Clone()
+	if cert != nil {
+		transport.TLSClientConfig.Certificates = []tls.Certificate{{Certificate: [][]byte{cert.Raw}, PrivateKey: key}}
+	}
+	return &http.Client{Transport: transport}
+}
+
+// newTestCert creates a certificate signed by the given parent. If parent is nil, the certificate is self-signed.
+func newTestCert(t *testing.T, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, isCA bool) (*x509.Certificate, *ecdsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "sparrow-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
@@ -14,8 +14,12 @@ import (
 	"github.com/telekom/sparrow/internal/helper"
 )
 
-// LabelTarget is the name of the label used by checks for the target of a check run
-const LabelTarget = "target"
+const (
+	// LabelTarget is the name of the label used by checks for the target of a check run
+	LabelTarget = "target"
+	// LabelCheck is the name of the label used for the name of the check instance
+	LabelCheck = "check"
+)
 
 // DefaultRetry provides a default configuration for the retry mechanism
 var DefaultRetry = helper.RetryConfig{
@@ -73,6 +77,9 @@ type Result struct {
 	Data any `json:"data"`
 	// Timestamp is the UTC time the check was run
 	Timestamp time.Time `json:"timestamp"`
+	// Checked are the targets checked in this run. The data of the other targets
+	// are their latest results of earlier runs. If nil, all targets were checked
+	Checked []string `json:"-"`
 }
 
 // ResultDTO is a data transfer object used to associate a check's name with its result.
@@ -6,11 +6,14 @@ package dns
 
 import (
 	"fmt"
+	"net"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/telekom/sparrow/internal/helper"
 	"github.com/telekom/sparrow/pkg/checks"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -20,10 +23,89 @@ const (
 
 // Config defines the configuration parameters for a DNS check
 type Config struct {
-	Targets  []string           `json:"targets" yaml:"targets"`
-	Interval time.Duration      `json:"interval" yaml:"interval"`
-	Timeout  time.Duration      `json:"timeout" yaml:"timeout"`
-	Retry    helper.RetryConfig `json:"retry" yaml:"retry"`
+	Targets []Target `json:"targets" yaml:"targets"`
+	// Nameservers are the nameservers queried for all targets
+	// without own nameservers. Defaults to the system resolver
+	Nameservers []string           `json:"nameservers,omitempty" yaml:"nameservers,omitempty"`
+	Interval    time.Duration      `json:"interval" yaml:"interval"`
+	Timeout     time.Duration      `json:"timeout" yaml:"timeout"`
+	Retry       helper.RetryConfig `json:"retry" yaml:"retry"`
+}
+
+// Target defines a DNS check target and how it is looked up.
+// A target can be configured as a plain name or IP string, in which case
+// the name is resolved to its addresses or the IP is reverse looked up.
+type Target struct {
+	// Name is the name or IP address to look up
+	Name string `json:"name" yaml:"name"`
+	// Type is the record type to query, one of A, AAAA, CNAME, MX, TXT, SRV, NS or SOA
+	Type RecordType `json:"type,omitempty" yaml:"type,omitempty"`
+	// Nameservers are the nameservers queried for this target.
+	// Overrides the nameservers of the check
+	Nameservers []string `json:"nameservers,omitempty" yaml:"nameservers,omitempty"`
+	// Expected is the optional set of expected answers.
+	// The lookup fails if the answers differ from it
+	Expected []string `json:"expected,omitempty" yaml:"expected,omitempty"`
+	// TargetOverrides optionally override the interval, timeout and retry of the check
+	checks.TargetOverrides `yaml:",inline"`
+}
+
+// targetFields is used to (un)marshal a Target without its custom (un)marshalers
+type targetFields Target
+
+// plainTarget allows targets to be configured as a plain name string
+var plainTarget = checks.PlainTarget[Target, targetFields]{
+	Key:     func(t *Target) *string { return &t.Name },
+	IsPlain: Target.isPlain,
+}
+
+// isPlain returns true if the target only consists of its name
+func (t Target) isPlain() bool {
+	return t.Type == "" && len(t.Nameservers) == 0 && len(t.Expected) == 0 && t.IsZero()
+}
+
+// UnmarshalYAML allows targets to be either a plain name string or an object
+func (t *Target) UnmarshalYAML(value *yaml.Node) error {
+	return plainTarget.DecodeYAML(value, t)
+}
+
+// MarshalYAML marshals targets without lookup options and overrides as a plain name string
+func (t Target) MarshalYAML() (any, error) {
+	return plainTarget.EncodeYAML(t)
+}
+
+// UnmarshalJSON allows targets to be either a plain name string or an object
+func (t *Target) UnmarshalJSON(b []byte) error {
+	return plainTarget.DecodeJSON(b, t)
+}
+
+// MarshalJSON marshals targets without lookup options and overrides as a plain name string
+func (t Target) MarshalJSON() ([]byte, error) {
+	return plainTarget.EncodeJSON(t)
+}
+
+// Key returns the identifier of the target used for its results and metrics.
+// Plain targets are identified by their name only, so existing results and
+// metrics keep their labels.
+func (t Target) Key() string {
+	key := t.Name
+	if t.Type != "" {
+		key += "/" + strings.ToUpper(string(t.Type))
+	}
+	if len(t.Nameservers) > 0 {
+		key += "@" + strings.Join(t.Nameservers, ",")
+	}
+	return key
+}
+
+// recordType returns the normalized record type of the target
+func (t *Target) recordType() RecordType {
+	return RecordType(strings.ToUpper(string(t.Type)))
+}
+
+// intervals returns the check interval of every target
+func (c *Config) intervals() map[string]time.Duration {
+	return checks.Intervals(c.Targets, Target.Key, c.Interval)
 }
 
 // For returns the name of the check
@@ -33,10 +115,30 @@ func (c *Config) For() string {
 
 // Validate checks if the configuration is valid
 func (c *Config) Validate() error {
-	for _, t := range c.Targets {
-		if strings.HasPrefix(t, "https://") || strings.HasPrefix(t, "http://") {
+	if err := validateNameservers(c.Nameservers); err != nil {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "nameservers", Reason: err.Error()}
+	}
+
+	for i, t := range c.Targets {
+		if t.Name == "" {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("targets[%d].name", i), Reason: "name must not be empty"}
+		}
+
+		if strings.HasPrefix(t.Name, "https://") || strings.HasPrefix(t.Name, "http://") {
 			return checks.ErrInvalidConfig{CheckName: c.For(), Field: "targets", Reason: "target URLs must not start with 'https://' or 'http://'"}
 		}
+
+		if t.Type != "" && !slices.Contains(recordTypes, t.recordType()) {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("targets[%d].type", i), Reason: fmt.Sprintf("unsupported record type %q", t.Type)}
+		}
+
+		if err := validateNameservers(t.Nameservers); err != nil {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("targets[%d].nameservers", i), Reason: err.Error()}
+		}
+
+		if err := t.Validate(c.For(), fmt.Sprintf("targets[%d]", i), minInterval, minTimeout); err != nil {
+			return err
+		}
 	}
 
 	if c.Interval < minInterval {
@@ -49,3 +151,17 @@ func (c *Config) Validate() error {
 
 	return nil
 }
+
+// validateNameservers checks that all nameservers are IP addresses with an optional port
+func validateNameservers(nameservers []string) error {
+	for _, ns := range nameservers {
+		host := ns
+		if h, _, err := net.SplitHostPort(ns); err == nil {
+			host = h
+		}
+		if net.ParseIP(strings.Trim(host, "[]")) == nil {
+			return fmt.Errorf("nameserver %q must be an IP address with an optional port", ns)
+		}
+	}
+	return nil
+}
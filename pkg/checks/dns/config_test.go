@@ -5,8 +5,13 @@
 package dns
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestConfig_Validate(t *testing.T) {
@@ -18,7 +23,7 @@ func TestConfig_Validate(t *testing.T) {
 		{
 			name: "valid config",
 			config: Config{
-				Targets:  []string{"example.com"},
+				Targets:  []Target{{Name: "example.com"}},
 				Interval: 100 * time.Millisecond,
 				Timeout:  1 * time.Second,
 			},
@@ -27,7 +32,57 @@ func TestConfig_Validate(t *testing.T) {
 		{
 			name: "invalid targets",
 			config: Config{
-				Targets:  []string{"http://example.com", "https://google.com"},
+				Targets:  []Target{{Name: "http://example.com"}, {Name: "https://google.com"}},
+				Interval: 100 * time.Millisecond,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid targets with record types and nameservers",
+			config: Config{
+				Targets: []Target{
+					{Name: "example.com", Type: "mx", Nameservers: []string{"1.1.1.1", "[2606:4700:4700::1111]:53"}},
+					{Name: "_sip._tcp.example.com", Type: RecordSRV, Expected: []string{"10 60 5060 sip.example.com."}},
+				},
+				Nameservers: []string{"8.8.8.8:53"},
+				Interval:    100 * time.Millisecond,
+				Timeout:     1 * time.Second,
+			},
+			wantErr: false,
+		},
+		{
+			name: "unsupported record type",
+			config: Config{
+				Targets:  []Target{{Name: "example.com", Type: "PTR"}},
+				Interval: 100 * time.Millisecond,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid target nameserver",
+			config: Config{
+				Targets:  []Target{{Name: "example.com", Type: RecordA, Nameservers: []string{"dns.example.com"}}},
+				Interval: 100 * time.Millisecond,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid check nameserver",
+			config: Config{
+				Targets:     []Target{{Name: "example.com"}},
+				Nameservers: []string{"not-an-ip:53"},
+				Interval:    100 * time.Millisecond,
+				Timeout:     1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty target name",
+			config: Config{
+				Targets:  []Target{{Type: RecordA}},
 				Interval: 100 * time.Millisecond,
 				Timeout:  1 * time.Second,
 			},
@@ -36,7 +91,7 @@ func TestConfig_Validate(t *testing.T) {
 		{
 			name: "invalid interval",
 			config: Config{
-				Targets:  []string{"example.com"},
+				Targets:  []Target{{Name: "example.com"}},
 				Interval: 10 * time.Millisecond,
 				Timeout:  1 * time.Second,
 			},
@@ -45,7 +100,7 @@ func TestConfig_Validate(t *testing.T) {
 		{
 			name: "invalid timeout",
 			config: Config{
-				Targets:  []string{"example.com"},
+				Targets:  []Target{{Name: "example.com"}},
 				Interval: 100 * time.Millisecond,
 				Timeout:  100 * time.Millisecond,
 			},
@@ -62,3 +117,69 @@ func TestConfig_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestTarget_Unmarshal(t *testing.T) {
+	want := Config{
+		Targets: []Target{
+			{Name: "example.com"},
+			{
+				Name:        "example.com",
+				Type:        RecordMX,
+				Nameservers: []string{"1.1.1.1"},
+				Expected:    []string{"10 mail.example.com."},
+			},
+		},
+		Nameservers: []string{"8.8.8.8"},
+	}
+
+	t.Run("yaml", func(t *testing.T) {
+		in := `
+nameservers: [8.8.8.8]
+targets:
+  - example.com
+  - name: example.com
+    type: MX
+    nameservers: [1.1.1.1]
+    expected: ["10 mail.example.com."]
+`
+		var got Config
+		require.NoError(t, yaml.Unmarshal([]byte(in), &got))
+		assert.Equal(t, want, got)
+
+		out, err := yaml.Marshal(got)
+		require.NoError(t, err)
+		var roundtrip Config
+		require.NoError(t, yaml.Unmarshal(out, &roundtrip))
+		assert.Equal(t, want, roundtrip)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		in := `{"nameservers": ["8.8.8.8"], "targets": ["example.com", {"name": "example.com", "type": "MX",
+			"nameservers": ["1.1.1.1"], "expected": ["10 mail.example.com."]}]}`
+		var got Config
+		require.NoError(t, json.Unmarshal([]byte(in), &got))
+		assert.Equal(t, want, got)
+
+		out, err := json.Marshal(got)
+		require.NoError(t, err)
+		var roundtrip Config
+		require.NoError(t, json.Unmarshal(out, &roundtrip))
+		assert.Equal(t, want, roundtrip)
+	})
+}
+
+func TestTarget_Key(t *testing.T) {
+	tests := []struct {
+		target Target
+		want   string
+	}{
+		{target: Target{Name: "example.com"}, want: "example.com"},
+		{target: Target{Name: "example.com", Type: "mx"}, want: "example.com/MX"},
+		{target: Target{Name: "example.com", Type: RecordA, Nameservers: []string{"1.1.1.1", "8.8.8.8"}}, want: "example.com/A@1.1.1.1,8.8.8.8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.target.Key())
+		})
+	}
+}
@@ -6,8 +6,10 @@ package dns
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -31,6 +33,8 @@ type DNS struct {
 	config  Config
 	metrics metrics
 	client  Resolver
+	// schedule tracks when the targets are due
+	schedule checks.Scheduler[result]
 }
 
 func (d *DNS) GetConfig() checks.Runtime {
@@ -45,6 +49,13 @@ func (d *DNS) Name() string {
 	return CheckName
 }
 
+func init() {
+	checks.MustRegister(CheckName, checks.Factory{
+		NewCheck:  NewCheck,
+		NewConfig: func() checks.Runtime { return &Config{} },
+	})
+}
+
 // NewCheck creates a new instance of the dns check
 func NewCheck() checks.Check {
 	return &DNS{
@@ -63,8 +74,12 @@ func NewCheck() checks.Check {
 // result represents the result of a single DNS check for a specific target
 type result struct {
 	Resolved []string `json:"resolved"`
-	Error    *string  `json:"error"`
-	Total    float64  `json:"total"`
+	// Type is the queried record type, if any
+	Type string `json:"type,omitempty"`
+	// Nameserver is the nameserver that answered, if custom nameservers are used
+	Nameserver string  `json:"nameserver,omitempty"`
+	Error      *string `json:"error"`
+	Total      float64 `json:"total"`
 }
 
 // Run starts the dns check
@@ -73,9 +88,9 @@ func (d *DNS) Run(ctx context.Context, cResult chan checks.ResultDTO) error {
 	defer cancel()
 	log := logger.FromContext(ctx)
 
-	interval := d.GetConfig().(*Config).Interval
+	cfg := d.GetConfig().(*Config)
 
-	log.Info("Starting dns check", "interval", interval.String())
+	log.Info("Starting dns check", "interval", cfg.Interval.String())
 	for {
 		select {
 		case <-ctx.Done():
@@ -83,7 +98,7 @@ func (d *DNS) Run(ctx context.Context, cResult chan checks.ResultDTO) error {
 			return ctx.Err()
 		case <-d.DoneChan:
 			return nil
-		case <-time.After(interval):
+		case <-time.After(d.schedule.Next(time.Now(), cfg.intervals(), cfg.Interval)):
 			res := d.check(ctx)
 
 			cResult <- checks.ResultDTO{
@@ -91,12 +106,13 @@ func (d *DNS) Run(ctx context.Context, cResult chan checks.ResultDTO) error {
 				Result: &checks.Result{
 					Data:      res,
 					Timestamp: time.Now(),
+					Checked:   d.schedule.Checked(),
 				},
 			}
 			log.Debug("Successfully finished dns check run")
 
-			// Re-read interval in case config was updated
-			interval = d.GetConfig().(*Config).Interval
+			// Re-read config in case it was updated
+			cfg = d.GetConfig().(*Config)
 		}
 	}
 }
@@ -112,8 +128,8 @@ func (d *DNS) UpdateConfig(cfg checks.Runtime) error {
 		defer d.Mu.Unlock()
 
 		for _, target := range d.config.Targets {
-			if !slices.Contains(c.Targets, target) {
-				err := d.metrics.Remove(target)
+			if !slices.ContainsFunc(c.Targets, func(t Target) bool { return t.Key() == target.Key() }) {
+				err := d.metrics.Remove(target.Key())
 				if err != nil {
 					return err
 				}
@@ -156,6 +172,7 @@ func (d *DNS) check(ctx context.Context) map[string]result {
 	// Get a copy of the config to avoid race conditions
 	cfg := d.GetConfig().(*Config)
 
+	due := d.schedule.Due(time.Now(), cfg.intervals())
 	if len(cfg.Targets) == 0 {
 		log.Debug("No targets defined")
 		return map[string]result{}
@@ -165,18 +182,34 @@ func (d *DNS) check(ctx context.Context) map[string]result {
 	var wg sync.WaitGroup
 	results := map[string]result{}
 
+	// The dialer is shared by all targets, so it must allow the longest timeout.
+	// The timeout of each target is enforced by the context of its lookups.
+	dialTimeout := cfg.Timeout
+	for _, t := range cfg.Targets {
+		dialTimeout = max(dialTimeout, t.Timeout)
+	}
 	d.client.SetDialer(&net.Dialer{
-		Timeout: cfg.Timeout,
+		Timeout: dialTimeout,
 	})
 
-	log.Debug("Getting dns status for each target in separate routine", "amount", len(cfg.Targets))
+	log.Debug("Getting dns status for each due target in separate routine", "amount", len(due))
 	for _, t := range cfg.Targets {
-		target := t
+		target := t.Key()
+		if !due[target] {
+			continue
+		}
+		timeout := t.TimeoutOr(cfg.Timeout)
+		nameservers := t.Nameservers
+		if len(nameservers) == 0 {
+			nameservers = cfg.Nameservers
+		}
 		wg.Add(1)
 		lo := log.With("target", target)
 
 		getDNSRetry := helper.Retry(func(ctx context.Context) error {
-			res, err := getDNS(ctx, d.client, target)
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			res, err := getDNS(ctx, d.client, t, nameservers)
 			mu.Lock()
 			defer mu.Unlock()
 			results[target] = res
@@ -184,7 +217,7 @@ func (d *DNS) check(ctx context.Context) map[string]result {
 				return err
 			}
 			return nil
-		}, cfg.Retry)
+		}, t.RetryOr(cfg.Retry))
 
 		go func() {
 			defer wg.Done()
@@ -204,28 +237,32 @@ func (d *DNS) check(ctx context.Context) map[string]result {
 	}
 	wg.Wait()
 
-	log.Debug("Successfully resolved names/addresses from all targets")
-	return results
+	log.Debug("Successfully resolved names/addresses from all due targets")
+	return d.schedule.Merge(results)
 }
 
-// getDNS performs a DNS resolution for the given address using the specified net.Resolver.
-// If the address is an IP address, LookupAddr is used to perform a reverse DNS lookup.
-// If the address is a hostname, LookupHost is used to find its IP addresses.
+// getDNS performs a DNS lookup of the given target using the specified Resolver.
+// Targets without a record type and nameservers are looked up with the system resolver:
+// If the name is an IP address, LookupAddr is used to perform a reverse DNS lookup.
+// If the name is a hostname, LookupHost is used to find its IP addresses.
+// Otherwise the records of the target's type are queried from the nameservers.
+// If expected answers are configured, the lookup fails if the answers differ from them.
 // Returns a result struct containing the outcome of the DNS query.
-func getDNS(ctx context.Context, c Resolver, address string) (result, error) {
-	log := logger.FromContext(ctx).With("address", address)
-	var res result
-
-	var lookupFunc func(context.Context, string) ([]string, error)
-	ip := net.ParseIP(address)
-	if ip != nil {
-		lookupFunc = c.LookupAddr
-	} else {
-		lookupFunc = c.LookupHost
-	}
+func getDNS(ctx context.Context, c Resolver, target Target, nameservers []string) (result, error) {
+	log := logger.FromContext(ctx).With("address", target.Name)
+	res := result{Type: string(target.recordType())}
 
 	start := time.Now()
-	resp, err := lookupFunc(ctx, address)
+	var resp []string
+	var err error
+	switch {
+	case target.Type == "" && len(nameservers) == 0 && net.ParseIP(target.Name) != nil:
+		resp, err = c.LookupAddr(ctx, target.Name)
+	case target.Type == "" && len(nameservers) == 0:
+		resp, err = c.LookupHost(ctx, target.Name)
+	default:
+		resp, res.Nameserver, err = c.LookupRecords(ctx, target.Name, target.recordType(), nameservers)
+	}
 	if err != nil {
 		log.Error("Error while looking up address", "error", err)
 		errval := err.Error()
@@ -237,5 +274,27 @@ func getDNS(ctx context.Context, c Resolver, address string) (result, error) {
 	res.Resolved = resp
 	res.Total = rtt
 
+	if len(target.Expected) > 0 && !sameAnswers(resp, target.Expected) {
+		err = fmt.Errorf("answers %v do not match the expected answers %v", resp, target.Expected)
+		log.Warn("Unexpected answers", "error", err)
+		errval := err.Error()
+		res.Error = &errval
+		return res, err
+	}
+
 	return res, nil
 }
+
+// sameAnswers returns true if both answer sets contain the same records.
+// The comparison ignores order, duplicates, case and trailing dots.
+func sameAnswers(got, want []string) bool {
+	normalize := func(answers []string) []string {
+		n := make([]string, 0, len(answers))
+		for _, a := range answers {
+			n = append(n, strings.TrimSuffix(strings.ToLower(strings.TrimSpace(a)), "."))
+		}
+		slices.Sort(n)
+		return slices.Compact(n)
+	}
+	return slices.Equal(normalize(got), normalize(want))
+}
@@ -154,8 +154,13 @@ func TestDNS_Run(t *testing.T) {
 			cResult := make(chan checks.ResultDTO, 1)
 			defer close(cResult)
 
+			targets := make([]Target, 0, len(tt.targets))
+			for _, t := range tt.targets {
+				targets = append(targets, Target{Name: t})
+			}
+
 			err := c.UpdateConfig(&Config{
-				Targets:  tt.targets,
+				Targets:  targets,
 				Interval: 1 * time.Second,
 				Timeout:  5 * time.Millisecond,
 			})
@@ -252,15 +257,15 @@ func TestDNS_UpdateConfig(t *testing.T) {
 		{
 			name: "simple config",
 			input: &Config{
-				Targets: []string{
-					exampleURL,
-					sparrowURL,
+				Targets: []Target{
+					{Name: exampleURL},
+					{Name: sparrowURL},
 				},
 				Interval: 10 * time.Second,
 				Timeout:  30 * time.Second,
 			},
 			want: Config{
-				Targets:  []string{exampleURL, sparrowURL},
+				Targets:  []Target{{Name: exampleURL}, {Name: sparrowURL}},
 				Interval: 10 * time.Second,
 				Timeout:  30 * time.Second,
 			},
@@ -275,8 +280,8 @@ func TestDNS_UpdateConfig(t *testing.T) {
 		{
 			name: "wrong type",
 			input: &health.Config{
-				Targets: []string{
-					exampleURL,
+				Targets: []health.Target{
+					{URL: exampleURL},
 				},
 			},
 			want:    Config{},
@@ -295,6 +300,77 @@ func TestDNS_UpdateConfig(t *testing.T) {
 	}
 }
 
+func Test_getDNS_records(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      Target
+		nameservers []string
+		answers     []string
+		wantErr     bool
+	}{
+		{
+			name:    "record type without nameservers",
+			target:  Target{Name: exampleURL, Type: RecordMX},
+			answers: []string{"10 mail.example.com."},
+		},
+		{
+			name:        "plain target with check nameservers",
+			target:      Target{Name: exampleURL},
+			nameservers: []string{"192.0.2.53"},
+			answers:     []string{exampleIP},
+		},
+		{
+			name:    "expected answers match",
+			target:  Target{Name: exampleURL, Type: RecordA, Expected: []string{sparrowIP, exampleIP}},
+			answers: []string{exampleIP, sparrowIP},
+		},
+		{
+			name:    "expected answers ignore case and trailing dot",
+			target:  Target{Name: exampleURL, Type: RecordCNAME, Expected: []string{"Alias.Example.com"}},
+			answers: []string{"alias.example.com."},
+		},
+		{
+			name:    "expected answers differ",
+			target:  Target{Name: exampleURL, Type: RecordA, Expected: []string{exampleIP}},
+			answers: []string{exampleIP, sparrowIP},
+			wantErr: true,
+		},
+		{
+			name:    "lookup error",
+			target:  Target{Name: exampleURL, Type: RecordTXT},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &ResolverMock{
+				LookupRecordsFunc: func(ctx context.Context, name string, rtype RecordType, nameservers []string) ([]string, string, error) {
+					assert.Equal(t, tt.target.Name, name)
+					assert.Equal(t, tt.target.Type, rtype)
+					assert.Equal(t, tt.nameservers, nameservers)
+					if tt.answers == nil {
+						return nil, "", &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+					}
+					return tt.answers, "192.0.2.53:53", nil
+				},
+			}
+
+			res, err := getDNS(context.Background(), c, tt.target, tt.nameservers)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getDNS() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, string(tt.target.Type), res.Type)
+			if tt.wantErr {
+				assert.NotNil(t, res.Error)
+				return
+			}
+			assert.Equal(t, tt.answers, res.Resolved)
+			assert.Equal(t, "192.0.2.53:53", res.Nameserver)
+			assert.Len(t, c.LookupRecordsCalls(), 1)
+		})
+	}
+}
+
 func TestNewCheck(t *testing.T) {
 	c := NewCheck()
 	if c == nil {
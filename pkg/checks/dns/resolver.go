@@ -6,18 +6,60 @@ package dns
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
 	"net"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
 )
 
 //go:generate go tool moq -out resolver_moq.go . Resolver
 type Resolver interface {
 	LookupAddr(ctx context.Context, addr string) ([]string, error)
 	LookupHost(ctx context.Context, addr string) ([]string, error)
+	// LookupRecords looks up the records of the given type for the name.
+	// If nameservers are given, they are queried in order until one answers,
+	// otherwise the system resolver is used.
+	// Returns the answers and the nameserver that answered, if any.
+	LookupRecords(ctx context.Context, name string, rtype RecordType, nameservers []string) ([]string, string, error)
 	SetDialer(d *net.Dialer)
 }
 
+// RecordType is the type of DNS record to query
+type RecordType string
+
+const (
+	RecordA     RecordType = "A"
+	RecordAAAA  RecordType = "AAAA"
+	RecordCNAME RecordType = "CNAME"
+	RecordMX    RecordType = "MX"
+	RecordTXT   RecordType = "TXT"
+	RecordSRV   RecordType = "SRV"
+	RecordNS    RecordType = "NS"
+	RecordSOA   RecordType = "SOA"
+)
+
+// recordTypes are all supported record types
+var recordTypes = []RecordType{RecordA, RecordAAAA, RecordCNAME, RecordMX, RecordTXT, RecordSRV, RecordNS, RecordSOA}
+
+const (
+	// dnsPort is the default port of a nameserver
+	dnsPort = "53"
+	// resolvConf is the path to the system resolver configuration
+	resolvConf = "/etc/resolv.conf"
+	// maxUDPSize is the maximum size of a DNS message over UDP
+	maxUDPSize = 1232
+)
+
 type resolver struct {
 	*net.Resolver
+	dialer *net.Dialer
 }
 
 func NewResolver() Resolver {
@@ -26,11 +68,339 @@ func NewResolver() Resolver {
 			// We need to set this so the custom dialer is used
 			PreferGo: true,
 		},
+		dialer: &net.Dialer{},
 	}
 }
 
 func (r *resolver) SetDialer(d *net.Dialer) {
+	r.dialer = d
 	r.Dial = func(ctx context.Context, network, address string) (net.Conn, error) {
 		return d.DialContext(ctx, network, address)
 	}
 }
+
+// LookupRecords looks up the records of the given type for the name
+func (r *resolver) LookupRecords(ctx context.Context, name string, rtype RecordType, nameservers []string) ([]string, string, error) {
+	if len(nameservers) == 0 {
+		if rtype == RecordSOA {
+			servers, err := systemNameservers()
+			if err != nil {
+				return nil, "", err
+			}
+			return r.lookupEach(ctx, name, rtype, servers)
+		}
+		res, err := lookup(ctx, r.Resolver, name, rtype)
+		return res, "", err
+	}
+	return r.lookupEach(ctx, name, rtype, nameservers)
+}
+
+// lookupEach queries the nameservers in order until one of them answers.
+// A negative answer like NXDOMAIN is returned immediately.
+func (r *resolver) lookupEach(ctx context.Context, name string, rtype RecordType, nameservers []string) (res []string, server string, err error) {
+	for _, ns := range nameservers {
+		server = withDefaultPort(ns)
+		res, err = r.lookupRaw(ctx, name, rtype, server)
+
+		var dnsErr *net.DNSError
+		if err == nil || (errors.As(err, &dnsErr) && dnsErr.IsNotFound) {
+			return res, server, err
+		}
+	}
+	return res, server, err
+}
+
+// lookup performs the lookup of the record type with the given resolver
+// and returns the answers in their textual representation.
+// Without a record type, names are resolved to their addresses and IPs are reverse looked up.
+func lookup(ctx context.Context, res *net.Resolver, name string, rtype RecordType) ([]string, error) {
+	var answers []string
+	switch rtype {
+	case "":
+		if net.ParseIP(name) != nil {
+			return res.LookupAddr(ctx, name)
+		}
+		return res.LookupHost(ctx, name)
+	case RecordA, RecordAAAA:
+		network := "ip4"
+		if rtype == RecordAAAA {
+			network = "ip6"
+		}
+		ips, err := res.LookupIP(ctx, network, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			answers = append(answers, ip.String())
+		}
+	case RecordCNAME:
+		cname, err := res.LookupCNAME(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		answers = append(answers, cname)
+	case RecordMX:
+		mxs, err := res.LookupMX(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, mx := range mxs {
+			answers = append(answers, fmt.Sprintf("%d %s", mx.Pref, mx.Host))
+		}
+	case RecordTXT:
+		return res.LookupTXT(ctx, name)
+	case RecordSRV:
+		_, srvs, err := res.LookupSRV(ctx, "", "", name)
+		if err != nil {
+			return nil, err
+		}
+		for _, srv := range srvs {
+			answers = append(answers, fmt.Sprintf("%d %d %d %s", srv.Priority, srv.Weight, srv.Port, srv.Target))
+		}
+	case RecordNS:
+		nss, err := res.LookupNS(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range nss {
+			answers = append(answers, ns.Host)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported record type %q", rtype)
+	}
+	return answers, nil
+}
+
+// queryTypes maps the record types to the types of the dns queries
+var queryTypes = map[RecordType]dnsmessage.Type{
+	RecordA:     dnsmessage.TypeA,
+	RecordAAAA:  dnsmessage.TypeAAAA,
+	RecordCNAME: dnsmessage.TypeCNAME,
+	RecordMX:    dnsmessage.TypeMX,
+	RecordTXT:   dnsmessage.TypeTXT,
+	RecordSRV:   dnsmessage.TypeSRV,
+	RecordNS:    dnsmessage.TypeNS,
+	RecordSOA:   dnsmessage.TypeSOA,
+}
+
+// lookupRaw queries the records of the given type for the name from the nameserver.
+// Unlike the standard library resolver, it neither consults the hosts file nor appends
+// search domains, so the answers are exactly the ones of the nameserver.
+// Without a record type, names are resolved to their addresses and IPs are reverse looked up.
+func (r *resolver) lookupRaw(ctx context.Context, name string, rtype RecordType, server string) ([]string, error) {
+	if rtype != "" {
+		qtype, ok := queryTypes[rtype]
+		if !ok {
+			return nil, fmt.Errorf("unsupported record type %q", rtype)
+		}
+		return r.query(ctx, name, qtype, server)
+	}
+
+	if ip := net.ParseIP(name); ip != nil {
+		return r.query(ctx, reverseName(ip), dnsmessage.TypePTR, server)
+	}
+	v4, err := r.query(ctx, name, dnsmessage.TypeA, server)
+	v6, err6 := r.query(ctx, name, dnsmessage.TypeAAAA, server)
+	if err != nil && err6 != nil {
+		return nil, err
+	}
+	return append(v4, v6...), nil
+}
+
+// query sends a query of the given type for the name to the nameserver
+// and returns the matching answers in their textual representation.
+// If the UDP response is truncated, the query is repeated over TCP.
+func (r *resolver) query(ctx context.Context, name string, qtype dnsmessage.Type, server string) ([]string, error) {
+	fqdn, err := dnsmessage.NewName(dnsName(name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid name %q: %w", name, err)
+	}
+
+	// A random id makes it hard to spoof responses without seeing the query
+	id, err := queryID()
+	if err != nil {
+		return nil, err
+	}
+	msg := dnsmessage.Message{
+		Header: dnsmessage.Header{
+			ID:               id,
+			RecursionDesired: true,
+		},
+		Questions: []dnsmessage.Question{{Name: fqdn, Type: qtype, Class: dnsmessage.ClassINET}},
+	}
+	query, err := msg.Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.exchange(ctx, "udp", server, query, id)
+	if err == nil && resp.Truncated {
+		resp, err = r.exchange(ctx, "tcp", server, query, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.RCode == dnsmessage.RCodeNameError {
+		return nil, &net.DNSError{Err: "no such host", Name: name, Server: server, IsNotFound: true}
+	}
+	if resp.RCode != dnsmessage.RCodeSuccess {
+		return nil, &net.DNSError{Err: resp.RCode.String(), Name: name, Server: server}
+	}
+
+	var answers []string
+	for _, a := range resp.Answers {
+		// Answers to address queries may contain the CNAME records of the name as well
+		if a.Header.Type != qtype {
+			continue
+		}
+		switch body := a.Body.(type) {
+		case *dnsmessage.AResource:
+			answers = append(answers, net.IP(body.A[:]).String())
+		case *dnsmessage.AAAAResource:
+			answers = append(answers, net.IP(body.AAAA[:]).String())
+		case *dnsmessage.CNAMEResource:
+			answers = append(answers, body.CNAME.String())
+		case *dnsmessage.MXResource:
+			answers = append(answers, fmt.Sprintf("%d %s", body.Pref, body.MX.String()))
+		case *dnsmessage.TXTResource:
+			answers = append(answers, strings.Join(body.TXT, ""))
+		case *dnsmessage.SRVResource:
+			answers = append(answers, fmt.Sprintf("%d %d %d %s", body.Priority, body.Weight, body.Port, body.Target.String()))
+		case *dnsmessage.NSResource:
+			answers = append(answers, body.NS.String())
+		case *dnsmessage.PTRResource:
+			answers = append(answers, body.PTR.String())
+		case *dnsmessage.SOAResource:
+			answers = append(answers, fmt.Sprintf("%s %s %d %d %d %d %d",
+				body.NS.String(), body.MBox.String(), body.Serial, body.Refresh, body.Retry, body.Expire, body.MinTTL))
+		}
+	}
+	if len(answers) == 0 {
+		return nil, &net.DNSError{Err: fmt.Sprintf("no %s record found", strings.TrimPrefix(qtype.String(), "Type")), Name: name, Server: server, IsNotFound: true}
+	}
+	return answers, nil
+}
+
+// queryID returns a random id for a dns query
+func queryID() (uint16, error) {
+	var b [2]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return 0, fmt.Errorf("failed to generate dns query id: %w", err)
+	}
+	return binary.BigEndian.Uint16(b[:]), nil
+}
+
+// exchange sends the query to the server and returns the parsed response.
+// Returns an error if the id of the response doesn't match the id of the query.
+func (r *resolver) exchange(ctx context.Context, network, server string, query []byte, id uint16) (*dnsmessage.Message, error) {
+	conn, err := r.dialer.DialContext(ctx, network, server)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close() // #nosec G307
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	} else if r.dialer.Timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(r.dialer.Timeout))
+	}
+
+	var b []byte
+	if network == "tcp" {
+		// DNS over TCP prefixes messages with their length
+		if _, err = conn.Write(binary.BigEndian.AppendUint16(nil, uint16(len(query)))); err != nil { // #nosec G115
+			return nil, err
+		}
+		if _, err = conn.Write(query); err != nil {
+			return nil, err
+		}
+		l := make([]byte, 2)
+		if _, err = io.ReadFull(conn, l); err != nil {
+			return nil, err
+		}
+		b = make([]byte, binary.BigEndian.Uint16(l))
+		if _, err = io.ReadFull(conn, b); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err = conn.Write(query); err != nil {
+			return nil, err
+		}
+		// Responses with another id are ignored, since they may be spoofed,
+		// and the next response is read until the deadline is reached
+		buf := make([]byte, maxUDPSize)
+		for {
+			n, rErr := conn.Read(buf)
+			if rErr != nil {
+				return nil, rErr
+			}
+			var resp dnsmessage.Message
+			if err = resp.Unpack(buf[:n]); err == nil && resp.ID == id {
+				return &resp, nil
+			}
+		}
+	}
+
+	var resp dnsmessage.Message
+	if err = resp.Unpack(b); err != nil {
+		return nil, fmt.Errorf("failed to parse dns response: %w", err)
+	}
+	if resp.ID != id {
+		return nil, errors.New("dns response id does not match query")
+	}
+	return &resp, nil
+}
+
+// systemNameservers returns the nameservers configured in the system resolver configuration
+func systemNameservers() ([]string, error) {
+	b, err := os.ReadFile(resolvConf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read system nameservers: %w", err)
+	}
+
+	var servers []string
+	for _, line := range strings.Split(string(b), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "nameserver" {
+			servers = append(servers, fields[1])
+		}
+	}
+	if len(servers) == 0 {
+		return nil, errors.New("no system nameservers configured")
+	}
+	return servers, nil
+}
+
+// withDefaultPort adds the default DNS port to the nameserver address if it has none
+func withDefaultPort(ns string) string {
+	if _, _, err := net.SplitHostPort(ns); err == nil {
+		return ns
+	}
+	return net.JoinHostPort(strings.Trim(ns, "[]"), dnsPort)
+}
+
+// dnsName returns the fully qualified form of the name
+func dnsName(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// reverseName returns the name of the PTR record of the IP
+func reverseName(ip net.IP) string {
+	if v4 := ip.To4(); v4 != nil {
+		return fmt.Sprintf("%d.%d.%d.%d.in-addr.arpa.", v4[3], v4[2], v4[1], v4[0])
+	}
+	const hex = "0123456789abcdef"
+	var b strings.Builder
+	for i := len(ip) - 1; i >= 0; i-- {
+		b.WriteByte(hex[ip[i]&0x0f])
+		b.WriteByte('.')
+		b.WriteByte(hex[ip[i]>>4])
+		b.WriteByte('.')
+	}
+	b.WriteString("ip6.arpa.")
+	return b.String()
+}
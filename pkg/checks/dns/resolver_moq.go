@@ -25,6 +25,9 @@ var _ Resolver = &ResolverMock{}
 //			LookupHostFunc: func(ctx context.Context, addr string) ([]string, error) {
 //				panic("mock out the LookupHost method")
 //			},
+//			LookupRecordsFunc: func(ctx context.Context, name string, rtype RecordType, nameservers []string) ([]string, string, error) {
+//				panic("mock out the LookupRecords method")
+//			},
 //			SetDialerFunc: func(d *net.Dialer)  {
 //				panic("mock out the SetDialer method")
 //			},
@@ -41,6 +44,9 @@ type ResolverMock struct {
 	// LookupHostFunc mocks the LookupHost method.
 	LookupHostFunc func(ctx context.Context, addr string) ([]string, error)
 
+	// LookupRecordsFunc mocks the LookupRecords method.
+	LookupRecordsFunc func(ctx context.Context, name string, rtype RecordType, nameservers []string) ([]string, string, error)
+
 	// SetDialerFunc mocks the SetDialer method.
 	SetDialerFunc func(d *net.Dialer)
 
@@ -60,15 +66,27 @@ type ResolverMock struct {
 			// Addr is the addr argument value.
 			Addr string
 		}
+		// LookupRecords holds details about calls to the LookupRecords method.
+		LookupRecords []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// Rtype is the rtype argument value.
+			Rtype RecordType
+			// Nameservers is the nameservers argument value.
+			Nameservers []string
+		}
 		// SetDialer holds details about calls to the SetDialer method.
 		SetDialer []struct {
 			// D is the d argument value.
 			D *net.Dialer
 		}
 	}
-	lockLookupAddr sync.RWMutex
-	lockLookupHost sync.RWMutex
-	lockSetDialer  sync.RWMutex
+	lockLookupAddr    sync.RWMutex
+	lockLookupHost    sync.RWMutex
+	lockLookupRecords sync.RWMutex
+	lockSetDialer     sync.RWMutex
 }
 
 // LookupAddr calls LookupAddrFunc.
@@ -143,6 +161,50 @@ func (mock *ResolverMock) LookupHostCalls() []struct {
 	return calls
 }
 
+// LookupRecords calls LookupRecordsFunc.
+func (mock *ResolverMock) LookupRecords(ctx context.Context, name string, rtype RecordType, nameservers []string) ([]string, string, error) {
+	if mock.LookupRecordsFunc == nil {
+		panic("ResolverMock.LookupRecordsFunc: method is nil but Resolver.LookupRecords was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		Name        string
+		Rtype       RecordType
+		Nameservers []string
+	}{
+		Ctx:         ctx,
+		Name:        name,
+		Rtype:       rtype,
+		Nameservers: nameservers,
+	}
+	mock.lockLookupRecords.Lock()
+	mock.calls.LookupRecords = append(mock.calls.LookupRecords, callInfo)
+	mock.lockLookupRecords.Unlock()
+	return mock.LookupRecordsFunc(ctx, name, rtype, nameservers)
+}
+
+// LookupRecordsCalls gets all the calls that were made to LookupRecords.
+// Check the length with:
+//
+//	len(mockedResolver.LookupRecordsCalls())
+func (mock *ResolverMock) LookupRecordsCalls() []struct {
+	Ctx         context.Context
+	Name        string
+	Rtype       RecordType
+	Nameservers []string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		Name        string
+		Rtype       RecordType
+		Nameservers []string
+	}
+	mock.lockLookupRecords.RLock()
+	calls = mock.calls.LookupRecords
+	mock.lockLookupRecords.RUnlock()
+	return calls
+}
+
 // SetDialer calls SetDialerFunc.
 func (mock *ResolverMock) SetDialer(d *net.Dialer) {
 	if mock.SetDialerFunc == nil {
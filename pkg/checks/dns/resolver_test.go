@@ -0,0 +1,219 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func TestResolver_LookupRecords(t *testing.T) {
+	ns := newTestNameserver(t)
+
+	tests := []struct {
+		name        string
+		target      string
+		rtype       RecordType
+		nameservers []string
+		want        []string
+		wantErr     bool
+	}{
+		{
+			name:        "A record",
+			target:      exampleURL,
+			rtype:       RecordA,
+			nameservers: []string{ns},
+			want:        []string{exampleIP},
+		},
+		{
+			name:        "SOA record",
+			target:      exampleURL,
+			rtype:       RecordSOA,
+			nameservers: []string{ns},
+			want:        []string{"ns.example.com. admin.example.com. 2024010101 7200 3600 1209600 300"},
+		},
+		{
+			name:        "address lookup",
+			target:      exampleURL,
+			nameservers: []string{ns},
+			want:        []string{exampleIP},
+		},
+		{
+			name:        "reverse lookup",
+			target:      exampleIP,
+			nameservers: []string{ns},
+			want:        []string{exampleURL + "."},
+		},
+		{
+			name:        "hosts file is not consulted",
+			target:      "localhost",
+			rtype:       RecordA,
+			nameservers: []string{ns},
+			wantErr:     true,
+		},
+		{
+			name:        "unknown name",
+			target:      sparrowURL,
+			rtype:       RecordSOA,
+			nameservers: []string{ns},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewResolver()
+			r.SetDialer(&net.Dialer{Timeout: time.Second})
+
+			got, server, err := r.LookupRecords(context.Background(), tt.target, tt.rtype, tt.nameservers)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LookupRecords() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, ns, server)
+		})
+	}
+}
+
+func TestResolver_query_spoofed(t *testing.T) {
+	tests := []struct {
+		name    string
+		answer  bool
+		wantErr bool
+	}{
+		{name: "spoofed response is ignored", answer: true},
+		{name: "only spoofed response", answer: false, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+			require.NoError(t, err)
+			defer conn.Close()
+			ids := make(chan uint16, 2)
+
+			go func() {
+				buf := make([]byte, maxUDPSize)
+				for {
+					n, addr, err := conn.ReadFrom(buf)
+					if err != nil {
+						return
+					}
+					var req dnsmessage.Message
+					if err := req.Unpack(buf[:n]); err != nil || len(req.Questions) == 0 {
+						continue
+					}
+					ids <- req.ID
+
+					resp := answer(req)
+					resp.ID++
+					b, _ := resp.Pack()
+					_, _ = conn.WriteTo(b, addr)
+					if tt.answer {
+						resp.ID--
+						b, _ = resp.Pack()
+						_, _ = conn.WriteTo(b, addr)
+					}
+				}
+			}()
+
+			r := &resolver{dialer: &net.Dialer{Timeout: 500 * time.Millisecond}}
+			got, err := r.query(context.Background(), exampleURL, dnsmessage.TypeSOA, conn.LocalAddr().String())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("query() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				assert.Len(t, got, 1)
+			}
+
+			// the query ids are random
+			_, err = r.query(context.Background(), exampleURL, dnsmessage.TypeSOA, conn.LocalAddr().String())
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.NotEqual(t, <-ids, <-ids)
+		})
+	}
+}
+
+func Test_reverseName(t *testing.T) {
+	assert.Equal(t, "4.3.2.1.in-addr.arpa.", reverseName(net.ParseIP("1.2.3.4")))
+	assert.Equal(t, "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.", reverseName(net.ParseIP("2001:db8::1")))
+}
+
+func Test_withDefaultPort(t *testing.T) {
+	assert.Equal(t, "1.1.1.1:53", withDefaultPort("1.1.1.1"))
+	assert.Equal(t, "1.1.1.1:5353", withDefaultPort("1.1.1.1:5353"))
+	assert.Equal(t, "[2606:4700:4700::1111]:53", withDefaultPort("2606:4700:4700::1111"))
+	assert.Equal(t, "[2606:4700:4700::1111]:53", withDefaultPort("[2606:4700:4700::1111]"))
+}
+
+// newTestNameserver starts a UDP nameserver that knows the A and SOA records of exampleURL
+// and the PTR record of exampleIP and returns its address
+func newTestNameserver(t *testing.T) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, maxUDPSize)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			var req dnsmessage.Message
+			if err := req.Unpack(buf[:n]); err != nil || len(req.Questions) == 0 {
+				continue
+			}
+			resp := answer(req)
+			b, err := resp.Pack()
+			if err != nil {
+				continue
+			}
+			_, _ = conn.WriteTo(b, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+// answer builds the test nameserver's response to the request
+func answer(req dnsmessage.Message) dnsmessage.Message {
+	q := req.Questions[0]
+	resp := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: req.ID, Response: true, RecursionAvailable: true},
+		Questions: req.Questions,
+	}
+	if q.Name.String() == "4.3.2.1.in-addr.arpa." && q.Type == dnsmessage.TypePTR {
+		hdr := dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: dnsmessage.ClassINET, TTL: 60}
+		resp.Answers = append(resp.Answers, dnsmessage.Resource{Header: hdr, Body: &dnsmessage.PTRResource{PTR: dnsmessage.MustNewName(exampleURL + ".")}})
+		return resp
+	}
+	if q.Name.String() != exampleURL+"." {
+		resp.RCode = dnsmessage.RCodeNameError
+		return resp
+	}
+
+	hdr := dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: dnsmessage.ClassINET, TTL: 60}
+	switch q.Type {
+	case dnsmessage.TypeA:
+		resp.Answers = append(resp.Answers, dnsmessage.Resource{Header: hdr, Body: &dnsmessage.AResource{A: [4]byte{1, 2, 3, 4}}})
+	case dnsmessage.TypeSOA:
+		resp.Answers = append(resp.Answers, dnsmessage.Resource{Header: hdr, Body: &dnsmessage.SOAResource{
+			NS:      dnsmessage.MustNewName("ns.example.com."),
+			MBox:    dnsmessage.MustNewName("admin.example.com."),
+			Serial:  2024010101,
+			Refresh: 7200,
+			Retry:   3600,
+			Expire:  1209600,
+			MinTTL:  300,
+		}})
+	}
+	return resp
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package exec
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/telekom/sparrow/internal/helper"
+	"github.com/telekom/sparrow/pkg/checks"
+)
+
+const (
+	minInterval = 1 * time.Second
+	minTimeout  = 100 * time.Millisecond
+	// defaultMaxOutput is the number of bytes of the output kept if no limit is configured
+	defaultMaxOutput = 4 << 10
+	// maxMaxOutput is the maximum number of bytes of the output which can be kept
+	maxMaxOutput = 1 << 20
+)
+
+// Config defines the configuration parameters for an exec check
+type Config struct {
+	// Targets is a list of commands to run
+	Targets  []Target           `json:"targets,omitempty" yaml:"targets,omitempty"`
+	Interval time.Duration      `json:"interval" yaml:"interval"`
+	Timeout  time.Duration      `json:"timeout" yaml:"timeout"`
+	Retry    helper.RetryConfig `json:"retry" yaml:"retry"`
+	// MaxOutput is the maximum number of bytes of the output of a command
+	// which is kept. Defaults to 4KiB.
+	MaxOutput int `json:"maxOutput,omitempty" yaml:"maxOutput,omitempty"`
+}
+
+// Target defines a command run by the exec check
+type Target struct {
+	// Name identifies the command in the results and metrics
+	Name string `json:"name" yaml:"name"`
+	// Command is the name or path of the executable to run
+	Command string `json:"command" yaml:"command"`
+	// Args are the arguments passed to the command
+	Args []string `json:"args,omitempty" yaml:"args,omitempty"`
+	// Env are additional environment variables set for the command
+	Env map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
+	// JSON parses the standard output of the command as JSON
+	JSON bool `json:"json,omitempty" yaml:"json,omitempty"`
+	// TargetOverrides optionally override the interval, timeout and retry of the check
+	checks.TargetOverrides `yaml:",inline"`
+}
+
+// maxOutput returns the maximum number of bytes of the output which is kept
+func (c *Config) maxOutput() int {
+	if c.MaxOutput > 0 {
+		return c.MaxOutput
+	}
+	return defaultMaxOutput
+}
+
+// intervals returns the check interval of every target
+func (c *Config) intervals() map[string]time.Duration {
+	return checks.Intervals(c.Targets, func(t Target) string { return t.Name }, c.Interval)
+}
+
+// For returns the name of the check
+func (c *Config) For() string {
+	return CheckName
+}
+
+// Validate checks if the configuration is valid
+func (c *Config) Validate() error {
+	names := make(map[string]bool, len(c.Targets))
+	for i, t := range c.Targets {
+		field := fmt.Sprintf("targets[%d]", i)
+		if t.Name == "" {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: field + ".name", Reason: "name must not be empty"}
+		}
+		if names[t.Name] {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: field + ".name", Reason: fmt.Sprintf("name %q is not unique", t.Name)}
+		}
+		names[t.Name] = true
+
+		if t.Command == "" {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: field + ".command", Reason: "command must not be empty"}
+		}
+
+		if err := t.Validate(c.For(), field, minInterval, minTimeout); err != nil {
+			return err
+		}
+	}
+
+	if c.Interval < minInterval {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "interval", Reason: fmt.Sprintf("interval must be at least %v", minInterval)}
+	}
+
+	if c.Timeout < minTimeout {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "timeout", Reason: fmt.Sprintf("timeout must be at least %v", minTimeout)}
+	}
+
+	if c.MaxOutput < 0 || c.MaxOutput > maxMaxOutput {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "maxOutput", Reason: fmt.Sprintf("maxOutput must be between 0 and %d", maxMaxOutput)}
+	}
+
+	return nil
+}
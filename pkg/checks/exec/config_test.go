@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package exec
+
+import (
+	"testing"
+	"time"
+
+	"github.com/telekom/sparrow/pkg/checks"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{
+			name: "valid config",
+			config: Config{
+				Targets:  []Target{{Name: "true", Command: "true"}},
+				Interval: time.Minute,
+				Timeout:  time.Second,
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing name",
+			config: Config{
+				Targets:  []Target{{Command: "true"}},
+				Interval: time.Minute,
+				Timeout:  time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate name",
+			config: Config{
+				Targets:  []Target{{Name: "probe", Command: "true"}, {Name: "probe", Command: "false"}},
+				Interval: time.Minute,
+				Timeout:  time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing command",
+			config: Config{
+				Targets:  []Target{{Name: "probe"}},
+				Interval: time.Minute,
+				Timeout:  time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid target override",
+			config: Config{
+				Targets:  []Target{{Name: "probe", Command: "true", TargetOverrides: checks.TargetOverrides{Interval: time.Millisecond}}},
+				Interval: time.Minute,
+				Timeout:  time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid interval",
+			config: Config{
+				Interval: 100 * time.Millisecond,
+				Timeout:  time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid timeout",
+			config: Config{
+				Interval: time.Minute,
+				Timeout:  10 * time.Millisecond,
+			},
+			wantErr: true,
+		},
+		{
+			name: "max output too large",
+			config: Config{
+				Interval:  time.Minute,
+				Timeout:   time.Second,
+				MaxOutput: maxMaxOutput + 1,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Config.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,366 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package exec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	osexec "os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/telekom/sparrow/internal/helper"
+	"github.com/telekom/sparrow/internal/logger"
+	"github.com/telekom/sparrow/pkg/checks"
+)
+
+var (
+	_ checks.Check   = (*Exec)(nil)
+	_ checks.Runtime = (*Config)(nil)
+)
+
+const CheckName = "exec"
+
+// waitDelay is the time to wait for the output of a command
+// after it was killed because its timeout was exceeded
+const waitDelay = time.Second
+
+// Exec is a check that runs commands and reports their outcome
+type Exec struct {
+	checks.CheckBase
+	config  Config
+	metrics metrics
+	// schedule tracks when the targets are due
+	schedule checks.Scheduler[result]
+}
+
+func init() {
+	checks.MustRegister(CheckName, checks.Factory{
+		NewCheck:  NewCheck,
+		NewConfig: func() checks.Runtime { return &Config{} },
+	})
+}
+
+// NewCheck creates a new instance of the exec check
+func NewCheck() checks.Check {
+	return &Exec{
+		CheckBase: checks.CheckBase{
+			Mu:       sync.Mutex{},
+			DoneChan: make(chan struct{}, 1),
+		},
+		config: Config{
+			Retry: checks.DefaultRetry,
+		},
+		metrics: newMetrics(),
+	}
+}
+
+// result represents the result of a single run of a command
+type result struct {
+	Success  bool    `json:"success"`
+	ExitCode int     `json:"exitCode"`
+	Total    float64 `json:"total"`
+	// Output is the standard output of the command, truncated to the configured maximum
+	Output    string `json:"output"`
+	Truncated bool   `json:"truncated,omitempty"`
+	// Data is the output of the command parsed as JSON
+	Data  any     `json:"data,omitempty"`
+	Error *string `json:"error"`
+}
+
+// Run starts the exec check
+func (e *Exec) Run(ctx context.Context, cResult chan checks.ResultDTO) error {
+	ctx, cancel := logger.NewContextWithLogger(ctx)
+	defer cancel()
+	log := logger.FromContext(ctx)
+
+	cfg := e.GetConfig().(*Config)
+
+	log.Info("Starting exec check", "interval", cfg.Interval.String())
+	for {
+		select {
+		case <-ctx.Done():
+			log.Error("Context canceled", "err", ctx.Err())
+			return ctx.Err()
+		case <-e.DoneChan:
+			return nil
+		case <-time.After(e.schedule.Next(time.Now(), cfg.intervals(), cfg.Interval)):
+			res := e.check(ctx)
+
+			cResult <- checks.ResultDTO{
+				Name: e.Name(),
+				Result: &checks.Result{
+					Data:      res,
+					Timestamp: time.Now(),
+					Checked:   e.schedule.Checked(),
+				},
+			}
+			log.Debug("Successfully finished exec check run")
+
+			// Re-read config in case it was updated
+			cfg = e.GetConfig().(*Config)
+		}
+	}
+}
+
+// Shutdown is called once when the check is unregistered or sparrow shuts down
+func (e *Exec) Shutdown() {
+	e.DoneChan <- struct{}{}
+	close(e.DoneChan)
+}
+
+// UpdateConfig sets the configuration for the exec check
+func (e *Exec) UpdateConfig(cfg checks.Runtime) error {
+	if c, ok := cfg.(*Config); ok {
+		e.Mu.Lock()
+		defer e.Mu.Unlock()
+
+		for _, target := range e.config.Targets {
+			if !slices.ContainsFunc(c.Targets, func(n Target) bool { return n.Name == target.Name }) {
+				err := e.metrics.Remove(target.Name)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		e.config = *c
+		return nil
+	}
+
+	return checks.ErrConfigMismatch{
+		Expected: CheckName,
+		Current:  cfg.For(),
+	}
+}
+
+// GetConfig returns a copy of the current configuration of the exec check
+func (e *Exec) GetConfig() checks.Runtime {
+	e.Mu.Lock()
+	defer e.Mu.Unlock()
+	// Return a copy to prevent race conditions when the config is read while being updated
+	configCopy := e.config
+	return &configCopy
+}
+
+// Name returns the name of the check
+func (e *Exec) Name() string {
+	return CheckName
+}
+
+// Schema provides the schema of the data that will be provided
+// by the exec check
+func (e *Exec) Schema() (*openapi3.SchemaRef, error) {
+	return checks.OpenapiFromPerfData(make(map[string]result))
+}
+
+// GetMetricCollectors returns all metric collectors of check
+func (e *Exec) GetMetricCollectors() []prometheus.Collector {
+	return e.metrics.GetCollectors()
+}
+
+// RemoveLabelledMetrics removes the metrics which have the passed
+// target as a label
+func (e *Exec) RemoveLabelledMetrics(target string) error {
+	return e.metrics.Remove(target)
+}
+
+// check runs all due commands using a retry function.
+// Returns a map where each target is associated with the result of its command.
+func (e *Exec) check(ctx context.Context) map[string]result {
+	log := logger.FromContext(ctx)
+	log.Debug("Running commands")
+
+	// Get a copy of the config to avoid race conditions
+	cfg := e.GetConfig().(*Config)
+
+	due := e.schedule.Due(time.Now(), cfg.intervals())
+	if len(cfg.Targets) == 0 {
+		log.Debug("No targets defined")
+		return map[string]result{}
+	}
+	log.Debug("Running each due command in separate routine", "amount", len(due))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := map[string]result{}
+
+	for _, target := range cfg.Targets {
+		if !due[target.Name] {
+			continue
+		}
+		wg.Add(1)
+		lo := log.With("target", target.Name)
+
+		timeout := target.TimeoutOr(cfg.Timeout)
+		runRetry := helper.Retry(func(ctx context.Context) error {
+			res, err := run(ctx, target, timeout, cfg.maxOutput())
+			mu.Lock()
+			defer mu.Unlock()
+			results[target.Name] = res
+			return err
+		}, target.RetryOr(cfg.Retry))
+
+		go func() {
+			defer wg.Done()
+
+			lo.Debug("Starting retry routine to run command")
+			if err := runRetry(ctx); err != nil {
+				lo.Warn("Error while running command", "error", err)
+			}
+			lo.Debug("Exec check completed for target")
+
+			mu.Lock()
+			defer mu.Unlock()
+			e.metrics.Set(target.Name, results[target.Name])
+		}()
+	}
+
+	log.Debug("Waiting for all routines to finish")
+	wg.Wait()
+
+	log.Debug("Finished running all due commands")
+	return e.schedule.Merge(results)
+}
+
+// run runs the command of the target and waits for it to exit.
+// A command which does not exit with code 0 within the timeout fails.
+// Returns a result struct containing the outcome of the command.
+func run(ctx context.Context, target Target, timeout time.Duration, maxOutput int) (result, error) {
+	log := logger.FromContext(ctx).With("command", target.Command)
+	var res result
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := osexec.CommandContext(ctx, target.Command, target.Args...) // #nosec G204 // commands are configured by the operator
+	cmd.Env = os.Environ()
+	for _, k := range slices.Sorted(maps.Keys(target.Env)) {
+		cmd.Env = append(cmd.Env, k+"="+target.Env[k])
+	}
+	stdout := &limitedBuffer{max: maxOutput}
+	stderr := &limitedBuffer{max: maxOutput}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.WaitDelay = waitDelay
+
+	start := time.Now()
+	err := cmd.Run()
+	res.Total = time.Since(start).Seconds()
+	res.Output = stdout.String()
+	res.Truncated = stdout.truncated
+	res.ExitCode = -1
+	if cmd.ProcessState != nil {
+		res.ExitCode = cmd.ProcessState.ExitCode()
+	}
+
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("command timed out after %v: %w", timeout, err)
+		}
+		var exitErr *osexec.ExitError
+		if errors.As(err, &exitErr) {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				err = fmt.Errorf("%w: %s", err, msg)
+			}
+		}
+		log.Debug("Error while running command", "error", err)
+		errval := err.Error()
+		res.Error = &errval
+		return res, err
+	}
+
+	if target.JSON {
+		if res.Truncated {
+			err = fmt.Errorf("output exceeds %d bytes and cannot be parsed as json", maxOutput)
+		} else if jErr := json.Unmarshal([]byte(res.Output), &res.Data); jErr != nil {
+			err = fmt.Errorf("failed to parse output as json: %w", jErr)
+		}
+		if err != nil {
+			log.Debug("Error while parsing command output", "error", err)
+			errval := err.Error()
+			res.Error = &errval
+			return res, err
+		}
+	}
+
+	res.Success = true
+	return res, nil
+}
+
+// limitedBuffer is a writer which keeps up to max bytes
+// and silently discards everything written beyond
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	max       int
+	truncated bool
+}
+
+// Write writes p to the buffer as long as there is room left
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if room := b.max - b.buf.Len(); n > room {
+		b.truncated = true
+		p = p[:max(room, 0)]
+	}
+	b.buf.Write(p)
+	return n, nil
+}
+
+// String returns the buffered bytes as a string
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
+
+// flatten returns all numeric and boolean values of the parsed JSON
+// keyed by their path, with the keys of nested objects and the indices
+// of arrays joined by dots. A single top-level value is keyed "value".
+func flatten(data any) map[string]float64 {
+	values := map[string]float64{}
+	var walk func(path string, v any)
+	walk = func(path string, v any) {
+		switch v := v.(type) {
+		case float64:
+			values[path] = v
+		case bool:
+			values[path] = 0
+			if v {
+				values[path] = 1
+			}
+		case map[string]any:
+			for k, x := range v {
+				walk(join(path, k), x)
+			}
+		case []any:
+			for i, x := range v {
+				walk(join(path, strconv.Itoa(i)), x)
+			}
+		}
+	}
+	walk("", data)
+	if v, ok := values[""]; ok {
+		delete(values, "")
+		values["value"] = v
+	}
+	return values
+}
+
+// join joins the path of a JSON value with a key
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
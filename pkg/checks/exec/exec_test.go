@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package exec
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telekom/sparrow/internal/helper"
+	"github.com/telekom/sparrow/pkg/checks"
+	"github.com/telekom/sparrow/pkg/checks/health"
+)
+
+func TestExec_Run(t *testing.T) {
+	tests := []struct {
+		name    string
+		targets []Target
+		want    map[string]result
+	}{
+		{
+			name:    "success with no targets",
+			targets: []Target{},
+			want:    map[string]result{},
+		},
+		{
+			name:    "successful command",
+			targets: []Target{{Name: "echo", Command: "sh", Args: []string{"-c", "echo $GREETING"}, Env: map[string]string{"GREETING": "hello"}}},
+			want: map[string]result{
+				"echo": {Success: true, Output: "hello\n"},
+			},
+		},
+		{
+			name:    "failing command",
+			targets: []Target{{Name: "fail", Command: "sh", Args: []string{"-c", "echo broken >&2; exit 3"}}},
+			want: map[string]result{
+				"fail": {ExitCode: 3},
+			},
+		},
+		{
+			name:    "unknown command",
+			targets: []Target{{Name: "unknown", Command: "sparrow-does-not-exist"}},
+			want: map[string]result{
+				"unknown": {ExitCode: -1},
+			},
+		},
+		{
+			name:    "json output",
+			targets: []Target{{Name: "json", Command: "echo", Args: []string{`{"queue":{"size":3},"healthy":true}`}, JSON: true}},
+			want: map[string]result{
+				"json": {Success: true, Output: `{"queue":{"size":3},"healthy":true}` + "\n", Data: map[string]any{"queue": map[string]any{"size": 3.0}, "healthy": true}},
+			},
+		},
+		{
+			name:    "invalid json output",
+			targets: []Target{{Name: "json", Command: "echo", Args: []string{"not json"}, JSON: true}},
+			want: map[string]result{
+				"json": {Output: "not json\n"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCheck()
+			cResult := make(chan checks.ResultDTO, 1)
+			defer close(cResult)
+
+			err := c.UpdateConfig(&Config{
+				Targets:  tt.targets,
+				Interval: time.Second,
+				Timeout:  5 * time.Second,
+				Retry:    helper.RetryConfig{Count: 0},
+			})
+			require.NoError(t, err)
+
+			go func() {
+				err := c.Run(context.Background(), cResult)
+				if err != nil {
+					t.Errorf("Exec.Run() error = %v", err)
+				}
+			}()
+			defer c.Shutdown()
+
+			r := <-cResult
+			got, ok := r.Result.Data.(map[string]result)
+			require.True(t, ok, "Exec.Run() result data has wrong type %T", r.Result.Data)
+			assert.Len(t, got, len(tt.want))
+			for target, want := range tt.want {
+				assert.Equal(t, want.Success, got[target].Success, "success of %s", target)
+				assert.Equal(t, want.ExitCode, got[target].ExitCode, "exit code of %s", target)
+				assert.Equal(t, want.Output, got[target].Output, "output of %s", target)
+				assert.Equal(t, want.Data, got[target].Data, "data of %s", target)
+				if !want.Success {
+					assert.NotNil(t, got[target].Error, "error of %s", target)
+				}
+			}
+		})
+	}
+}
+
+func TestRun_timeout(t *testing.T) {
+	start := time.Now()
+	res, err := run(t.Context(), Target{Name: "sleep", Command: "sleep", Args: []string{"10"}}, 100*time.Millisecond, defaultMaxOutput)
+	assert.Error(t, err)
+	assert.False(t, res.Success)
+	assert.Equal(t, -1, res.ExitCode)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestRun_truncatedOutput(t *testing.T) {
+	res, err := run(t.Context(), Target{Name: "yes", Command: "sh", Args: []string{"-c", "printf '%0100d' 0"}}, time.Second, 10)
+	require.NoError(t, err)
+	assert.Equal(t, "0000000000", res.Output)
+	assert.True(t, res.Truncated)
+
+	_, err = run(t.Context(), Target{Name: "yes", Command: "sh", Args: []string{"-c", "printf '%0100d' 0"}, JSON: true}, time.Second, 10)
+	assert.Error(t, err, "truncated output should not be parsed as json")
+}
+
+func TestFlatten(t *testing.T) {
+	tests := []struct {
+		name string
+		data any
+		want map[string]float64
+	}{
+		{name: "no data", data: nil, want: map[string]float64{}},
+		{name: "single value", data: 42.0, want: map[string]float64{"value": 42}},
+		{
+			name: "nested values",
+			data: map[string]any{
+				"healthy": true,
+				"name":    "ignored",
+				"queue":   map[string]any{"size": 3.0, "workers": []any{1.0, false}},
+			},
+			want: map[string]float64{"healthy": 1, "queue.size": 3, "queue.workers.0": 1, "queue.workers.1": 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, flatten(tt.data))
+		})
+	}
+}
+
+func TestExec_UpdateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   checks.Runtime
+		want    Config
+		wantErr bool
+	}{
+		{
+			name: "simple config",
+			input: &Config{
+				Targets:  []Target{{Name: "true", Command: "true"}},
+				Interval: 10 * time.Second,
+				Timeout:  30 * time.Second,
+			},
+			want: Config{
+				Targets:  []Target{{Name: "true", Command: "true"}},
+				Interval: 10 * time.Second,
+				Timeout:  30 * time.Second,
+			},
+		},
+		{
+			name:    "wrong type",
+			input:   &health.Config{Targets: []health.Target{{URL: "https://example.com"}}},
+			want:    Config{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Exec{metrics: newMetrics()}
+			if err := c.UpdateConfig(tt.input); (err != nil) != tt.wantErr {
+				t.Errorf("Exec.UpdateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, c.config)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package exec
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/telekom/sparrow/pkg/checks"
+)
+
+const (
+	statusMetric   = "sparrow_exec_status"
+	exitCodeMetric = "sparrow_exec_exit_code"
+	durationMetric = "sparrow_exec_duration_seconds"
+	countMetric    = "sparrow_exec_check_count"
+	valueMetric    = "sparrow_exec_value"
+
+	labelKey = "key"
+)
+
+// metrics defines the metric collectors of the exec check
+type metrics struct {
+	status   *prometheus.GaugeVec
+	exitCode *prometheus.GaugeVec
+	duration *prometheus.GaugeVec
+	count    *prometheus.CounterVec
+	value    *prometheus.GaugeVec
+}
+
+// newMetrics initializes metric collectors of the exec check
+func newMetrics() metrics {
+	return metrics{
+		status: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: statusMetric,
+				Help: "Specifies if the command finished successfully.",
+			},
+			[]string{checks.LabelTarget},
+		),
+		exitCode: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: exitCodeMetric,
+				Help: "Exit code of the command. -1 if the command did not exit on its own.",
+			},
+			[]string{checks.LabelTarget},
+		),
+		duration: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: durationMetric,
+				Help: "Duration of the command in seconds.",
+			},
+			[]string{checks.LabelTarget},
+		),
+		count: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: countMetric,
+				Help: "Total number of runs of the command.",
+			},
+			[]string{checks.LabelTarget},
+		),
+		value: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: valueMetric,
+				Help: "Numeric values of the JSON output of the command by key.",
+			},
+			[]string{checks.LabelTarget, labelKey},
+		),
+	}
+}
+
+// GetCollectors returns all metric collectors
+func (m *metrics) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.status,
+		m.exitCode,
+		m.duration,
+		m.count,
+		m.value,
+	}
+}
+
+// Set sets the metrics of one target result
+func (m *metrics) Set(target string, res result) {
+	status := 0.0
+	if res.Success {
+		status = 1
+	}
+	m.status.WithLabelValues(target).Set(status)
+	m.exitCode.WithLabelValues(target).Set(float64(res.ExitCode))
+	m.duration.WithLabelValues(target).Set(res.Total)
+	m.count.WithLabelValues(target).Inc()
+
+	// Values of a previous run may no longer be part of the output
+	m.value.DeletePartialMatch(prometheus.Labels{checks.LabelTarget: target})
+	for key, v := range flatten(res.Output) {
+		m.value.WithLabelValues(target, key).Set(v)
+	}
+}
+
+// Remove removes the metrics of one target
+func (m *metrics) Remove(target string) error {
+	if !m.status.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+
+	if !m.exitCode.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+
+	if !m.duration.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+
+	if !m.count.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+
+	// The values are only set if the output is parsed as JSON
+	m.value.DeletePartialMatch(prometheus.Labels{checks.LabelTarget: target})
+
+	return nil
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package exec
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/telekom/sparrow/pkg/checks"
+)
+
+// Policy restricts the exec checks of runtime configurations from remote sources,
+// like the http and git loaders and the api, since the exec check runs arbitrary commands.
+type Policy struct {
+	// Enabled allows exec checks in runtime configurations from remote sources
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// Commands are the only commands exec checks from remote sources may run.
+	// The command and the arguments of a target must match one of them exactly
+	Commands []AllowedCommand `yaml:"commands" mapstructure:"commands"`
+}
+
+// AllowedCommand is a command with its arguments allowed by the policy
+type AllowedCommand struct {
+	// Command is the name or path of the executable
+	Command string `yaml:"command" mapstructure:"command"`
+	// Args are the arguments the command must be run with
+	Args []string `yaml:"args" mapstructure:"args"`
+}
+
+// matches returns true if the target runs the command with exactly its arguments
+func (a AllowedCommand) matches(t Target) bool {
+	return a.Command == t.Command && slices.Equal(a.Args, t.Args)
+}
+
+// Allows returns an error if the policy doesn't allow the exec check instance with the given name.
+// Environment variables are never allowed, since they can change what the command
+// runs, e.g. with PATH or LD_PRELOAD.
+func (p Policy) Allows(name string, c *Config) error {
+	if c == nil {
+		return nil
+	}
+	if !p.Enabled {
+		return checks.ErrInvalidConfig{CheckName: name, Field: "targets", Reason: "exec checks are not enabled for remote runtime configurations"}
+	}
+	for i, t := range c.Targets {
+		if len(t.Env) > 0 {
+			return checks.ErrInvalidConfig{CheckName: name, Field: fmt.Sprintf("targets[%d].env", i), Reason: "environment variables are not allowed for remote runtime configurations"}
+		}
+		if !slices.ContainsFunc(p.Commands, func(a AllowedCommand) bool { return a.matches(t) }) {
+			return checks.ErrInvalidConfig{CheckName: name, Field: fmt.Sprintf("targets[%d].command", i), Reason: fmt.Sprintf("command %q with args %q is not allowed", t.Command, t.Args)}
+		}
+	}
+	return nil
+}
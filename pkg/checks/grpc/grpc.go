@@ -0,0 +1,370 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/telekom/sparrow/internal/helper"
+	"github.com/telekom/sparrow/internal/logger"
+	"github.com/telekom/sparrow/pkg/checks"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	_ checks.Check   = (*GRPC)(nil)
+	_ checks.Runtime = (*Config)(nil)
+)
+
+const CheckName = "grpc"
+
+// GRPC is a check that queries the serving status of targets
+// using the grpc health checking protocol
+type GRPC struct {
+	checks.CheckBase
+	config  Config
+	metrics metrics
+	// schedule tracks when the targets are due
+	schedule checks.Scheduler[result]
+}
+
+func init() {
+	checks.MustRegister(CheckName, checks.Factory{
+		NewCheck:  NewCheck,
+		NewConfig: func() checks.Runtime { return &Config{} },
+	})
+}
+
+// NewCheck creates a new instance of the grpc check
+func NewCheck() checks.Check {
+	return &GRPC{
+		CheckBase: checks.CheckBase{
+			Mu:       sync.Mutex{},
+			DoneChan: make(chan struct{}, 1),
+		},
+		config: Config{
+			Retry: checks.DefaultRetry,
+		},
+		metrics: newMetrics(),
+	}
+}
+
+// result represents the result of a single grpc health check for a specific target
+type result struct {
+	Serving bool `json:"serving"`
+	// Status is the serving status reported by the target
+	Status string `json:"status,omitempty"`
+	// Code is the grpc status code of a failed call
+	Code  string  `json:"code,omitempty"`
+	Error *string `json:"error"`
+	Total float64 `json:"total"`
+}
+
+// Run starts the grpc check
+func (g *GRPC) Run(ctx context.Context, cResult chan checks.ResultDTO) error {
+	ctx, cancel := logger.NewContextWithLogger(ctx)
+	defer cancel()
+	log := logger.FromContext(ctx)
+
+	cfg := g.GetConfig().(*Config)
+
+	log.Info("Starting grpc check", "interval", cfg.Interval.String())
+	for {
+		select {
+		case <-ctx.Done():
+			log.Error("Context canceled", "err", ctx.Err())
+			return ctx.Err()
+		case <-g.DoneChan:
+			return nil
+		case <-time.After(g.schedule.Next(time.Now(), cfg.intervals(), cfg.Interval)):
+			res := g.check(ctx)
+
+			cResult <- checks.ResultDTO{
+				Name: g.Name(),
+				Result: &checks.Result{
+					Data:      res,
+					Timestamp: time.Now(),
+					Checked:   g.schedule.Checked(),
+				},
+			}
+			log.Debug("Successfully finished grpc check run")
+
+			// Re-read config in case it was updated
+			cfg = g.GetConfig().(*Config)
+		}
+	}
+}
+
+// Shutdown is called once when the check is unregistered or sparrow shuts down
+func (g *GRPC) Shutdown() {
+	g.DoneChan <- struct{}{}
+	close(g.DoneChan)
+}
+
+// UpdateConfig sets the configuration for the grpc check
+func (g *GRPC) UpdateConfig(cfg checks.Runtime) error {
+	if c, ok := cfg.(*Config); ok {
+		g.Mu.Lock()
+		defer g.Mu.Unlock()
+
+		for _, target := range g.config.Targets {
+			if !slices.ContainsFunc(c.Targets, func(n Target) bool { return n.Key() == target.Key() }) {
+				err := g.metrics.Remove(target.Key())
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		g.config = *c
+		return nil
+	}
+
+	return checks.ErrConfigMismatch{
+		Expected: CheckName,
+		Current:  cfg.For(),
+	}
+}
+
+// GetConfig returns a copy of the current configuration of the grpc check
+func (g *GRPC) GetConfig() checks.Runtime {
+	g.Mu.Lock()
+	defer g.Mu.Unlock()
+	// Return a copy to prevent race conditions when the config is read while being updated
+	configCopy := g.config
+	return &configCopy
+}
+
+// Name returns the name of the check
+func (g *GRPC) Name() string {
+	return CheckName
+}
+
+// Schema provides the schema of the data that will be provided
+// by the grpc check
+func (g *GRPC) Schema() (*openapi3.SchemaRef, error) {
+	return checks.OpenapiFromPerfData(make(map[string]result))
+}
+
+// GetMetricCollectors returns all metric collectors of check
+func (g *GRPC) GetMetricCollectors() []prometheus.Collector {
+	return g.metrics.GetCollectors()
+}
+
+// RemoveLabelledMetrics removes the metrics which have the passed
+// target as a label
+func (g *GRPC) RemoveLabelledMetrics(target string) error {
+	return g.metrics.Remove(target)
+}
+
+// check queries the serving status of all due targets using a retry function.
+// Returns a map where each target is associated with its grpc check result.
+func (g *GRPC) check(ctx context.Context) map[string]result {
+	log := logger.FromContext(ctx)
+	log.Debug("Checking grpc health")
+
+	// Get a copy of the config to avoid race conditions
+	cfg := g.GetConfig().(*Config)
+
+	due := g.schedule.Due(time.Now(), cfg.intervals())
+	if len(cfg.Targets) == 0 {
+		log.Debug("No targets defined")
+		return map[string]result{}
+	}
+
+	creds, cErr := transportCredentials(cfg.TLS)
+	if cErr != nil {
+		log.Error("Failed to load tls configuration", "error", cErr)
+	}
+
+	log.Debug("Querying each due target in separate routine", "amount", len(due))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := map[string]result{}
+
+	for _, target := range cfg.Targets {
+		key := target.Key()
+		if !due[key] {
+			continue
+		}
+		wg.Add(1)
+		lo := log.With("target", key)
+
+		probeRetry := helper.Retry(func(ctx context.Context) error {
+			res, err := probe(ctx, target, cfg.Watch != nil && *cfg.Watch, target.TimeoutOr(cfg.Timeout), creds)
+			mu.Lock()
+			defer mu.Unlock()
+			results[key] = res
+			return err
+		}, target.RetryOr(cfg.Retry))
+
+		go func() {
+			defer wg.Done()
+
+			if cErr != nil {
+				errval := cErr.Error()
+				mu.Lock()
+				defer mu.Unlock()
+				results[key] = result{Error: &errval}
+				g.metrics.Set(key, results[key])
+				return
+			}
+
+			lo.Debug("Starting retry routine to query target")
+			if err := probeRetry(ctx); err != nil {
+				lo.Warn("Error while querying serving status of target", "error", err)
+			}
+			lo.Debug("gRPC check completed for target")
+
+			mu.Lock()
+			defer mu.Unlock()
+			g.metrics.Set(key, results[key])
+		}()
+	}
+
+	log.Debug("Waiting for all routines to finish")
+	wg.Wait()
+
+	log.Debug("Finished grpc checks of all due targets")
+	return g.schedule.Merge(results)
+}
+
+// probe queries the serving status of the target using the Check or, if watch is set,
+// the first response of the Watch method of the grpc health service.
+// An error is returned if the call failed or the target is not serving.
+func probe(ctx context.Context, target Target, watch bool, timeout time.Duration, creds credentials.TransportCredentials) (result, error) {
+	log := logger.FromContext(ctx).With("address", target.Address, "service", target.Service)
+	var res result
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	fail := func(err error) (result, error) {
+		log.Debug("Error while querying serving status", "error", err)
+		errval := err.Error()
+		res.Error = &errval
+		return res, err
+	}
+
+	conn, err := grpc.NewClient(target.Address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return fail(err)
+	}
+	defer func() {
+		if cErr := conn.Close(); cErr != nil {
+			log.Debug("Failed to close grpc connection", "error", cErr)
+		}
+	}()
+
+	// The client connects lazily, so the connection is established before
+	// the call is timed to measure the duration of the call only
+	if err = connect(ctx, conn); err != nil {
+		res.Code = codes.Unavailable.String()
+		if ctx.Err() != nil {
+			res.Code = codes.DeadlineExceeded.String()
+		}
+		return fail(err)
+	}
+
+	client := healthpb.NewHealthClient(conn)
+	req := &healthpb.HealthCheckRequest{Service: target.Service}
+
+	start := time.Now()
+	var resp *healthpb.HealthCheckResponse
+	if watch {
+		var stream grpc.ServerStreamingClient[healthpb.HealthCheckResponse]
+		stream, err = client.Watch(ctx, req)
+		if err == nil {
+			resp, err = stream.Recv()
+		}
+	} else {
+		resp, err = client.Check(ctx, req)
+	}
+	res.Total = time.Since(start).Seconds()
+	if err != nil {
+		res.Code = status.Code(err).String()
+		return fail(err)
+	}
+
+	res.Status = resp.GetStatus().String()
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fail(fmt.Errorf("target is not serving: %s", res.Status))
+	}
+
+	res.Serving = true
+	return res, nil
+}
+
+// connect establishes the connection and waits until it is ready.
+// An error is returned if the connection fails or the context is done.
+func connect(ctx context.Context, conn *grpc.ClientConn) error {
+	conn.Connect()
+	for {
+		state := conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			return nil
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			return fmt.Errorf("failed to connect to target: connection state is %s", state)
+		}
+		if !conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("failed to connect to target: %w", ctx.Err())
+		}
+	}
+}
+
+// transportCredentials returns the credentials used for the connections to the targets
+func transportCredentials(c TLSConfig) (credentials.TransportCredentials, error) {
+	if !c.enabled() {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: c.ServerName,
+	}
+
+	if c.CaPath != "" {
+		b, err := os.ReadFile(c.CaPath) // #nosec G304 // path is provided by the runtime configuration
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ca file: %w", err)
+		}
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM(b) {
+			return nil, errors.New("no certificates found in ca file")
+		}
+		tlsCfg.RootCAs = roots
+	}
+
+	if c.CertPath != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertPath, c.KeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(tlsCfg), nil
+}
@@ -0,0 +1,234 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+	"encoding/pem"
+	"net"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telekom/sparrow/internal/helper"
+	"github.com/telekom/sparrow/pkg/checks"
+	"github.com/telekom/sparrow/pkg/checks/health"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// newHealthServer starts a grpc server with a health service reporting
+// the overall health as serving and the "orders" service as not serving
+func newHealthServer(t *testing.T) *grpc.Server {
+	t.Helper()
+	hs := grpchealth.NewServer()
+	hs.SetServingStatus("orders", healthpb.HealthCheckResponse_NOT_SERVING)
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, hs)
+	return srv
+}
+
+func TestGRPC_Run(t *testing.T) {
+	srv := newHealthServer(t)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+	addr := lis.Addr().String()
+
+	tests := []struct {
+		name    string
+		targets []Target
+		watch   bool
+		want    map[string]result
+	}{
+		{
+			name:    "success with no targets",
+			targets: []Target{},
+			want:    map[string]result{},
+		},
+		{
+			name:    "serving server",
+			targets: []Target{{Address: addr}},
+			want: map[string]result{
+				addr: {Serving: true, Status: "SERVING"},
+			},
+		},
+		{
+			name:    "not serving service and unknown service",
+			targets: []Target{{Address: addr, Service: "orders"}, {Address: addr, Service: "unknown"}},
+			want: map[string]result{
+				addr + "/orders":  {Status: "NOT_SERVING"},
+				addr + "/unknown": {Code: codes.NotFound.String()},
+			},
+		},
+		{
+			name:    "watch",
+			targets: []Target{{Address: addr}, {Address: addr, Service: "unknown"}},
+			watch:   true,
+			want: map[string]result{
+				addr:              {Serving: true, Status: "SERVING"},
+				addr + "/unknown": {Status: "SERVICE_UNKNOWN"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCheck()
+			cResult := make(chan checks.ResultDTO, 1)
+			defer close(cResult)
+
+			err := c.UpdateConfig(&Config{
+				Targets:  tt.targets,
+				Interval: 100 * time.Millisecond,
+				Timeout:  time.Second,
+				Retry:    helper.RetryConfig{Count: 0},
+				Watch:    &tt.watch,
+			})
+			require.NoError(t, err)
+
+			go func() {
+				err := c.Run(context.Background(), cResult)
+				if err != nil {
+					t.Errorf("GRPC.Run() error = %v", err)
+				}
+			}()
+			defer c.Shutdown()
+
+			r := <-cResult
+			got, ok := r.Result.Data.(map[string]result)
+			require.True(t, ok, "GRPC.Run() result data has wrong type %T", r.Result.Data)
+			assert.Len(t, got, len(tt.want))
+			for target, want := range tt.want {
+				assert.Equal(t, want.Serving, got[target].Serving, "serving of %s", target)
+				assert.Equal(t, want.Status, got[target].Status, "status of %s", target)
+				assert.Equal(t, want.Code, got[target].Code, "code of %s", target)
+				if !want.Serving {
+					assert.NotNil(t, got[target].Error, "error of %s", target)
+				}
+			}
+		})
+	}
+}
+
+func TestProbe_tls(t *testing.T) {
+	gs := newHealthServer(t)
+	srv := httptest.NewUnstartedServer(gs)
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+	target := Target{Address: srv.Listener.Addr().String()}
+
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caPath, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: srv.Certificate().Raw,
+	}), 0o600))
+
+	creds, err := transportCredentials(TLSConfig{Enabled: new(true)})
+	require.NoError(t, err)
+	res, err := probe(t.Context(), target, false, time.Second, creds)
+	assert.Error(t, err, "untrusted certificate should fail")
+	assert.False(t, res.Serving)
+
+	creds, err = transportCredentials(TLSConfig{Enabled: new(true), CaPath: caPath, ServerName: "example.com"})
+	require.NoError(t, err)
+	res, err = probe(t.Context(), target, false, time.Second, creds)
+	require.NoError(t, err)
+	assert.True(t, res.Serving)
+
+	_, err = transportCredentials(TLSConfig{Enabled: new(true), CertPath: caPath, KeyPath: filepath.Join(t.TempDir(), "missing.key")})
+	assert.Error(t, err, "missing client key should fail")
+}
+
+// slowListener delays accepting connections to slow down the connection establishment
+type slowListener struct {
+	net.Listener
+	delay time.Duration
+}
+
+func (l slowListener) Accept() (net.Conn, error) {
+	time.Sleep(l.delay)
+	return l.Listener.Accept()
+}
+
+func TestProbe_connect(t *testing.T) {
+	creds, err := transportCredentials(TLSConfig{})
+	require.NoError(t, err)
+
+	t.Run("connection is not timed", func(t *testing.T) {
+		const delay = 500 * time.Millisecond
+		srv := newHealthServer(t)
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		go func() { _ = srv.Serve(slowListener{Listener: lis, delay: delay}) }()
+		defer srv.Stop()
+
+		start := time.Now()
+		res, err := probe(t.Context(), Target{Address: lis.Addr().String()}, false, 5*time.Second, creds)
+		require.NoError(t, err)
+		assert.True(t, res.Serving)
+		assert.GreaterOrEqual(t, time.Since(start), delay)
+		assert.Less(t, res.Total, delay.Seconds())
+	})
+
+	t.Run("unreachable target", func(t *testing.T) {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := lis.Addr().String()
+		require.NoError(t, lis.Close())
+
+		res, err := probe(t.Context(), Target{Address: addr}, false, time.Second, creds)
+		assert.Error(t, err)
+		assert.Equal(t, codes.Unavailable.String(), res.Code)
+		assert.NotNil(t, res.Error)
+		assert.Zero(t, res.Total)
+	})
+}
+
+func TestGRPC_UpdateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   checks.Runtime
+		want    Config
+		wantErr bool
+	}{
+		{
+			name: "simple config",
+			input: &Config{
+				Targets:  []Target{{Address: "example.com:50051"}},
+				Interval: 10 * time.Second,
+				Timeout:  30 * time.Second,
+			},
+			want: Config{
+				Targets:  []Target{{Address: "example.com:50051"}},
+				Interval: 10 * time.Second,
+				Timeout:  30 * time.Second,
+			},
+		},
+		{
+			name:    "wrong type",
+			input:   &health.Config{Targets: []health.Target{{URL: "https://example.com"}}},
+			want:    Config{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &GRPC{metrics: newMetrics()}
+			if err := c.UpdateConfig(tt.input); (err != nil) != tt.wantErr {
+				t.Errorf("GRPC.UpdateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, c.config)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/telekom/sparrow/pkg/checks"
+)
+
+const (
+	statusMetric    = "sparrow_grpc_status"
+	durationMetric  = "sparrow_grpc_duration_seconds"
+	countMetric     = "sparrow_grpc_check_count"
+	histogramMetric = "sparrow_grpc_duration"
+)
+
+// metrics defines the metric collectors of the grpc check
+type metrics struct {
+	status    *prometheus.GaugeVec
+	duration  *prometheus.GaugeVec
+	count     *prometheus.CounterVec
+	histogram *prometheus.HistogramVec
+}
+
+// newMetrics initializes metric collectors of the grpc check
+func newMetrics() metrics {
+	return metrics{
+		status: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: statusMetric,
+				Help: "Specifies if the target reports the serving status SERVING.",
+			},
+			[]string{checks.LabelTarget},
+		),
+		duration: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: durationMetric,
+				Help: "Duration of the grpc health check call in seconds.",
+			},
+			[]string{checks.LabelTarget},
+		),
+		count: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: countMetric,
+				Help: "Total number of grpc health checks performed on the target.",
+			},
+			[]string{checks.LabelTarget},
+		),
+		histogram: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name: histogramMetric,
+				Help: "Histogram of grpc health check call durations in seconds.",
+			},
+			[]string{checks.LabelTarget},
+		),
+	}
+}
+
+// GetCollectors returns all metric collectors
+func (m *metrics) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.status,
+		m.duration,
+		m.count,
+		m.histogram,
+	}
+}
+
+// Set sets the metrics of one target result
+func (m *metrics) Set(target string, res result) {
+	status := 0.0
+	if res.Serving {
+		status = 1
+	}
+	if res.Status != "" {
+		m.histogram.WithLabelValues(target).Observe(res.Total)
+	}
+	m.duration.WithLabelValues(target).Set(res.Total)
+	m.status.WithLabelValues(target).Set(status)
+	m.count.WithLabelValues(target).Inc()
+}
+
+// Remove removes the metrics of one target
+func (m *metrics) Remove(target string) error {
+	if !m.status.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+
+	if !m.duration.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+
+	if !m.count.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+
+	// The histogram is only set if the target responded
+	m.histogram.DeleteLabelValues(target)
+
+	return nil
+}
@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package health
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Assertion is a check on the response body of a health request.
+// Exactly one of Contains, Regex or JSONPath must be set.
+type Assertion struct {
+	// Contains asserts that the body contains the given substring
+	Contains string `json:"contains,omitempty" yaml:"contains,omitempty"`
+	// Regex asserts that the body matches the given regular expression
+	Regex string `json:"regex,omitempty" yaml:"regex,omitempty"`
+	// JSONPath selects a value of a JSON body, e.g. "$.status" or "$.items[0].name".
+	// If Equals is set, the selected value must be equal to it, otherwise it must exist.
+	JSONPath string `json:"jsonPath,omitempty" yaml:"jsonPath,omitempty"`
+	// Equals is the expected value selected by JSONPath
+	Equals any `json:"equals,omitempty" yaml:"equals,omitempty"`
+}
+
+// validate checks if the assertion is valid
+func (a *Assertion) validate() error {
+	set := 0
+	for _, v := range []string{a.Contains, a.Regex, a.JSONPath} {
+		if v != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return errors.New("exactly one of 'contains', 'regex' or 'jsonPath' must be set")
+	}
+
+	if a.Regex != "" {
+		if _, err := regexp.Compile(a.Regex); err != nil {
+			return fmt.Errorf("invalid regex: %w", err)
+		}
+	}
+
+	if a.JSONPath != "" {
+		if _, err := parseJSONPath(a.JSONPath); err != nil {
+			return err
+		}
+	}
+
+	if a.Equals != nil && a.JSONPath == "" {
+		return errors.New("'equals' can only be used with 'jsonPath'")
+	}
+	return nil
+}
+
+// evaluate returns an error if the body does not satisfy the assertion
+func (a *Assertion) evaluate(body []byte) error {
+	switch {
+	case a.Contains != "":
+		if !bytes.Contains(body, []byte(a.Contains)) {
+			return fmt.Errorf("body does not contain %q", a.Contains)
+		}
+	case a.Regex != "":
+		re, err := regexp.Compile(a.Regex)
+		if err != nil {
+			return fmt.Errorf("invalid regex: %w", err)
+		}
+		if !re.Match(body) {
+			return fmt.Errorf("body does not match %q", a.Regex)
+		}
+	case a.JSONPath != "":
+		return a.evaluateJSONPath(body)
+	}
+	return nil
+}
+
+// evaluateJSONPath selects the value of the JSONPath and compares it to the expected value
+func (a *Assertion) evaluateJSONPath(body []byte) error {
+	segments, err := parseJSONPath(a.JSONPath)
+	if err != nil {
+		return err
+	}
+
+	var doc any
+	if err = json.Unmarshal(body, &doc); err != nil {
+		return fmt.Errorf("body is not valid json: %w", err)
+	}
+
+	got, err := lookupJSONPath(doc, segments)
+	if err != nil {
+		return fmt.Errorf("json path %q: %w", a.JSONPath, err)
+	}
+	if a.Equals == nil {
+		return nil
+	}
+
+	// Normalize the expected value, so numbers are compared as float64 like the decoded body
+	b, err := json.Marshal(a.Equals)
+	if err != nil {
+		return fmt.Errorf("invalid expected value: %w", err)
+	}
+	var want any
+	if err = json.Unmarshal(b, &want); err != nil {
+		return fmt.Errorf("invalid expected value: %w", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		return fmt.Errorf("json path %q is %v, expected %v", a.JSONPath, got, want)
+	}
+	return nil
+}
+
+// jsonPathSegment is a single step of a JSONPath, either an object key or an array index
+type jsonPathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// parseJSONPath parses the supported subset of JSONPath: the root "$"
+// followed by ".key", "['key']" and "[index]" segments.
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	rest, ok := strings.CutPrefix(path, "$")
+	if !ok {
+		return nil, fmt.Errorf("json path %q must start with '$'", path)
+	}
+
+	var segments []jsonPathSegment
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("empty key in json path %q", path)
+			}
+			segments = append(segments, jsonPathSegment{key: rest[:end]})
+			rest = rest[end:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("unclosed bracket in json path %q", path)
+			}
+			inner := rest[1:end]
+			rest = rest[end+1:]
+
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				segments = append(segments, jsonPathSegment{key: inner[1 : len(inner)-1]})
+				continue
+			}
+			idx, err := strconv.Atoi(inner)
+			if err != nil || idx < 0 {
+				return nil, fmt.Errorf("invalid index %q in json path %q", inner, path)
+			}
+			segments = append(segments, jsonPathSegment{index: idx, isIndex: true})
+		default:
+			return nil, fmt.Errorf("unexpected character %q in json path %q", rest[0], path)
+		}
+	}
+	return segments, nil
+}
+
+// lookupJSONPath returns the value of the decoded json document at the given path
+func lookupJSONPath(doc any, segments []jsonPathSegment) (any, error) {
+	cur := doc
+	for _, s := range segments {
+		if s.isIndex {
+			arr, ok := cur.([]any)
+			if !ok {
+				return nil, fmt.Errorf("cannot index non-array with [%d]", s.index)
+			}
+			if s.index >= len(arr) {
+				return nil, fmt.Errorf("index [%d] out of range", s.index)
+			}
+			cur = arr[s.index]
+			continue
+		}
+
+		obj, ok := cur.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("cannot select key %q of non-object", s.key)
+		}
+		if cur, ok = obj[s.key]; !ok {
+			return nil, fmt.Errorf("key %q not found", s.key)
+		}
+	}
+	return cur, nil
+}
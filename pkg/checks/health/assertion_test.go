@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package health
+
+import (
+	"testing"
+)
+
+func TestAssertion_evaluate(t *testing.T) {
+	body := []byte(`{"status":"ok","checks":[{"name":"db","healthy":true},{"name":"cache","latency":3}],"build.info":{"version":"1.2.3"}}`)
+
+	tests := []struct {
+		name      string
+		assertion Assertion
+		wantErr   bool
+	}{
+		{name: "contains", assertion: Assertion{Contains: `"status":"ok"`}},
+		{name: "contains fails", assertion: Assertion{Contains: "degraded"}, wantErr: true},
+		{name: "regex", assertion: Assertion{Regex: `"status":"(ok|up)"`}},
+		{name: "regex fails", assertion: Assertion{Regex: `^ok$`}, wantErr: true},
+		{name: "json path equals string", assertion: Assertion{JSONPath: "$.status", Equals: "ok"}},
+		{name: "json path equals bool in array", assertion: Assertion{JSONPath: "$.checks[0].healthy", Equals: true}},
+		{name: "json path equals int", assertion: Assertion{JSONPath: "$.checks[1].latency", Equals: 3}},
+		{name: "json path bracket key", assertion: Assertion{JSONPath: "$['build.info'].version", Equals: "1.2.3"}},
+		{name: "json path exists", assertion: Assertion{JSONPath: "$.checks[1].name"}},
+		{name: "json path not equal", assertion: Assertion{JSONPath: "$.status", Equals: "degraded"}, wantErr: true},
+		{name: "json path missing key", assertion: Assertion{JSONPath: "$.missing"}, wantErr: true},
+		{name: "json path index out of range", assertion: Assertion{JSONPath: "$.checks[5]"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.assertion.evaluate(body); (err != nil) != tt.wantErr {
+				t.Errorf("Assertion.evaluate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseJSONPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		wantErr bool
+	}{
+		{path: "$"},
+		{path: "$.a.b[0]"},
+		{path: `$["a"][1].c`},
+		{path: "a.b", wantErr: true},
+		{path: "$..a", wantErr: true},
+		{path: "$.a[", wantErr: true},
+		{path: "$.a[x]", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if _, err := parseJSONPath(tt.path); (err != nil) != tt.wantErr {
+				t.Errorf("parseJSONPath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -6,11 +6,15 @@ package health
 
 import (
 	"fmt"
+	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/telekom/sparrow/internal/helper"
 	"github.com/telekom/sparrow/pkg/checks"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -20,12 +24,80 @@ const (
 
 // Config defines the configuration parameters for a health check
 type Config struct {
-	Targets  []string           `json:"targets,omitempty" yaml:"targets,omitempty"`
+	Targets  []Target           `json:"targets,omitempty" yaml:"targets,omitempty"`
 	Interval time.Duration      `json:"interval" yaml:"interval"`
 	Timeout  time.Duration      `json:"timeout" yaml:"timeout"`
 	Retry    helper.RetryConfig `json:"retry" yaml:"retry"`
 }
 
+// Target defines a health check target and how it is requested.
+// A target can be configured as a plain URL string, in which case
+// a GET request is sent and only status 200 is accepted.
+type Target struct {
+	// URL is the url of the target
+	URL string `json:"url" yaml:"url"`
+	// Method is the HTTP method of the request. Defaults to GET
+	Method string `json:"method,omitempty" yaml:"method,omitempty"`
+	// Headers are additional headers sent with the request
+	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	// Body is the body sent with the request
+	Body string `json:"body,omitempty" yaml:"body,omitempty"`
+	// ExpectedStatus is a list of accepted status codes or
+	// status code ranges like "200-299". Defaults to 200
+	ExpectedStatus []string `json:"expectedStatus,omitempty" yaml:"expectedStatus,omitempty"`
+	// Assertions are optional checks on the response body
+	Assertions []Assertion `json:"assertions,omitempty" yaml:"assertions,omitempty"`
+	// TargetOverrides optionally override the interval, timeout and retry of the check
+	checks.TargetOverrides `yaml:",inline"`
+}
+
+// targetFields is used to (un)marshal a Target without its custom (un)marshalers
+type targetFields Target
+
+// plainTarget allows targets to be configured as a plain URL string
+var plainTarget = checks.PlainTarget[Target, targetFields]{
+	Key:     func(t *Target) *string { return &t.URL },
+	IsPlain: Target.isPlain,
+}
+
+// isPlain returns true if the target only consists of its URL
+func (t Target) isPlain() bool {
+	return t.Method == "" && len(t.Headers) == 0 && t.Body == "" && len(t.ExpectedStatus) == 0 && len(t.Assertions) == 0 && t.IsZero()
+}
+
+// UnmarshalYAML allows targets to be either a plain URL string or an object
+func (t *Target) UnmarshalYAML(value *yaml.Node) error {
+	return plainTarget.DecodeYAML(value, t)
+}
+
+// MarshalYAML marshals targets without request options and overrides as a plain URL string
+func (t Target) MarshalYAML() (any, error) {
+	return plainTarget.EncodeYAML(t)
+}
+
+// UnmarshalJSON allows targets to be either a plain URL string or an object
+func (t *Target) UnmarshalJSON(b []byte) error {
+	return plainTarget.DecodeJSON(b, t)
+}
+
+// MarshalJSON marshals targets without request options and overrides as a plain URL string
+func (t Target) MarshalJSON() ([]byte, error) {
+	return plainTarget.EncodeJSON(t)
+}
+
+// intervals returns the check interval of every target
+func (c *Config) intervals() map[string]time.Duration {
+	return checks.Intervals(c.Targets, func(t Target) string { return t.URL }, c.Interval)
+}
+
+// method returns the HTTP method of the target's request
+func (t *Target) method() string {
+	if t.Method == "" {
+		return http.MethodGet
+	}
+	return strings.ToUpper(t.Method)
+}
+
 // For returns the name of the check
 func (c *Config) For() string {
 	return CheckName
@@ -33,15 +105,36 @@ func (c *Config) For() string {
 
 // Validate checks if the configuration is valid
 func (c *Config) Validate() error {
-	for _, t := range c.Targets {
-		u, err := url.Parse(t)
+	urls := make(map[string]bool, len(c.Targets))
+	for i, t := range c.Targets {
+		u, err := url.Parse(t.URL)
 		if err != nil {
 			return checks.ErrInvalidConfig{CheckName: c.For(), Field: "targets", Reason: "invalid target URL"}
 		}
 
+		// results and metrics are keyed by the url, so targets with the same url would overwrite each other
+		if urls[t.URL] {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("targets[%d].url", i), Reason: fmt.Sprintf("url %q is not unique", t.URL)}
+		}
+		urls[t.URL] = true
+
 		if u.Scheme != "https" && u.Scheme != "http" {
 			return checks.ErrInvalidConfig{CheckName: c.For(), Field: "targets", Reason: "target URLs must start with 'https://' or 'http://'"}
 		}
+
+		if _, err := parseStatusRanges(t.ExpectedStatus); err != nil {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("targets[%d].expectedStatus", i), Reason: err.Error()}
+		}
+
+		for j, a := range t.Assertions {
+			if err := a.validate(); err != nil {
+				return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("targets[%d].assertions[%d]", i, j), Reason: err.Error()}
+			}
+		}
+
+		if err := t.Validate(c.For(), fmt.Sprintf("targets[%d]", i), minInterval, minTimeout); err != nil {
+			return err
+		}
 	}
 
 	if c.Interval < minInterval {
@@ -54,3 +147,52 @@ func (c *Config) Validate() error {
 
 	return nil
 }
+
+// statusRange is an inclusive range of accepted status codes
+type statusRange struct {
+	min, max int
+}
+
+// contains returns true if the status code is within the range
+func (r statusRange) contains(code int) bool {
+	return code >= r.min && code <= r.max
+}
+
+// parseStatusRanges parses a list of status codes or status code ranges like "200-299".
+// If the list is empty, only status 200 is accepted.
+func parseStatusRanges(codes []string) ([]statusRange, error) {
+	if len(codes) == 0 {
+		return []statusRange{{min: http.StatusOK, max: http.StatusOK}}, nil
+	}
+
+	ranges := make([]statusRange, 0, len(codes))
+	for _, c := range codes {
+		lo, hi, isRange := strings.Cut(strings.TrimSpace(c), "-")
+		if !isRange {
+			hi = lo
+		}
+
+		minCode, err := parseStatusCode(lo)
+		if err != nil {
+			return nil, err
+		}
+		maxCode, err := parseStatusCode(hi)
+		if err != nil {
+			return nil, err
+		}
+		if minCode > maxCode {
+			return nil, fmt.Errorf("invalid status code range %q", c)
+		}
+		ranges = append(ranges, statusRange{min: minCode, max: maxCode})
+	}
+	return ranges, nil
+}
+
+// parseStatusCode parses a single HTTP status code
+func parseStatusCode(s string) (int, error) {
+	code, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || code < 100 || code > 599 {
+		return 0, fmt.Errorf("invalid status code %q", s)
+	}
+	return code, nil
+}
@@ -5,8 +5,13 @@
 package health
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestConfig_Validate(t *testing.T) {
@@ -18,7 +23,7 @@ func TestConfig_Validate(t *testing.T) {
 		{
 			name: "valid config",
 			config: Config{
-				Targets:  []string{"http://localhost:8080"},
+				Targets:  []Target{{URL: "http://localhost:8080"}},
 				Interval: 100 * time.Millisecond,
 				Timeout:  1 * time.Second,
 			},
@@ -27,7 +32,7 @@ func TestConfig_Validate(t *testing.T) {
 		{
 			name: "invalid targets - invalid url",
 			config: Config{
-				Targets:  []string{"://localhost:8080"},
+				Targets:  []Target{{URL: "://localhost:8080"}},
 				Interval: 100 * time.Millisecond,
 				Timeout:  1 * time.Second,
 			},
@@ -36,7 +41,16 @@ func TestConfig_Validate(t *testing.T) {
 		{
 			name: "invalid targets - invalid scheme",
 			config: Config{
-				Targets:  []string{"localhost:8080"},
+				Targets:  []Target{{URL: "localhost:8080"}},
+				Interval: 100 * time.Millisecond,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid targets - duplicate url",
+			config: Config{
+				Targets:  []Target{{URL: "http://localhost:8080"}, {URL: "http://localhost:8080", Method: "POST"}},
 				Interval: 100 * time.Millisecond,
 				Timeout:  1 * time.Second,
 			},
@@ -45,7 +59,7 @@ func TestConfig_Validate(t *testing.T) {
 		{
 			name: "invalid interval",
 			config: Config{
-				Targets:  []string{"http://localhost:8080"},
+				Targets:  []Target{{URL: "http://localhost:8080"}},
 				Interval: 10 * time.Millisecond,
 				Timeout:  1 * time.Second,
 			},
@@ -54,12 +68,57 @@ func TestConfig_Validate(t *testing.T) {
 		{
 			name: "invalid timeout",
 			config: Config{
-				Targets:  []string{"http://localhost:8080"},
+				Targets:  []Target{{URL: "http://localhost:8080"}},
 				Interval: 100 * time.Millisecond,
 				Timeout:  100 * time.Millisecond,
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid request options",
+			config: Config{
+				Targets: []Target{{
+					URL:            "http://localhost:8080/status",
+					Method:         "POST",
+					ExpectedStatus: []string{"204", "200-299"},
+					Assertions: []Assertion{
+						{Contains: "ok"},
+						{Regex: "^ok$"},
+						{JSONPath: "$.status", Equals: "ok"},
+					},
+				}},
+				Interval: 100 * time.Millisecond,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid expected status",
+			config: Config{
+				Targets:  []Target{{URL: "http://localhost:8080", ExpectedStatus: []string{"299-200"}}},
+				Interval: 100 * time.Millisecond,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid assertion - multiple kinds",
+			config: Config{
+				Targets:  []Target{{URL: "http://localhost:8080", Assertions: []Assertion{{Contains: "ok", Regex: "ok"}}}},
+				Interval: 100 * time.Millisecond,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid assertion - regex",
+			config: Config{
+				Targets:  []Target{{URL: "http://localhost:8080", Assertions: []Assertion{{Regex: "("}}}},
+				Interval: 100 * time.Millisecond,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -71,3 +130,57 @@ func TestConfig_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestTarget_Unmarshal(t *testing.T) {
+	want := Config{
+		Targets: []Target{
+			{URL: "https://example.com"},
+			{
+				URL:            "https://example.com/status",
+				Method:         "HEAD",
+				Headers:        map[string]string{"Authorization": "Bearer token"},
+				ExpectedStatus: []string{"204", "200-299"},
+				Assertions:     []Assertion{{JSONPath: "$.status", Equals: "ok"}},
+			},
+		},
+	}
+
+	t.Run("yaml", func(t *testing.T) {
+		in := `
+targets:
+  - https://example.com
+  - url: https://example.com/status
+    method: HEAD
+    headers:
+      Authorization: Bearer token
+    expectedStatus: [204, "200-299"]
+    assertions:
+      - jsonPath: $.status
+        equals: ok
+`
+		var got Config
+		require.NoError(t, yaml.Unmarshal([]byte(in), &got))
+		assert.Equal(t, want, got)
+
+		out, err := yaml.Marshal(got)
+		require.NoError(t, err)
+		var roundtrip Config
+		require.NoError(t, yaml.Unmarshal(out, &roundtrip))
+		assert.Equal(t, want, roundtrip)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		in := `{"targets": ["https://example.com", {"url": "https://example.com/status", "method": "HEAD",
+			"headers": {"Authorization": "Bearer token"}, "expectedStatus": ["204", "200-299"],
+			"assertions": [{"jsonPath": "$.status", "equals": "ok"}]}]}`
+		var got Config
+		require.NoError(t, json.Unmarshal([]byte(in), &got))
+		assert.Equal(t, want, got)
+
+		out, err := json.Marshal(got)
+		require.NoError(t, err)
+		var roundtrip Config
+		require.NoError(t, json.Unmarshal(out, &roundtrip))
+		assert.Equal(t, want, roundtrip)
+	})
+}
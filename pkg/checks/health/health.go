@@ -10,6 +10,7 @@ import (
 	"io"
 	"net/http"
 	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -37,11 +38,23 @@ var (
 
 const CheckName = "health"
 
+// maxBodySize is the maximum number of bytes of a response body read for assertions
+const maxBodySize = 1 << 20
+
 // Health is a check that measures the availability of an endpoint
 type Health struct {
 	checks.CheckBase
 	config  Config
 	metrics metrics
+	// schedule tracks when the targets are due
+	schedule checks.Scheduler[string]
+}
+
+func init() {
+	checks.MustRegister(CheckName, checks.Factory{
+		NewCheck:  NewCheck,
+		NewConfig: func() checks.Runtime { return &Config{} },
+	})
 }
 
 // NewCheck creates a new instance of the health check
@@ -64,9 +77,9 @@ func (h *Health) Run(ctx context.Context, cResult chan checks.ResultDTO) error {
 	defer cancel()
 	log := logger.FromContext(ctx)
 
-	interval := h.GetConfig().(*Config).Interval
+	cfg := h.GetConfig().(*Config)
 
-	log.Info("Starting health check", "interval", interval.String())
+	log.Info("Starting health check", "interval", cfg.Interval.String())
 	for {
 		select {
 		case <-ctx.Done():
@@ -75,7 +88,7 @@ func (h *Health) Run(ctx context.Context, cResult chan checks.ResultDTO) error {
 		case <-h.DoneChan:
 			log.Debug("Soft shut down")
 			return nil
-		case <-time.After(interval):
+		case <-time.After(h.schedule.Next(time.Now(), cfg.intervals(), cfg.Interval)):
 			res := h.check(ctx)
 
 			cResult <- checks.ResultDTO{
@@ -83,12 +96,13 @@ func (h *Health) Run(ctx context.Context, cResult chan checks.ResultDTO) error {
 				Result: &checks.Result{
 					Data:      res,
 					Timestamp: time.Now(),
+					Checked:   h.schedule.Checked(),
 				},
 			}
 			log.Debug("Successfully finished health check run")
 
-			// Re-read interval in case config was updated
-			interval = h.GetConfig().(*Config).Interval
+			// Re-read config in case it was updated
+			cfg = h.GetConfig().(*Config)
 		}
 	}
 }
@@ -106,8 +120,8 @@ func (h *Health) UpdateConfig(cfg checks.Runtime) error {
 		defer h.Mu.Unlock()
 
 		for _, target := range h.config.Targets {
-			if !slices.Contains(c.Targets, target) {
-				err := h.metrics.Remove(target)
+			if !slices.ContainsFunc(c.Targets, func(t Target) bool { return t.URL == target.URL }) {
+				err := h.metrics.Remove(target.URL)
 				if err != nil {
 					return err
 				}
@@ -166,27 +180,32 @@ func (h *Health) check(ctx context.Context) map[string]string {
 	// Get a copy of the config to avoid race conditions
 	cfg := h.GetConfig().(*Config)
 
+	due := h.schedule.Due(time.Now(), cfg.intervals())
 	if len(cfg.Targets) == 0 {
 		log.Debug("No targets defined")
 		return map[string]string{}
 	}
-	log.Debug("Getting health status for each target in separate routine", "amount", len(cfg.Targets))
+	log.Debug("Getting health status for each due target in separate routine", "amount", len(due))
 
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 	results := map[string]string{}
 
-	client := &http.Client{
-		Timeout: cfg.Timeout,
-	}
 	for _, t := range cfg.Targets {
-		target := t
+		if !due[t.URL] {
+			continue
+		}
+		target := t.URL
 		wg.Add(1)
 		l := log.With("target", target)
 
+		client := &http.Client{
+			Timeout: t.TimeoutOr(cfg.Timeout),
+		}
+		retry := t.RetryOr(cfg.Retry)
 		getHealthRetry := helper.Retry(func(ctx context.Context) error {
-			return getHealth(ctx, client, target)
-		}, cfg.Retry)
+			return getHealth(ctx, client, t)
+		}, retry)
 
 		go func() {
 			defer wg.Done()
@@ -195,7 +214,7 @@ func (h *Health) check(ctx context.Context) map[string]string {
 			l.Debug("Starting retry routine to get health status")
 			if err := getHealthRetry(ctx); err != nil {
 				state = 0
-				l.Warn(fmt.Sprintf("Health check failed after %d retries", cfg.Retry.Count), "error", err)
+				l.Warn(fmt.Sprintf("Health check failed after %d retries", retry.Count), "error", err)
 			}
 
 			l.Debug("Successfully got health status of target", "status", stateMapping[state])
@@ -210,19 +229,32 @@ func (h *Health) check(ctx context.Context) map[string]string {
 	log.Debug("Waiting for all routines to finish")
 	wg.Wait()
 
-	log.Debug("Successfully got health status from all targets")
-	return results
+	log.Debug("Successfully got health status from all due targets")
+	return h.schedule.Merge(results)
 }
 
-// getHealth performs an HTTP get request and returns ok if status code is 200
-func getHealth(ctx context.Context, client *http.Client, url string) error {
-	log := logger.FromContext(ctx).With("url", url)
+// getHealth performs the HTTP request of the target and returns an error
+// if the response status is not accepted or an assertion on the body fails
+func getHealth(ctx context.Context, client *http.Client, target Target) error {
+	log := logger.FromContext(ctx).With("url", target.URL)
+
+	var body io.Reader = http.NoBody
+	if target.Body != "" {
+		body = strings.NewReader(target.Body)
+	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	req, err := http.NewRequestWithContext(ctx, target.method(), target.URL, body)
 	if err != nil {
 		log.Error("Error while creating request", "error", err)
 		return err
 	}
+	for k, v := range target.Headers {
+		if strings.EqualFold(k, "Host") {
+			req.Host = v
+			continue
+		}
+		req.Header.Set(k, v)
+	}
 
 	resp, err := client.Do(req) //nolint:bodyclose // Closed in defer below
 	if err != nil {
@@ -236,10 +268,31 @@ func getHealth(ctx context.Context, client *http.Client, url string) error {
 		}
 	}(resp.Body)
 
-	if resp.StatusCode != http.StatusOK {
-		log.Warn("Health request was not ok (HTTP Status 200)", "status", resp.Status)
+	accepted, err := parseStatusRanges(target.ExpectedStatus)
+	if err != nil {
+		log.Error("Invalid expected status codes", "error", err)
+		return err
+	}
+	if !slices.ContainsFunc(accepted, func(r statusRange) bool { return r.contains(resp.StatusCode) }) {
+		log.Warn("Health request returned an unexpected status", "status", resp.Status, "expected", target.ExpectedStatus)
 		return fmt.Errorf("request failed, status is %s", resp.Status)
 	}
 
+	if len(target.Assertions) == 0 {
+		return nil
+	}
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		log.Error("Failed to read response body", "error", err)
+		return err
+	}
+	for _, a := range target.Assertions {
+		if err := a.evaluate(b); err != nil {
+			log.Warn("Health response assertion failed", "error", err)
+			return fmt.Errorf("assertion failed: %w", err)
+		}
+	}
+
 	return nil
 }
@@ -6,6 +6,7 @@ package health
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"testing"
 	"time"
@@ -27,12 +28,12 @@ func TestHealth_UpdateConfig(t *testing.T) {
 		{
 			name: "simple config",
 			inputConfig: &Config{
-				Targets:  []string{"test"},
+				Targets:  []Target{{URL: "test"}},
 				Interval: 10 * time.Second,
 				Timeout:  30 * time.Second,
 			},
 			expectedConfig: Config{
-				Targets:  []string{"test"},
+				Targets:  []Target{{URL: "test"}},
 				Interval: 10 * time.Second,
 				Timeout:  30 * time.Second,
 			},
@@ -47,7 +48,7 @@ func TestHealth_UpdateConfig(t *testing.T) {
 		{
 			name: "wrong type",
 			inputConfig: &latency.Config{
-				Targets: []string{"test"},
+				Targets: []latency.Target{{URL: "test"}},
 			},
 			expectedConfig: Config{},
 			wantErr:        true,
@@ -128,7 +129,77 @@ func Test_getHealth(t *testing.T) {
 	for _, tt := range tests {
 		httpmock.RegisterResponder(http.MethodGet, endpoint, tt.httpResponder)
 		t.Run(tt.name, func(t *testing.T) {
-			if err := getHealth(tt.args.ctx, tt.args.client, tt.args.url); (err != nil) != tt.wantErr {
+			if err := getHealth(tt.args.ctx, tt.args.client, Target{URL: tt.args.url}); (err != nil) != tt.wantErr {
+				t.Errorf("getHealth() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_getHealth_requestOptions(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	endpoint := "https://api.test.com/status"
+
+	tests := []struct {
+		name      string
+		target    Target
+		responder httpmock.Responder
+		wantErr   bool
+	}{
+		{
+			name:   "custom method, headers and body",
+			target: Target{URL: endpoint, Method: http.MethodPost, Headers: map[string]string{"X-Test": "yes"}, Body: "ping"},
+			responder: func(req *http.Request) (*http.Response, error) {
+				b, _ := io.ReadAll(req.Body)
+				if req.Method != http.MethodPost || req.Header.Get("X-Test") != "yes" || string(b) != "ping" {
+					return httpmock.NewStringResponse(http.StatusBadRequest, ""), nil
+				}
+				return httpmock.NewStringResponse(http.StatusOK, ""), nil
+			},
+			wantErr: false,
+		},
+		{
+			name:      "accepted status code",
+			target:    Target{URL: endpoint, ExpectedStatus: []string{"204"}},
+			responder: httpmock.NewStringResponder(http.StatusNoContent, ""),
+			wantErr:   false,
+		},
+		{
+			name:      "accepted status code range",
+			target:    Target{URL: endpoint, ExpectedStatus: []string{"200-299"}},
+			responder: httpmock.NewStringResponder(http.StatusAccepted, ""),
+			wantErr:   false,
+		},
+		{
+			name:      "status 200 not accepted",
+			target:    Target{URL: endpoint, ExpectedStatus: []string{"204"}},
+			responder: httpmock.NewStringResponder(http.StatusOK, ""),
+			wantErr:   true,
+		},
+		{
+			name:      "json path assertion succeeds",
+			target:    Target{URL: endpoint, Assertions: []Assertion{{JSONPath: "$.status", Equals: "ok"}}},
+			responder: httpmock.NewStringResponder(http.StatusOK, `{"status":"ok"}`),
+			wantErr:   false,
+		},
+		{
+			name:      "json path assertion fails on degraded status",
+			target:    Target{URL: endpoint, Assertions: []Assertion{{JSONPath: "$.status", Equals: "ok"}}},
+			responder: httpmock.NewStringResponder(http.StatusOK, `{"status":"degraded"}`),
+			wantErr:   true,
+		},
+		{
+			name:      "substring assertion fails",
+			target:    Target{URL: endpoint, Assertions: []Assertion{{Contains: "ready"}}},
+			responder: httpmock.NewStringResponder(http.StatusOK, "starting"),
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.RegisterResponder(tt.target.method(), endpoint, tt.responder)
+			if err := getHealth(context.Background(), &http.Client{}, tt.target); (err != nil) != tt.wantErr {
 				t.Errorf("getHealth() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -214,9 +285,14 @@ func TestHealth_Check(t *testing.T) {
 				)
 			}
 
+			targets := make([]Target, 0, len(tt.targets))
+			for _, u := range tt.targets {
+				targets = append(targets, Target{URL: u})
+			}
+
 			h := &Health{
 				config: Config{
-					Targets: tt.targets,
+					Targets: targets,
 					Timeout: 30,
 					Retry:   checks.DefaultRetry,
 				},
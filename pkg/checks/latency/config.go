@@ -11,6 +11,7 @@ import (
 
 	"github.com/telekom/sparrow/internal/helper"
 	"github.com/telekom/sparrow/pkg/checks"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -20,10 +21,63 @@ const (
 
 // Config defines the configuration parameters for a latency check
 type Config struct {
-	Targets  []string           `json:"targets,omitempty" yaml:"targets,omitempty"`
+	Targets  []Target           `json:"targets,omitempty" yaml:"targets,omitempty"`
 	Interval time.Duration      `json:"interval" yaml:"interval"`
 	Timeout  time.Duration      `json:"timeout" yaml:"timeout"`
 	Retry    helper.RetryConfig `json:"retry" yaml:"retry"`
+	// Phases enables the breakdown of the request duration into its phases.
+	// Connections are not reused then, so the total duration
+	// includes the DNS lookup, TCP connect and TLS handshake.
+	// It's a pointer, so a layered runtime configuration can disable it again.
+	Phases *bool `json:"phases,omitempty" yaml:"phases,omitempty"`
+}
+
+// Target defines a latency check target.
+// A target can be configured as a plain URL string,
+// in which case the settings of the check are used.
+type Target struct {
+	// URL is the url of the target
+	URL string `json:"url" yaml:"url"`
+	// TargetOverrides optionally override the interval, timeout and retry of the check
+	checks.TargetOverrides `yaml:",inline"`
+}
+
+// targetFields is used to (un)marshal a Target without its custom (un)marshalers
+type targetFields Target
+
+// plainTarget allows targets to be configured as a plain URL string
+var plainTarget = checks.PlainTarget[Target, targetFields]{
+	Key: func(t *Target) *string { return &t.URL },
+}
+
+// UnmarshalYAML allows targets to be either a plain URL string or an object
+func (t *Target) UnmarshalYAML(value *yaml.Node) error {
+	return plainTarget.DecodeYAML(value, t)
+}
+
+// MarshalYAML marshals targets without overrides as a plain URL string
+func (t Target) MarshalYAML() (any, error) {
+	return plainTarget.EncodeYAML(t)
+}
+
+// UnmarshalJSON allows targets to be either a plain URL string or an object
+func (t *Target) UnmarshalJSON(b []byte) error {
+	return plainTarget.DecodeJSON(b, t)
+}
+
+// MarshalJSON marshals targets without overrides as a plain URL string
+func (t Target) MarshalJSON() ([]byte, error) {
+	return plainTarget.EncodeJSON(t)
+}
+
+// withPhases returns true if the phases of the requests are measured
+func (c *Config) withPhases() bool {
+	return c.Phases != nil && *c.Phases
+}
+
+// intervals returns the check interval of every target
+func (c *Config) intervals() map[string]time.Duration {
+	return checks.Intervals(c.Targets, func(t Target) string { return t.URL }, c.Interval)
 }
 
 // For returns the name of the check
@@ -33,8 +87,8 @@ func (c *Config) For() string {
 
 // Validate checks if the configuration is valid
 func (c *Config) Validate() error {
-	for _, t := range c.Targets {
-		u, err := url.Parse(t)
+	for i, t := range c.Targets {
+		u, err := url.Parse(t.URL)
 		if err != nil {
 			return checks.ErrInvalidConfig{CheckName: c.For(), Field: "targets", Reason: "invalid target URL"}
 		}
@@ -42,6 +96,10 @@ func (c *Config) Validate() error {
 		if u.Scheme != "https" && u.Scheme != "http" {
 			return checks.ErrInvalidConfig{CheckName: c.For(), Field: "targets", Reason: "target URLs must start with 'https://' or 'http://'"}
 		}
+
+		if err := t.Validate(c.For(), fmt.Sprintf("targets[%d]", i), minInterval, minTimeout); err != nil {
+			return err
+		}
 	}
 
 	if c.Interval < minInterval {
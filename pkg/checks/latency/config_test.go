@@ -5,8 +5,15 @@
 package latency
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telekom/sparrow/internal/helper"
+	"github.com/telekom/sparrow/pkg/checks"
+	"gopkg.in/yaml.v3"
 )
 
 func TestConfig_Validate(t *testing.T) {
@@ -18,7 +25,7 @@ func TestConfig_Validate(t *testing.T) {
 		{
 			name: "valid config",
 			config: Config{
-				Targets:  []string{"http://localhost:8080"},
+				Targets:  []Target{{URL: "http://localhost:8080"}},
 				Interval: 100 * time.Millisecond,
 				Timeout:  1 * time.Second,
 			},
@@ -27,7 +34,7 @@ func TestConfig_Validate(t *testing.T) {
 		{
 			name: "invalid targets - invalid url",
 			config: Config{
-				Targets:  []string{"://localhost:8080"},
+				Targets:  []Target{{URL: "://localhost:8080"}},
 				Interval: 100 * time.Millisecond,
 				Timeout:  1 * time.Second,
 			},
@@ -36,7 +43,16 @@ func TestConfig_Validate(t *testing.T) {
 		{
 			name: "invalid targets - invalid scheme",
 			config: Config{
-				Targets:  []string{"localhost:8080"},
+				Targets:  []Target{{URL: "localhost:8080"}},
+				Interval: 100 * time.Millisecond,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid targets - timeout override too short",
+			config: Config{
+				Targets:  []Target{{URL: "http://localhost:8080", TargetOverrides: checks.TargetOverrides{Timeout: 100 * time.Millisecond}}},
 				Interval: 100 * time.Millisecond,
 				Timeout:  1 * time.Second,
 			},
@@ -45,7 +61,7 @@ func TestConfig_Validate(t *testing.T) {
 		{
 			name: "invalid interval",
 			config: Config{
-				Targets:  []string{"http://localhost:8080"},
+				Targets:  []Target{{URL: "http://localhost:8080"}},
 				Interval: 10 * time.Millisecond,
 				Timeout:  1 * time.Second,
 			},
@@ -54,7 +70,7 @@ func TestConfig_Validate(t *testing.T) {
 		{
 			name: "invalid timeout",
 			config: Config{
-				Targets:  []string{"http://localhost:8080"},
+				Targets:  []Target{{URL: "http://localhost:8080"}},
 				Interval: 100 * time.Millisecond,
 				Timeout:  100 * time.Millisecond,
 			},
@@ -71,3 +87,45 @@ func TestConfig_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestTarget_Unmarshal(t *testing.T) {
+	want := []Target{
+		{URL: "https://example.com"},
+		{URL: "https://legacy.example.com", TargetOverrides: checks.TargetOverrides{
+			Interval: 5 * time.Minute,
+			Timeout:  30 * time.Second,
+			Retry:    &helper.RetryConfig{Count: 5, Delay: 10 * time.Second},
+		}},
+	}
+
+	t.Run("yaml", func(t *testing.T) {
+		var got []Target
+		err := yaml.Unmarshal([]byte(`
+- https://example.com
+- url: https://legacy.example.com
+  interval: 5m
+  timeout: 30s
+  retry:
+    count: 5
+    delay: 10s
+`), &got)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+
+		b, err := yaml.Marshal(got)
+		require.NoError(t, err)
+		var roundtrip []Target
+		require.NoError(t, yaml.Unmarshal(b, &roundtrip))
+		assert.Equal(t, want, roundtrip)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		b, err := json.Marshal(want)
+		require.NoError(t, err)
+		assert.Contains(t, string(b), `"https://example.com"`, "targets without overrides should be marshaled as plain string")
+
+		var got []Target
+		require.NoError(t, json.Unmarshal(b, &got))
+		assert.Equal(t, want, got)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"net/http/httptrace"
 	"slices"
 	"sync"
 	"time"
@@ -25,13 +26,26 @@ var (
 	_ checks.Runtime = (*Config)(nil)
 )
 
-const CheckName = "latency"
+const (
+	CheckName = "latency"
+	// maxBodySize is the maximum number of bytes read from a response body
+	maxBodySize = 1 << 20
+)
 
 // Latency is a check that measures the latency to an endpoint
 type Latency struct {
 	checks.CheckBase
 	config  Config
 	metrics metrics
+	// schedule tracks when the targets are due
+	schedule checks.Scheduler[result]
+}
+
+func init() {
+	checks.MustRegister(CheckName, checks.Factory{
+		NewCheck:  NewCheck,
+		NewConfig: func() checks.Runtime { return &Config{} },
+	})
 }
 
 // NewCheck creates a new instance of the latency check
@@ -53,6 +67,23 @@ type result struct {
 	Code  int     `json:"code"`
 	Error *string `json:"error"`
 	Total float64 `json:"total"`
+	// Phases is the breakdown of the request duration into its phases.
+	// Only set if the phases are enabled in the config
+	Phases *phases `json:"phases,omitempty"`
+}
+
+// phases contains the durations of the request phases in seconds
+type phases struct {
+	// DNS is the duration of the DNS lookup
+	DNS float64 `json:"dns"`
+	// Connect is the duration of establishing the TCP connection
+	Connect float64 `json:"connect"`
+	// TLS is the duration of the TLS handshake
+	TLS float64 `json:"tls"`
+	// TTFB is the time from writing the request until the first response byte
+	TTFB float64 `json:"ttfb"`
+	// Transfer is the duration of reading the response body
+	Transfer float64 `json:"transfer"`
 }
 
 // Run starts the latency check
@@ -61,9 +92,9 @@ func (l *Latency) Run(ctx context.Context, cResult chan checks.ResultDTO) error
 	defer cancel()
 	log := logger.FromContext(ctx)
 
-	interval := l.GetConfig().(*Config).Interval
+	cfg := l.GetConfig().(*Config)
 
-	log.Info("Starting latency check", "interval", interval.String())
+	log.Info("Starting latency check", "interval", cfg.Interval.String())
 	for {
 		select {
 		case <-ctx.Done():
@@ -71,7 +102,7 @@ func (l *Latency) Run(ctx context.Context, cResult chan checks.ResultDTO) error
 			return ctx.Err()
 		case <-l.DoneChan:
 			return nil
-		case <-time.After(interval):
+		case <-time.After(l.schedule.Next(time.Now(), cfg.intervals(), cfg.Interval)):
 			res := l.check(ctx)
 
 			cResult <- checks.ResultDTO{
@@ -79,12 +110,13 @@ func (l *Latency) Run(ctx context.Context, cResult chan checks.ResultDTO) error
 				Result: &checks.Result{
 					Data:      res,
 					Timestamp: time.Now(),
+					Checked:   l.schedule.Checked(),
 				},
 			}
 			log.Debug("Successfully finished latency check run")
 
-			// Re-read interval in case config was updated
-			interval = l.GetConfig().(*Config).Interval
+			// Re-read config in case it was updated
+			cfg = l.GetConfig().(*Config)
 		}
 	}
 }
@@ -101,11 +133,16 @@ func (l *Latency) UpdateConfig(cfg checks.Runtime) error {
 		defer l.Mu.Unlock()
 
 		for _, target := range l.config.Targets {
-			if !slices.Contains(c.Targets, target) {
-				err := l.metrics.Remove(target)
+			if !slices.ContainsFunc(c.Targets, func(t Target) bool { return t.URL == target.URL }) {
+				err := l.metrics.Remove(target.URL)
 				if err != nil {
 					return err
 				}
+				continue
+			}
+			// the phases of the targets are not measured anymore
+			if !c.withPhases() {
+				l.metrics.RemovePhases(target.URL)
 			}
 		}
 
@@ -145,6 +182,7 @@ func (l *Latency) GetMetricCollectors() []prometheus.Collector {
 		l.metrics.totalDuration,
 		l.metrics.count,
 		l.metrics.histogram,
+		l.metrics.phaseDuration,
 	}
 }
 
@@ -162,26 +200,30 @@ func (l *Latency) check(ctx context.Context) map[string]result {
 	// Get a copy of the config to avoid race conditions
 	cfg := l.GetConfig().(*Config)
 
+	due := l.schedule.Due(time.Now(), cfg.intervals())
 	if len(cfg.Targets) == 0 {
 		log.Debug("No targets defined")
 		return map[string]result{}
 	}
-	log.Debug("Getting latency status for each target in separate routine", "amount", len(cfg.Targets))
+	log.Debug("Getting latency status for each due target in separate routine", "amount", len(due))
 
 	var mu sync.Mutex
 	var wg sync.WaitGroup
 	results := map[string]result{}
 
-	client := &http.Client{
-		Timeout: cfg.Timeout,
-	}
 	for _, t := range cfg.Targets {
-		target := t
+		if !due[t.URL] {
+			continue
+		}
+		target := t.URL
 		wg.Add(1)
 		lo := log.With("target", target)
 
+		client := &http.Client{
+			Timeout: t.TimeoutOr(cfg.Timeout),
+		}
 		getLatencyRetry := helper.Retry(func(ctx context.Context) error {
-			res, err := getLatency(ctx, client, target)
+			res, err := getLatency(ctx, client, target, cfg.withPhases())
 			mu.Lock()
 			defer mu.Unlock()
 			results[target] = res
@@ -189,7 +231,7 @@ func (l *Latency) check(ctx context.Context) map[string]result {
 				return err
 			}
 			return nil
-		}, cfg.Retry)
+		}, t.RetryOr(cfg.Retry))
 
 		go func() {
 			defer wg.Done()
@@ -206,21 +248,30 @@ func (l *Latency) check(ctx context.Context) map[string]result {
 			l.metrics.totalDuration.WithLabelValues(target).Set(results[target].Total)
 			l.metrics.count.WithLabelValues(target).Inc()
 			l.metrics.histogram.WithLabelValues(target).Observe(results[target].Total)
+			if p := results[target].Phases; p != nil {
+				l.metrics.SetPhases(target, *p)
+			}
 		}()
 	}
 
 	log.Debug("Waiting for all routines to finish")
 	wg.Wait()
 
-	log.Debug("Successfully got latency status from all targets")
-	return results
+	log.Debug("Successfully got latency status from all due targets")
+	return l.schedule.Merge(results)
 }
 
-// getLatency performs an HTTP get request and returns ok if request succeeds
-func getLatency(ctx context.Context, c *http.Client, url string) (result, error) {
+// getLatency performs an HTTP get request and returns ok if request succeeds.
+// If withPhases is true, the request is traced to break down its duration into the single phases.
+func getLatency(ctx context.Context, c *http.Client, url string, withPhases bool) (result, error) {
 	log := logger.FromContext(ctx).With("url", url)
 	var res result
 
+	var tt *tracer
+	if withPhases {
+		tt = &tracer{}
+		ctx = httptrace.WithClientTrace(ctx, tt.clientTrace())
+	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
 	if err != nil {
 		log.Error("Error while creating request", "error", err)
@@ -228,6 +279,9 @@ func getLatency(ctx context.Context, c *http.Client, url string) (result, error)
 		res.Error = &errval
 		return res, err
 	}
+	// Don't reuse connections when tracing, otherwise the DNS lookup,
+	// TCP connect and TLS handshake would not be measured
+	req.Close = withPhases
 
 	start := time.Now()
 	resp, err := c.Do(req) //nolint:bodyclose // Closed in defer below
@@ -235,6 +289,7 @@ func getLatency(ctx context.Context, c *http.Client, url string) (result, error)
 		log.Error("Error while checking latency", "error", err)
 		errval := err.Error()
 		res.Error = &errval
+		res.Phases = tt.phases(time.Now())
 		return res, err
 	}
 	end := time.Now()
@@ -244,6 +299,12 @@ func getLatency(ctx context.Context, c *http.Client, url string) (result, error)
 		_ = Body.Close()
 	}(resp.Body)
 
+	// the body is read to measure the transfer, but limited so large bodies don't stall the check
+	if _, err = io.Copy(io.Discard, io.LimitReader(resp.Body, maxBodySize)); err != nil {
+		log.Warn("Error while reading response body", "error", err)
+	}
+
 	res.Total = end.Sub(start).Seconds()
+	res.Phases = tt.phases(time.Now())
 	return res, nil
 }
@@ -7,15 +7,21 @@ package latency
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/telekom/sparrow/pkg/checks"
 
 	"github.com/jarcoal/httpmock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -39,7 +45,7 @@ func TestLatency_Run(t *testing.T) {
 			status  int
 			success bool
 		}
-		targets []string
+		targets []Target
 		ctx     context.Context
 		want    checks.Result
 	}{
@@ -56,7 +62,7 @@ func TestLatency_Run(t *testing.T) {
 					success: true,
 				},
 			},
-			targets: []string{successURL},
+			targets: []Target{{URL: successURL}},
 			ctx:     context.Background(),
 			want: checks.Result{
 				Data: map[string]result{
@@ -88,7 +94,7 @@ func TestLatency_Run(t *testing.T) {
 					success: false,
 				},
 			},
-			targets: []string{successURL, failURL, timeoutURL},
+			targets: []Target{{URL: successURL}, {URL: failURL}, {URL: timeoutURL}},
 			ctx:     context.Background(),
 			want: checks.Result{
 				Data: map[string]result{
@@ -176,14 +182,14 @@ func TestLatency_check(t *testing.T) {
 			status  int
 			success bool
 		}
-		targets []string
+		targets []Target
 		ctx     context.Context
 		want    map[string]result
 	}{
 		{
 			name:                "no target",
 			registeredEndpoints: nil,
-			targets:             []string{},
+			targets:             []Target{},
 			ctx:                 context.Background(),
 			want:                map[string]result{},
 		},
@@ -200,7 +206,7 @@ func TestLatency_check(t *testing.T) {
 					success: true,
 				},
 			},
-			targets: []string{successURL},
+			targets: []Target{{URL: successURL}},
 			ctx:     context.Background(),
 			want: map[string]result{
 				successURL: {Code: http.StatusOK, Error: nil, Total: 0},
@@ -228,7 +234,7 @@ func TestLatency_check(t *testing.T) {
 					success: false,
 				},
 			},
-			targets: []string{successURL, failURL, timeoutURL},
+			targets: []Target{{URL: successURL}, {URL: failURL}, {URL: timeoutURL}},
 			ctx:     context.Background(),
 			want: map[string]result{
 				successURL: {
@@ -291,6 +297,119 @@ func TestLatency_check(t *testing.T) {
 	}
 }
 
+func TestLatency_check_targetOverrides(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests[r.URL.Path]++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	fast, slow := srv.URL+"/fast", srv.URL+"/slow"
+	l := &Latency{
+		config: Config{
+			Targets: []Target{
+				{URL: fast},
+				{URL: slow, TargetOverrides: checks.TargetOverrides{Interval: time.Hour}},
+			},
+			Interval: 100 * time.Millisecond,
+			Timeout:  time.Second,
+		},
+		metrics: newMetrics(),
+	}
+
+	got := l.check(t.Context())
+	assert.Len(t, got, 2)
+
+	time.Sleep(100 * time.Millisecond)
+	got = l.check(t.Context())
+
+	// the slow target is not due yet, but its last result is still reported
+	assert.Len(t, got, 2)
+	assert.Equal(t, http.StatusOK, got[slow].Code)
+	assert.Equal(t, map[string]int{"/fast": 2, "/slow": 1}, requests)
+}
+
+func Test_getLatency_phases(t *testing.T) {
+	delay := 50 * time.Millisecond
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(delay)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	res, err := getLatency(context.Background(), srv.Client(), srv.URL, true)
+	if err != nil {
+		t.Fatalf("getLatency() error = %v", err)
+	}
+
+	assert.Equal(t, http.StatusOK, res.Code)
+	require.NotNil(t, res.Phases)
+	assert.Positive(t, res.Phases.Connect, "connect phase should be measured")
+	assert.Positive(t, res.Phases.TLS, "tls phase should be measured")
+	assert.GreaterOrEqual(t, res.Phases.TTFB, delay.Seconds(), "ttfb should include the server processing time")
+	assert.GreaterOrEqual(t, res.Total, res.Phases.TTFB)
+	// The target is an IP address, so no DNS lookup is done
+	assert.Zero(t, res.Phases.DNS)
+
+}
+
+func Test_getLatency_withoutPhases(t *testing.T) {
+	var conns atomic.Int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	for range 2 {
+		res, err := getLatency(context.Background(), srv.Client(), srv.URL, false)
+		require.NoError(t, err)
+		assert.Nil(t, res.Phases)
+	}
+	assert.Equal(t, int32(1), conns.Load(), "connection should be reused")
+}
+
+func Test_getLatency_endlessBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		chunk := make([]byte, 32<<10)
+		for r.Context().Err() == nil {
+			if _, err := w.Write(chunk); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	res, err := getLatency(ctx, srv.Client(), srv.URL, false)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.NoError(t, ctx.Err(), "the body should only be read up to its limit")
+}
+
+func TestLatency_UpdateConfig_removesPhases(t *testing.T) {
+	c := NewCheck().(*Latency)
+	target := "http://localhost:9090"
+	require.NoError(t, c.UpdateConfig(&Config{Targets: []Target{{URL: target}}, Phases: new(true)}))
+	c.metrics.SetPhases(target, phases{DNS: 1, Connect: 1, TLS: 1, TTFB: 1, Transfer: 1})
+	require.Equal(t, 5, testutil.CollectAndCount(c.metrics.phaseDuration))
+
+	require.NoError(t, c.UpdateConfig(&Config{Targets: []Target{{URL: target}}}))
+	assert.Zero(t, testutil.CollectAndCount(c.metrics.phaseDuration), "phases should be removed once disabled")
+}
+
 func TestLatency_Shutdown(t *testing.T) {
 	cDone := make(chan struct{}, 1)
 	c := Latency{
@@ -309,7 +428,7 @@ func TestLatency_Shutdown(t *testing.T) {
 func TestLatency_UpdateConfig(t *testing.T) {
 	c := Latency{}
 	wantCfg := Config{
-		Targets: []string{"http://localhost:9090"},
+		Targets: []Target{{URL: "http://localhost:9090"}},
 	}
 
 	err := c.UpdateConfig(&wantCfg)
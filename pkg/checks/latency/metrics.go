@@ -14,8 +14,12 @@ type metrics struct {
 	totalDuration *prometheus.GaugeVec
 	count         *prometheus.CounterVec
 	histogram     *prometheus.HistogramVec
+	phaseDuration *prometheus.GaugeVec
 }
 
+// labelPhase is the name of the label used for the request phase
+const labelPhase = "phase"
+
 // newMetrics initializes metric collectors of the latency check
 func newMetrics() metrics {
 	return metrics{
@@ -40,9 +44,25 @@ func newMetrics() metrics {
 			},
 			[]string{checks.LabelTarget},
 		),
+		phaseDuration: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "sparrow_latency_phase_seconds",
+				Help: "Duration of the request phases (dns, connect, tls, ttfb, transfer) for each target",
+			},
+			[]string{checks.LabelTarget, labelPhase},
+		),
 	}
 }
 
+// SetPhases sets the phase durations of one target
+func (m metrics) SetPhases(target string, p phases) {
+	m.phaseDuration.WithLabelValues(target, "dns").Set(p.DNS)
+	m.phaseDuration.WithLabelValues(target, "connect").Set(p.Connect)
+	m.phaseDuration.WithLabelValues(target, "tls").Set(p.TLS)
+	m.phaseDuration.WithLabelValues(target, "ttfb").Set(p.TTFB)
+	m.phaseDuration.WithLabelValues(target, "transfer").Set(p.Transfer)
+}
+
 // Remove removes the metrics which have the passed target as a label
 func (m metrics) Remove(label string) error {
 	if !m.totalDuration.Delete(map[string]string{checks.LabelTarget: label}) {
@@ -57,5 +77,12 @@ func (m metrics) Remove(label string) error {
 		return checks.ErrMetricNotFound{Label: label}
 	}
 
+	m.RemovePhases(label)
+
 	return nil
 }
+
+// RemovePhases removes the phase durations of one target
+func (m metrics) RemovePhases(label string) {
+	m.phaseDuration.DeletePartialMatch(map[string]string{checks.LabelTarget: label})
+}
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package latency
+
+import (
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// tracer records the timestamps of the phases of an HTTP request
+type tracer struct {
+	// mu protects the timestamps, since the trace hooks
+	// may be called concurrently by the transport
+	mu           sync.Mutex
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	tlsStart     time.Time
+	tlsDone      time.Time
+	wroteRequest time.Time
+	firstByte    time.Time
+}
+
+// clientTrace returns the hooks recording the timestamps of the request phases
+func (t *tracer) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.record(&t.dnsStart)
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.record(&t.dnsDone)
+		},
+		ConnectStart: func(_, _ string) {
+			t.recordOnce(&t.connectStart)
+		},
+		ConnectDone: func(_, _ string, err error) {
+			if err == nil {
+				t.record(&t.connectDone)
+			}
+		},
+		TLSHandshakeStart: func() {
+			t.record(&t.tlsStart)
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.record(&t.tlsDone)
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			t.record(&t.wroteRequest)
+		},
+		GotFirstResponseByte: func() {
+			t.record(&t.firstByte)
+		},
+	}
+}
+
+// record sets the timestamp to the current time
+func (t *tracer) record(ts *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	*ts = time.Now()
+}
+
+// recordOnce sets the timestamp to the current time if it is not yet set.
+// This is used for hooks that are called multiple times when dialing multiple addresses.
+func (t *tracer) recordOnce(ts *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if ts.IsZero() {
+		*ts = time.Now()
+	}
+}
+
+// phases returns the durations of the recorded phases.
+// The transfer phase is measured until the given end time.
+// Phases which were not completed are reported as 0.
+// Returns nil if the request was not traced.
+func (t *tracer) phases(end time.Time) *phases {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return &phases{
+		DNS:      between(t.dnsStart, t.dnsDone),
+		Connect:  between(t.connectStart, t.connectDone),
+		TLS:      between(t.tlsStart, t.tlsDone),
+		TTFB:     between(t.wroteRequest, t.firstByte),
+		Transfer: between(t.firstByte, end),
+	}
+}
+
+// between returns the duration between start and end in seconds
+// or 0 if one of the timestamps was not recorded
+func between(start, end time.Time) float64 {
+	if start.IsZero() || end.IsZero() || end.Before(start) {
+		return 0
+	}
+	return end.Sub(start).Seconds()
+}
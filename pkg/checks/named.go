@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package checks
+
+import (
+	"context"
+	"strings"
+)
+
+// InstanceSeparator separates the check type from the instance name
+// in the name of a named check instance, e.g. "latency/internal"
+const InstanceSeparator = "/"
+
+// SplitName splits the name of a check into the check type and the instance name.
+// The instance name is empty for the default instance of a check.
+func SplitName(name string) (check, instance string) {
+	check, instance, _ = strings.Cut(name, InstanceSeparator)
+	return check, instance
+}
+
+// named is a check running under the name of a named check instance
+type named struct {
+	Check
+	name string
+}
+
+// NewNamed returns the check running as the instance with the given name.
+// The results of the check are reported under that name.
+// If the name equals the name of the check, the check is returned unchanged.
+func NewNamed(c Check, name string) Check {
+	if name == c.Name() {
+		return c
+	}
+	return &named{Check: c, name: name}
+}
+
+// Name returns the name of the check instance
+func (n *named) Name() string {
+	return n.name
+}
+
+// Run runs the wrapped check and reports its results under the name of the instance
+func (n *named) Run(ctx context.Context, cResult chan ResultDTO) error {
+	cInner := make(chan ResultDTO)
+	cErr := make(chan error, 1)
+	go func() {
+		cErr <- n.Check.Run(ctx, cInner)
+	}()
+
+	for {
+		select {
+		case res := <-cInner:
+			res.Name = n.name
+			cResult <- res
+		case err := <-cErr:
+			return err
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package checks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitName(t *testing.T) {
+	tests := []struct {
+		name         string
+		wantCheck    string
+		wantInstance string
+	}{
+		{name: "latency", wantCheck: "latency"},
+		{name: "latency/internal", wantCheck: "latency", wantInstance: "internal"},
+		{name: "latency/", wantCheck: "latency"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check, instance := SplitName(tt.name)
+			assert.Equal(t, tt.wantCheck, check)
+			assert.Equal(t, tt.wantInstance, instance)
+		})
+	}
+}
+
+func TestNewNamed(t *testing.T) {
+	mock := &CheckMock{
+		NameFunc: func() string { return "latency" },
+		RunFunc: func(ctx context.Context, cResult chan ResultDTO) error {
+			cResult <- ResultDTO{Name: "latency", Result: &Result{Data: 1}}
+			return nil
+		},
+	}
+
+	assert.Same(t, Check(mock), NewNamed(mock, "latency"), "default instance should not be wrapped")
+
+	c := NewNamed(mock, "latency/internal")
+	assert.Equal(t, "latency/internal", c.Name())
+
+	cResult := make(chan ResultDTO, 1)
+	require.NoError(t, c.Run(t.Context(), cResult))
+	res := <-cResult
+	assert.Equal(t, "latency/internal", res.Name)
+	assert.Equal(t, 1, res.Result.Data)
+}
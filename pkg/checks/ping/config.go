@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ping
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/telekom/sparrow/internal/helper"
+	"github.com/telekom/sparrow/pkg/checks"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	minInterval       = 1 * time.Second
+	minTimeout        = 10 * time.Millisecond
+	minPacketInterval = 10 * time.Millisecond
+	maxCount          = 100
+	// defaultCount is the number of echo requests sent per target if no count is configured
+	defaultCount = 5
+	// defaultPacketInterval is the time between two echo requests if no packet interval is configured
+	defaultPacketInterval = 100 * time.Millisecond
+)
+
+// Config defines the configuration parameters for a ping check
+type Config struct {
+	// Targets is a list of hosts or IP addresses to ping
+	Targets  []Target      `json:"targets,omitempty" yaml:"targets,omitempty"`
+	Interval time.Duration `json:"interval" yaml:"interval"`
+	// Timeout is the time to wait for the reply to an echo request
+	Timeout time.Duration      `json:"timeout" yaml:"timeout"`
+	Retry   helper.RetryConfig `json:"retry" yaml:"retry"`
+	// Count is the number of echo requests sent to every target per interval. Defaults to 5.
+	Count int `json:"count,omitempty" yaml:"count,omitempty"`
+	// PacketInterval is the time between two echo requests. Defaults to 100ms.
+	PacketInterval time.Duration `json:"packetInterval,omitempty" yaml:"packetInterval,omitempty"`
+}
+
+// Target defines a ping check target.
+// A target can be configured as a plain host or IP address string,
+// in which case the settings of the check are used.
+type Target struct {
+	// Address is the host or IP address to ping
+	Address string `json:"address" yaml:"address"`
+	// TargetOverrides optionally override the interval, timeout and retry of the check
+	checks.TargetOverrides `yaml:",inline"`
+}
+
+// targetFields is used to (un)marshal a Target without its custom (un)marshalers
+type targetFields Target
+
+// plainTarget allows targets to be configured as a plain address string
+var plainTarget = checks.PlainTarget[Target, targetFields]{
+	Key: func(t *Target) *string { return &t.Address },
+}
+
+// UnmarshalYAML allows targets to be either a plain address string or an object
+func (t *Target) UnmarshalYAML(value *yaml.Node) error {
+	return plainTarget.DecodeYAML(value, t)
+}
+
+// MarshalYAML marshals targets without overrides as a plain address string
+func (t Target) MarshalYAML() (any, error) {
+	return plainTarget.EncodeYAML(t)
+}
+
+// UnmarshalJSON allows targets to be either a plain address string or an object
+func (t *Target) UnmarshalJSON(b []byte) error {
+	return plainTarget.DecodeJSON(b, t)
+}
+
+// MarshalJSON marshals targets without overrides as a plain address string
+func (t Target) MarshalJSON() ([]byte, error) {
+	return plainTarget.EncodeJSON(t)
+}
+
+// count returns the number of echo requests sent per target
+func (c *Config) count() int {
+	if c.Count > 0 {
+		return c.Count
+	}
+	return defaultCount
+}
+
+// packetInterval returns the time between two echo requests
+func (c *Config) packetInterval() time.Duration {
+	if c.PacketInterval > 0 {
+		return c.PacketInterval
+	}
+	return defaultPacketInterval
+}
+
+// intervals returns the check interval of every target
+func (c *Config) intervals() map[string]time.Duration {
+	return checks.Intervals(c.Targets, func(t Target) string { return t.Address }, c.Interval)
+}
+
+// For returns the name of the check
+func (c *Config) For() string {
+	return CheckName
+}
+
+// Validate checks if the configuration is valid
+func (c *Config) Validate() error {
+	for i, t := range c.Targets {
+		if t.Address == "" {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("targets[%d]", i), Reason: "target must not be empty"}
+		}
+
+		if err := t.Validate(c.For(), fmt.Sprintf("targets[%d]", i), minInterval, minTimeout); err != nil {
+			return err
+		}
+	}
+
+	if c.Interval < minInterval {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "interval", Reason: fmt.Sprintf("interval must be at least %v", minInterval)}
+	}
+
+	if c.Timeout < minTimeout {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "timeout", Reason: fmt.Sprintf("timeout must be at least %v", minTimeout)}
+	}
+
+	if c.Count < 0 || c.Count > maxCount {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "count", Reason: fmt.Sprintf("count must be between 0 and %d", maxCount)}
+	}
+
+	if c.PacketInterval != 0 && c.PacketInterval < minPacketInterval {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "packetInterval", Reason: fmt.Sprintf("packetInterval must be at least %v", minPacketInterval)}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ping
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{
+			name: "valid config",
+			config: Config{
+				Targets:        []Target{{Address: "example.com"}, {Address: "10.0.0.1"}},
+				Interval:       10 * time.Second,
+				Timeout:        time.Second,
+				Count:          10,
+				PacketInterval: 200 * time.Millisecond,
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty target",
+			config: Config{
+				Targets:  []Target{{}},
+				Interval: 10 * time.Second,
+				Timeout:  time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "too many echo requests",
+			config: Config{
+				Interval: 10 * time.Second,
+				Timeout:  time.Second,
+				Count:    maxCount + 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "packet interval too short",
+			config: Config{
+				Interval:       10 * time.Second,
+				Timeout:        time.Second,
+				PacketInterval: time.Millisecond,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid interval",
+			config: Config{
+				Interval: 100 * time.Millisecond,
+				Timeout:  time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid timeout",
+			config: Config{
+				Interval: 10 * time.Second,
+				Timeout:  time.Millisecond,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Config.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
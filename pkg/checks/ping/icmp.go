@@ -0,0 +1,243 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ping
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/telekom/sparrow/internal/helper"
+	"github.com/telekom/sparrow/internal/logger"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+// payload is the data sent with every echo request
+var payload = []byte("sparrow-ping")
+
+// burstOptions defines the burst of echo requests sent to a target
+type burstOptions struct {
+	// count is the number of echo requests to send
+	count int
+	// interval is the time between two echo requests
+	interval time.Duration
+	// timeout is the time to wait for the reply to an echo request
+	timeout time.Duration
+}
+
+// ping resolves the address and sends a burst of echo requests to it.
+// An error is returned if the address cannot be resolved, the echo
+// requests cannot be sent or no reply at all was received.
+func ping(ctx context.Context, address string, opts burstOptions) (result, error) {
+	log := logger.FromContext(ctx).With("address", address)
+	var res result
+
+	fail := func(err error) (result, error) {
+		log.Debug("Error while pinging address", "error", err)
+		errval := err.Error()
+		res.Error = &errval
+		return res, err
+	}
+
+	ip, err := resolve(ctx, address)
+	if err != nil {
+		return fail(err)
+	}
+
+	conn, unprivileged, err := helper.ListenICMP(ip.To4() == nil, true)
+	if err != nil {
+		return fail(fmt.Errorf("failed to open icmp socket: %w", err))
+	}
+	defer func() {
+		if cErr := conn.Close(); cErr != nil {
+			log.Debug("Failed to close icmp socket", "error", cErr)
+		}
+	}()
+
+	rtts, err := burst(ctx, conn, ip, unprivileged, opts)
+	res = stats(rtts)
+	res.Unprivileged = unprivileged
+	if err != nil {
+		return fail(err)
+	}
+	if res.Received == 0 {
+		return fail(fmt.Errorf("no reply received to %d echo requests", res.Sent))
+	}
+	return res, nil
+}
+
+// resolve returns the IP address of the given host or IP address.
+// IPv4 addresses are preferred if the host resolves to both families.
+func resolve(ctx context.Context, address string) (net.IP, error) {
+	if ip := net.ParseIP(address); ip != nil {
+		return ip, nil
+	}
+
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip", address)
+	if err != nil {
+		return nil, err
+	}
+	for _, ip := range ips {
+		if ip.To4() != nil {
+			return ip, nil
+		}
+	}
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("no ip address found for %q", address)
+	}
+	return ips[0], nil
+}
+
+// burst sends the echo requests to the ip address and collects the replies.
+// Returns the round trip time of every sent echo request, which is negative
+// if no reply was received within the timeout.
+func burst(ctx context.Context, conn *icmp.PacketConn, ip net.IP, unprivileged bool, opts burstOptions) ([]time.Duration, error) {
+	var request, reply icmp.Type = ipv4.ICMPTypeEcho, ipv4.ICMPTypeEchoReply
+	if ip.To4() == nil {
+		request, reply = ipv6.ICMPTypeEchoRequest, ipv6.ICMPTypeEchoReply
+	}
+	var dst net.Addr = &net.IPAddr{IP: ip}
+	if unprivileged {
+		dst = &net.UDPAddr{IP: ip}
+	}
+	// The kernel sets the id of unprivileged sockets itself and only passes matching replies.
+	// Raw sockets receive all icmp messages, so replies are matched by a random id.
+	id := rand.N(math.MaxUint16) // #nosec G404 // the id only needs to distinguish concurrent bursts
+
+	var mu sync.Mutex
+	sent := make([]time.Time, 0, opts.count)
+	rtts := make([]time.Duration, 0, opts.count)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		buf := make([]byte, 1500)
+		received := 0
+		for received < opts.count {
+			n, peer, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			now := time.Now()
+
+			msg, err := icmp.ParseMessage(request.Protocol(), buf[:n])
+			if err != nil || msg.Type != reply || !peerIP(peer).Equal(ip) {
+				continue
+			}
+			echo, ok := msg.Body.(*icmp.Echo)
+			if !ok || (!unprivileged && echo.ID != id) {
+				continue
+			}
+
+			mu.Lock()
+			if echo.Seq < len(rtts) && rtts[echo.Seq] < 0 {
+				rtts[echo.Seq] = now.Sub(sent[echo.Seq])
+				received++
+			}
+			mu.Unlock()
+		}
+	}()
+
+	var err error
+	for seq := range opts.count {
+		if seq > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(opts.interval):
+			}
+		}
+		if ctx.Err() != nil {
+			err = ctx.Err()
+			break
+		}
+
+		b, mErr := (&icmp.Message{
+			Type: request,
+			Body: &icmp.Echo{ID: id, Seq: seq, Data: payload},
+		}).Marshal(nil)
+		if mErr != nil {
+			err = mErr
+			break
+		}
+
+		mu.Lock()
+		sent = append(sent, time.Now())
+		rtts = append(rtts, -1)
+		mu.Unlock()
+		if _, wErr := conn.WriteTo(b, dst); wErr != nil {
+			mu.Lock()
+			sent, rtts = sent[:seq], rtts[:seq]
+			mu.Unlock()
+			err = fmt.Errorf("failed to send echo request: %w", wErr)
+			break
+		}
+	}
+
+	// Wait for the reply to the last echo request or stop early if the context is done
+	deadline := time.Now().Add(opts.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = conn.SetReadDeadline(deadline)
+	<-done
+
+	mu.Lock()
+	defer mu.Unlock()
+	return rtts, err
+}
+
+// peerIP returns the ip address of the sender of an icmp message
+func peerIP(addr net.Addr) net.IP {
+	switch addr := addr.(type) {
+	case *net.IPAddr:
+		return addr.IP
+	case *net.UDPAddr:
+		return addr.IP
+	default:
+		return nil
+	}
+}
+
+// stats calculates the result of a burst from the round trip times of its echo requests.
+// Negative round trip times are counted as lost echo requests.
+func stats(rtts []time.Duration) result {
+	res := result{Sent: len(rtts)}
+
+	var sum, jitter float64
+	prev := -1.0
+	for _, rtt := range rtts {
+		if rtt < 0 {
+			continue
+		}
+		s := rtt.Seconds()
+		if res.Received == 0 || s < res.Min {
+			res.Min = s
+		}
+		res.Max = max(res.Max, s)
+		sum += s
+		if prev >= 0 {
+			jitter += math.Abs(s - prev)
+		}
+		prev = s
+		res.Received++
+	}
+
+	if res.Sent > 0 {
+		res.Loss = float64(res.Sent-res.Received) / float64(res.Sent) * 100
+	}
+	if res.Received > 0 {
+		res.Avg = sum / float64(res.Received)
+	}
+	if res.Received > 1 {
+		res.Jitter = jitter / float64(res.Received-1)
+	}
+	return res
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ping
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/telekom/sparrow/pkg/checks"
+)
+
+const (
+	lossMetric   = "sparrow_ping_packet_loss_percent"
+	minMetric    = "sparrow_ping_rtt_min_seconds"
+	avgMetric    = "sparrow_ping_rtt_avg_seconds"
+	maxMetric    = "sparrow_ping_rtt_max_seconds"
+	jitterMetric = "sparrow_ping_jitter_seconds"
+	countMetric  = "sparrow_ping_check_count"
+)
+
+// metrics defines the metric collectors of the ping check
+type metrics struct {
+	loss   *prometheus.GaugeVec
+	min    *prometheus.GaugeVec
+	avg    *prometheus.GaugeVec
+	max    *prometheus.GaugeVec
+	jitter *prometheus.GaugeVec
+	count  *prometheus.CounterVec
+}
+
+// newMetrics initializes metric collectors of the ping check
+func newMetrics() metrics {
+	return metrics{
+		loss: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: lossMetric,
+				Help: "Percentage of echo requests to the target without a reply.",
+			},
+			[]string{checks.LabelTarget},
+		),
+		min: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: minMetric,
+				Help: "Minimum round trip time of the echo requests to the target in seconds.",
+			},
+			[]string{checks.LabelTarget},
+		),
+		avg: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: avgMetric,
+				Help: "Average round trip time of the echo requests to the target in seconds.",
+			},
+			[]string{checks.LabelTarget},
+		),
+		max: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: maxMetric,
+				Help: "Maximum round trip time of the echo requests to the target in seconds.",
+			},
+			[]string{checks.LabelTarget},
+		),
+		jitter: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: jitterMetric,
+				Help: "Mean difference between the round trip times of consecutive echo requests to the target in seconds.",
+			},
+			[]string{checks.LabelTarget},
+		),
+		count: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: countMetric,
+				Help: "Total number of ping checks performed on the target.",
+			},
+			[]string{checks.LabelTarget},
+		),
+	}
+}
+
+// GetCollectors returns all metric collectors
+func (m *metrics) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.loss,
+		m.min,
+		m.avg,
+		m.max,
+		m.jitter,
+		m.count,
+	}
+}
+
+// Set sets the metrics of one target result
+func (m *metrics) Set(target string, res result) {
+	m.loss.WithLabelValues(target).Set(res.Loss)
+	m.min.WithLabelValues(target).Set(res.Min)
+	m.avg.WithLabelValues(target).Set(res.Avg)
+	m.max.WithLabelValues(target).Set(res.Max)
+	m.jitter.WithLabelValues(target).Set(res.Jitter)
+	m.count.WithLabelValues(target).Inc()
+}
+
+// Remove removes the metrics of one target
+func (m *metrics) Remove(target string) error {
+	for _, g := range []*prometheus.GaugeVec{m.loss, m.min, m.avg, m.max, m.jitter} {
+		if !g.DeleteLabelValues(target) {
+			return checks.ErrMetricNotFound{Label: target}
+		}
+	}
+
+	if !m.count.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+
+	return nil
+}
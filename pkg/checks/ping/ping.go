@@ -0,0 +1,233 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ping
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/telekom/sparrow/internal/helper"
+	"github.com/telekom/sparrow/internal/logger"
+	"github.com/telekom/sparrow/pkg/checks"
+)
+
+var (
+	_ checks.Check   = (*Ping)(nil)
+	_ checks.Runtime = (*Config)(nil)
+)
+
+const CheckName = "ping"
+
+// Ping is a check that sends bursts of ICMP echo requests to targets
+// and measures the packet loss and round trip times
+type Ping struct {
+	checks.CheckBase
+	config  Config
+	metrics metrics
+	// schedule tracks when the targets are due
+	schedule checks.Scheduler[result]
+}
+
+func init() {
+	checks.MustRegister(CheckName, checks.Factory{
+		NewCheck:  NewCheck,
+		NewConfig: func() checks.Runtime { return &Config{} },
+	})
+}
+
+// NewCheck creates a new instance of the ping check
+func NewCheck() checks.Check {
+	return &Ping{
+		CheckBase: checks.CheckBase{
+			Mu:       sync.Mutex{},
+			DoneChan: make(chan struct{}, 1),
+		},
+		config: Config{
+			Retry: checks.DefaultRetry,
+		},
+		metrics: newMetrics(),
+	}
+}
+
+// result represents the result of a single burst of echo requests to a specific target
+type result struct {
+	Sent     int `json:"sent"`
+	Received int `json:"received"`
+	// Loss is the percentage of echo requests without a reply
+	Loss float64 `json:"loss"`
+	// Min, Avg and Max are the round trip times in seconds
+	Min float64 `json:"min"`
+	Avg float64 `json:"avg"`
+	Max float64 `json:"max"`
+	// Jitter is the mean difference between consecutive round trip times in seconds
+	Jitter float64 `json:"jitter"`
+	// Unprivileged is true if an unprivileged datagram socket was used
+	Unprivileged bool    `json:"unprivileged"`
+	Error        *string `json:"error"`
+}
+
+// Run starts the ping check
+func (p *Ping) Run(ctx context.Context, cResult chan checks.ResultDTO) error {
+	ctx, cancel := logger.NewContextWithLogger(ctx)
+	defer cancel()
+	log := logger.FromContext(ctx)
+
+	cfg := p.GetConfig().(*Config)
+
+	log.Info("Starting ping check", "interval", cfg.Interval.String())
+	for {
+		select {
+		case <-ctx.Done():
+			log.Error("Context canceled", "err", ctx.Err())
+			return ctx.Err()
+		case <-p.DoneChan:
+			return nil
+		case <-time.After(p.schedule.Next(time.Now(), cfg.intervals(), cfg.Interval)):
+			res := p.check(ctx)
+
+			cResult <- checks.ResultDTO{
+				Name: p.Name(),
+				Result: &checks.Result{
+					Data:      res,
+					Timestamp: time.Now(),
+					Checked:   p.schedule.Checked(),
+				},
+			}
+			log.Debug("Successfully finished ping check run")
+
+			// Re-read config in case it was updated
+			cfg = p.GetConfig().(*Config)
+		}
+	}
+}
+
+// Shutdown is called once when the check is unregistered or sparrow shuts down
+func (p *Ping) Shutdown() {
+	p.DoneChan <- struct{}{}
+	close(p.DoneChan)
+}
+
+// UpdateConfig sets the configuration for the ping check
+func (p *Ping) UpdateConfig(cfg checks.Runtime) error {
+	if c, ok := cfg.(*Config); ok {
+		p.Mu.Lock()
+		defer p.Mu.Unlock()
+
+		for _, target := range p.config.Targets {
+			if !slices.ContainsFunc(c.Targets, func(n Target) bool { return n.Address == target.Address }) {
+				err := p.metrics.Remove(target.Address)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		p.config = *c
+		return nil
+	}
+
+	return checks.ErrConfigMismatch{
+		Expected: CheckName,
+		Current:  cfg.For(),
+	}
+}
+
+// GetConfig returns a copy of the current configuration of the ping check
+func (p *Ping) GetConfig() checks.Runtime {
+	p.Mu.Lock()
+	defer p.Mu.Unlock()
+	// Return a copy to prevent race conditions when the config is read while being updated
+	configCopy := p.config
+	return &configCopy
+}
+
+// Name returns the name of the check
+func (p *Ping) Name() string {
+	return CheckName
+}
+
+// Schema provides the schema of the data that will be provided
+// by the ping check
+func (p *Ping) Schema() (*openapi3.SchemaRef, error) {
+	return checks.OpenapiFromPerfData(make(map[string]result))
+}
+
+// GetMetricCollectors returns all metric collectors of check
+func (p *Ping) GetMetricCollectors() []prometheus.Collector {
+	return p.metrics.GetCollectors()
+}
+
+// RemoveLabelledMetrics removes the metrics which have the passed
+// target as a label
+func (p *Ping) RemoveLabelledMetrics(target string) error {
+	return p.metrics.Remove(target)
+}
+
+// check sends a burst of echo requests to all due targets using a retry function.
+// Returns a map where each target is associated with its ping check result.
+func (p *Ping) check(ctx context.Context) map[string]result {
+	log := logger.FromContext(ctx)
+	log.Debug("Pinging targets")
+
+	// Get a copy of the config to avoid race conditions
+	cfg := p.GetConfig().(*Config)
+
+	due := p.schedule.Due(time.Now(), cfg.intervals())
+	if len(cfg.Targets) == 0 {
+		log.Debug("No targets defined")
+		return map[string]result{}
+	}
+	log.Debug("Pinging each due target in separate routine", "amount", len(due))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := map[string]result{}
+
+	for _, target := range cfg.Targets {
+		if !due[target.Address] {
+			continue
+		}
+		addr := target.Address
+		wg.Add(1)
+		lo := log.With("target", addr)
+
+		opts := burstOptions{
+			count:    cfg.count(),
+			interval: cfg.packetInterval(),
+			timeout:  target.TimeoutOr(cfg.Timeout),
+		}
+		pingRetry := helper.Retry(func(ctx context.Context) error {
+			res, err := ping(ctx, addr, opts)
+			mu.Lock()
+			defer mu.Unlock()
+			results[addr] = res
+			return err
+		}, target.RetryOr(cfg.Retry))
+
+		go func() {
+			defer wg.Done()
+
+			lo.Debug("Starting retry routine to ping target")
+			if err := pingRetry(ctx); err != nil {
+				lo.Warn("Error while pinging target", "error", err)
+			}
+			lo.Debug("Ping check completed for target")
+
+			mu.Lock()
+			defer mu.Unlock()
+			p.metrics.Set(addr, results[addr])
+		}()
+	}
+
+	log.Debug("Waiting for all routines to finish")
+	wg.Wait()
+
+	log.Debug("Finished pinging all due targets")
+	return p.schedule.Merge(results)
+}
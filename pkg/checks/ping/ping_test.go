@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ping
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telekom/sparrow/internal/helper"
+	"github.com/telekom/sparrow/pkg/checks"
+	"github.com/telekom/sparrow/pkg/checks/health"
+)
+
+func TestPing_Run(t *testing.T) {
+	conn, _, err := helper.ListenICMP(false, true)
+	if errors.Is(err, os.ErrPermission) {
+		t.Skip("Neither raw nor unprivileged icmp sockets are permitted")
+	}
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	c := NewCheck()
+	cResult := make(chan checks.ResultDTO, 1)
+	defer close(cResult)
+
+	err = c.UpdateConfig(&Config{
+		Targets:        []Target{{Address: "127.0.0.1"}},
+		Interval:       time.Second,
+		Timeout:        time.Second,
+		Retry:          helper.RetryConfig{Count: 0},
+		Count:          3,
+		PacketInterval: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	go func() {
+		err := c.Run(context.Background(), cResult)
+		if err != nil {
+			t.Errorf("Ping.Run() error = %v", err)
+		}
+	}()
+	defer c.Shutdown()
+
+	r := <-cResult
+	got, ok := r.Result.Data.(map[string]result)
+	require.True(t, ok, "Ping.Run() result data has wrong type %T", r.Result.Data)
+	res := got["127.0.0.1"]
+	assert.Nil(t, res.Error)
+	assert.Equal(t, 3, res.Sent)
+	assert.Equal(t, 3, res.Received)
+	assert.Zero(t, res.Loss)
+	assert.Positive(t, res.Avg)
+	assert.LessOrEqual(t, res.Min, res.Avg)
+	assert.LessOrEqual(t, res.Avg, res.Max)
+}
+
+func TestStats(t *testing.T) {
+	tests := []struct {
+		name string
+		rtts []time.Duration
+		want result
+	}{
+		{
+			name: "nothing sent",
+			want: result{},
+		},
+		{
+			name: "all lost",
+			rtts: []time.Duration{-1, -1},
+			want: result{Sent: 2, Loss: 100},
+		},
+		{
+			name: "all received",
+			rtts: []time.Duration{10 * time.Millisecond, 30 * time.Millisecond, 20 * time.Millisecond},
+			want: result{Sent: 3, Received: 3, Min: 0.01, Avg: 0.02, Max: 0.03, Jitter: 0.015},
+		},
+		{
+			name: "partial loss",
+			rtts: []time.Duration{10 * time.Millisecond, -1, 20 * time.Millisecond, -1},
+			want: result{Sent: 4, Received: 2, Loss: 50, Min: 0.01, Avg: 0.015, Max: 0.02, Jitter: 0.01},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := stats(tt.rtts)
+			assert.Equal(t, tt.want.Sent, got.Sent)
+			assert.Equal(t, tt.want.Received, got.Received)
+			assert.InDelta(t, tt.want.Loss, got.Loss, 1e-9)
+			assert.InDelta(t, tt.want.Min, got.Min, 1e-9)
+			assert.InDelta(t, tt.want.Avg, got.Avg, 1e-9)
+			assert.InDelta(t, tt.want.Max, got.Max, 1e-9)
+			assert.InDelta(t, tt.want.Jitter, got.Jitter, 1e-9)
+		})
+	}
+}
+
+func TestPing_UpdateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   checks.Runtime
+		want    Config
+		wantErr bool
+	}{
+		{
+			name: "simple config",
+			input: &Config{
+				Targets:  []Target{{Address: "example.com"}},
+				Interval: 10 * time.Second,
+				Timeout:  time.Second,
+			},
+			want: Config{
+				Targets:  []Target{{Address: "example.com"}},
+				Interval: 10 * time.Second,
+				Timeout:  time.Second,
+			},
+		},
+		{
+			name:    "wrong type",
+			input:   &health.Config{Targets: []health.Target{{URL: "https://example.com"}}},
+			want:    Config{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Ping{metrics: newMetrics()}
+			if err := c.UpdateConfig(tt.input); (err != nil) != tt.wantErr {
+				t.Errorf("Ping.UpdateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, c.config)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package checks
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"sync"
+)
+
+// checkNameRegex matches valid names of check types
+var checkNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// Factory creates new instances of a check type and its runtime configuration
+type Factory struct {
+	// NewCheck creates a new instance of the check
+	NewCheck func() Check
+	// NewConfig creates a new empty runtime configuration of the check.
+	// It is used to decode the configuration of the check from the runtime configuration.
+	NewConfig func() Runtime
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{}
+)
+
+// Register registers a check type under the given name, so it can be configured
+// in the runtime configuration under that name. The name must equal the name
+// returned by the check and its runtime configuration.
+// Checks need to be registered before the runtime configuration is loaded,
+// e.g. in an init function of the package providing the check.
+func Register(name string, f Factory) error {
+	if !checkNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid check name %q: must match %s", name, checkNameRegex)
+	}
+	if f.NewCheck == nil || f.NewConfig == nil {
+		return errors.New("check factory must provide both NewCheck and NewConfig")
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[name]; ok {
+		return fmt.Errorf("check %q is already registered", name)
+	}
+	registry[name] = f
+	return nil
+}
+
+// MustRegister is like Register but panics if the check cannot be registered
+func MustRegister(name string, f Factory) {
+	if err := Register(name, f); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup returns the factory of the check type registered under the given name
+func Lookup(name string) (Factory, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	f, ok := registry[name]
+	return f, ok
+}
+
+// Registered returns the sorted names of all registered check types
+func Registered() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return slices.Sorted(maps.Keys(registry))
+}
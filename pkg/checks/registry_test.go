@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister(t *testing.T) {
+	f := Factory{
+		NewCheck:  func() Check { return &CheckMock{NameFunc: func() string { return "registry-test" }} },
+		NewConfig: func() Runtime { return nil },
+	}
+
+	tests := []struct {
+		name    string
+		check   string
+		factory Factory
+		wantErr bool
+	}{
+		{name: "valid check", check: "registry-test", factory: f},
+		{name: "already registered", check: "registry-test", factory: f, wantErr: true},
+		{name: "empty name", check: "", factory: f, wantErr: true},
+		{name: "name with instance separator", check: "registry/test", factory: f, wantErr: true},
+		{name: "missing constructors", check: "registry-test-2", factory: Factory{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Register(tt.check, tt.factory); (err != nil) != tt.wantErr {
+				t.Errorf("Register() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	got, ok := Lookup("registry-test")
+	require.True(t, ok)
+	assert.Equal(t, "registry-test", got.NewCheck().Name())
+	assert.Contains(t, Registered(), "registry-test")
+	assert.NotContains(t, Registered(), "registry-test-2")
+
+	_, ok = Lookup("unknown")
+	assert.False(t, ok)
+	assert.Panics(t, func() { MustRegister("registry-test", f) })
+}
@@ -5,23 +5,128 @@
 package runtime
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/telekom/sparrow/pkg/checks"
-	"github.com/telekom/sparrow/pkg/checks/dns"
-	"github.com/telekom/sparrow/pkg/checks/health"
-	"github.com/telekom/sparrow/pkg/checks/latency"
-	"github.com/telekom/sparrow/pkg/checks/traceroute"
+	"github.com/telekom/sparrow/pkg/checks/exec"
+	"gopkg.in/yaml.v3"
+
+	// The built-in checks register themselves, so they can be configured
+	_ "github.com/telekom/sparrow/pkg/checks/dns"
+	_ "github.com/telekom/sparrow/pkg/checks/grpc"
+	_ "github.com/telekom/sparrow/pkg/checks/health"
+	_ "github.com/telekom/sparrow/pkg/checks/latency"
+	_ "github.com/telekom/sparrow/pkg/checks/ping"
+	_ "github.com/telekom/sparrow/pkg/checks/tcp"
+	_ "github.com/telekom/sparrow/pkg/checks/tls"
+	_ "github.com/telekom/sparrow/pkg/checks/traceroute"
 )
 
-// Config holds the runtime configuration
-// for the various checks
-// the sparrow supports
+// historySuffix is the path segment used by the api for the result history of a check.
+// It cannot be used as instance name because the routes would collide.
+const historySuffix = "history"
+
+// instanceNameRegex matches valid names of check instances
+var instanceNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// Config holds the runtime configuration of the checks.
+// Every check registered with [checks.Register] is configured under its name.
 type Config struct {
-	Health     *health.Config     `yaml:"health" json:"health"`
-	Latency    *latency.Config    `yaml:"latency" json:"latency"`
-	Dns        *dns.Config        `yaml:"dns" json:"dns"`
-	Traceroute *traceroute.Config `yaml:"traceroute" json:"traceroute"`
+	// Checks holds the configurations of the checks keyed by the name of the check instance.
+	// The default instance of a check is keyed by its check name, additional named
+	// instances by their name in the form "<check>/<instance>".
+	Checks map[string]checks.Runtime
+}
+
+// UnmarshalYAML decodes the runtime configuration.
+// Keys of registered checks are decoded as their default instance and keys
+// in the form "<check>/<instance>" as named check instances.
+// Keys of unregistered checks are ignored.
+func (c *Config) UnmarshalYAML(node *yaml.Node) error {
+	*c = Config{}
+	if node.Kind != yaml.MappingNode {
+		return node.Decode(&map[string]any{})
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name, value := node.Content[i].Value, node.Content[i+1]
+		cfg, err := newInstanceConfig(name)
+		if err != nil {
+			return err
+		}
+		if cfg == nil || value.Tag == "!!null" {
+			continue
+		}
+		if err = value.Decode(cfg); err != nil {
+			return err
+		}
+		c.set(name, cfg)
+	}
+	return nil
+}
+
+// MarshalYAML encodes the runtime configuration
+func (c Config) MarshalYAML() (any, error) {
+	return c.ByName(), nil
+}
+
+// UnmarshalJSON decodes the runtime configuration.
+// Keys of registered checks are decoded as their default instance and keys
+// in the form "<check>/<instance>" as named check instances.
+// Keys of unregistered checks are ignored.
+func (c *Config) UnmarshalJSON(b []byte) error {
+	*c = Config{}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	for name, v := range raw {
+		cfg, err := newInstanceConfig(name)
+		if err != nil {
+			return err
+		}
+		if cfg == nil || string(v) == "null" {
+			continue
+		}
+		if err = json.Unmarshal(v, cfg); err != nil {
+			return err
+		}
+		c.set(name, cfg)
+	}
+	return nil
+}
+
+// MarshalJSON encodes the runtime configuration
+func (c Config) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.ByName())
+}
+
+// set sets the configuration of the check instance with the given name
+func (c *Config) set(name string, cfg checks.Runtime) {
+	if c.Checks == nil {
+		c.Checks = make(map[string]checks.Runtime)
+	}
+	c.Checks[name] = cfg
+}
+
+// newInstanceConfig returns an empty configuration for the check type of the named instance.
+// It returns nil if the name is not the name of a registered check.
+func newInstanceConfig(name string) (checks.Runtime, error) {
+	check, instance := checks.SplitName(name)
+	f, ok := checks.Lookup(check)
+	if !ok {
+		if instance == "" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unknown check type %q of check instance %q", check, name)
+	}
+	return f.NewConfig(), nil
 }
 
 // Empty returns true if no checks are configured
@@ -30,106 +135,80 @@ func (c Config) Empty() bool {
 }
 
 func (c Config) Validate() (err error) {
-	for _, cfg := range c.Iter() {
+	byName := c.ByName()
+	for _, name := range slices.Sorted(maps.Keys(byName)) {
+		cfg := byName[name]
+		if vErr := validateInstance(name, cfg); vErr != nil {
+			err = errors.Join(err, vErr)
+			continue
+		}
 		if vErr := cfg.Validate(); vErr != nil {
 			err = errors.Join(err, vErr)
 		}
 	}
-
 	return err
 }
 
-// Iter returns configured checks in an iterable format
-func (c Config) Iter() []checks.Runtime {
-	var configs []checks.Runtime
-	if c.Health != nil {
-		configs = append(configs, c.Health)
+// validateInstance checks if the name of a check instance is valid
+func validateInstance(name string, cfg checks.Runtime) error {
+	check, instance := checks.SplitName(name)
+	if check != cfg.For() {
+		return checks.ErrInvalidConfig{CheckName: name, Field: "name", Reason: "name must be the check type, optionally followed by " + checks.InstanceSeparator + " and the instance name"}
 	}
-	if c.Latency != nil {
-		configs = append(configs, c.Latency)
+	if !strings.Contains(name, checks.InstanceSeparator) {
+		return nil
 	}
-	if c.Dns != nil {
-		configs = append(configs, c.Dns)
+	if !instanceNameRegex.MatchString(instance) || instance == historySuffix {
+		return checks.ErrInvalidConfig{CheckName: name, Field: "name", Reason: fmt.Sprintf("instance name must match %s and must not be %q", instanceNameRegex, historySuffix)}
 	}
-	if c.Traceroute != nil {
-		configs = append(configs, c.Traceroute)
-	}
-	return configs
+	return nil
 }
 
-// size returns the number of checks configured
-func (c Config) size() int {
-	size := 0
-	if c.HasHealthCheck() {
-		size++
-	}
-	if c.HasLatencyCheck() {
-		size++
-	}
-	if c.HasDNSCheck() {
-		size++
-	}
-	if c.HasTracerouteCheck() {
-		size++
+// Iter returns the configured checks sorted by the name of the check instance
+func (c Config) Iter() []checks.Runtime {
+	byName := c.ByName()
+	configs := make([]checks.Runtime, 0, len(byName))
+	for _, name := range slices.Sorted(maps.Keys(byName)) {
+		configs = append(configs, byName[name])
 	}
-	return size
-}
-
-// HasHealthCheck returns true if the check has a health check configured
-func (c Config) HasHealthCheck() bool {
-	return c.Health != nil
-}
-
-// HasLatencyCheck returns true if the check has a latency check configured
-func (c Config) HasLatencyCheck() bool {
-	return c.Latency != nil
+	return configs
 }
 
-// HasDNSCheck returns true if the check has a dns check configured
-func (c Config) HasDNSCheck() bool {
-	return c.Dns != nil
+// ByName returns the configured checks keyed by the name of the check instance.
+// The default instance of a check is named after the check type.
+func (c Config) ByName() map[string]checks.Runtime {
+	configs := make(map[string]checks.Runtime, len(c.Checks))
+	for name, cfg := range c.Checks {
+		if cfg != nil {
+			configs[name] = cfg
+		}
+	}
+	return configs
 }
 
-// HasTracerouteCheck returns true if the check has a traceroute check configured
-func (c Config) HasTracerouteCheck() bool {
-	return c.Traceroute != nil
+// size returns the number of checks configured
+func (c Config) size() int {
+	return len(c.ByName())
 }
 
-// HasCheck returns true if the check has a check with the given name configured
+// HasCheck returns true if the check has a check with the given name configured.
+// The name may also be the name of a named check instance.
 func (c Config) HasCheck(name string) bool {
-	switch name {
-	case health.CheckName:
-		return c.HasHealthCheck()
-	case latency.CheckName:
-		return c.HasLatencyCheck()
-	case dns.CheckName:
-		return c.HasDNSCheck()
-	case traceroute.CheckName:
-		return c.HasTracerouteCheck()
-	default:
-		return false
-	}
+	return c.For(name) != nil
 }
 
-// For returns the runtime configuration for the check with the given name
+// For returns the runtime configuration for the check with the given name.
+// The name may also be the name of a named check instance.
 func (c Config) For(name string) checks.Runtime {
-	switch name {
-	case health.CheckName:
-		if c.HasHealthCheck() {
-			return c.Health
-		}
-	case latency.CheckName:
-		if c.HasLatencyCheck() {
-			return c.Latency
-		}
-	case dns.CheckName:
-		if c.HasDNSCheck() {
-			return c.Dns
-		}
-	case traceroute.CheckName:
-		if c.HasTracerouteCheck() {
-			return c.Traceroute
+	return c.ByName()[name]
+}
+
+// AllowsExec returns an error if the policy doesn't allow the configured exec checks
+func (c Config) AllowsExec(p exec.Policy) (err error) {
+	for name, cfg := range c.ByName() {
+		if e, ok := cfg.(*exec.Config); ok {
+			err = errors.Join(err, p.Allows(name, e))
 		}
 	}
-	return nil
+	return err
 }
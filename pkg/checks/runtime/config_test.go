@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telekom/sparrow/internal/helper"
+	"github.com/telekom/sparrow/pkg/checks"
+	"github.com/telekom/sparrow/pkg/checks/exec"
+	"github.com/telekom/sparrow/pkg/checks/latency"
+	"gopkg.in/yaml.v3"
+)
+
+func TestConfig_Unmarshal(t *testing.T) {
+	want := Config{Checks: map[string]checks.Runtime{
+		"latency": &latency.Config{Targets: []latency.Target{{URL: "https://example.com"}}, Interval: time.Minute, Timeout: time.Second},
+		"latency/internal": &latency.Config{
+			Targets:  []latency.Target{{URL: "https://internal.example.com"}},
+			Interval: 10 * time.Second,
+			Timeout:  time.Second,
+			Retry:    helper.RetryConfig{Count: 1, Delay: time.Second},
+		},
+	}}
+
+	t.Run("yaml", func(t *testing.T) {
+		var got Config
+		err := yaml.Unmarshal([]byte(`
+latency:
+  targets: [https://example.com]
+  interval: 1m
+  timeout: 1s
+latency/internal:
+  targets: [https://internal.example.com]
+  interval: 10s
+  timeout: 1s
+  retry:
+    count: 1
+    delay: 1s
+`), &got)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+
+		b, err := yaml.Marshal(got)
+		require.NoError(t, err)
+		var roundtrip Config
+		require.NoError(t, yaml.Unmarshal(b, &roundtrip))
+		assert.Equal(t, want, roundtrip)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		b, err := json.Marshal(want)
+		require.NoError(t, err)
+		var got Config
+		require.NoError(t, json.Unmarshal(b, &got))
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("check without configuration", func(t *testing.T) {
+		var got Config
+		require.NoError(t, yaml.Unmarshal([]byte("latency:\nhealth: {}\n"), &got))
+		assert.False(t, got.HasCheck("latency"))
+		assert.True(t, got.HasCheck("health"))
+
+		require.NoError(t, json.Unmarshal([]byte(`{"latency": null, "health": {}}`), &got))
+		assert.False(t, got.HasCheck("latency"))
+		assert.True(t, got.HasCheck("health"))
+	})
+
+	t.Run("unknown check type", func(t *testing.T) {
+		var got Config
+		assert.Error(t, yaml.Unmarshal([]byte("unknown/internal: {}"), &got))
+	})
+}
+
+func TestConfig_Instances(t *testing.T) {
+	internal := &latency.Config{Targets: []latency.Target{{URL: "https://internal.example.com"}}, Interval: 10 * time.Second, Timeout: time.Second}
+	cfg := Config{Checks: map[string]checks.Runtime{
+		"latency":          &latency.Config{Interval: time.Minute, Timeout: time.Second},
+		"latency/internal": internal,
+	}}
+
+	assert.Equal(t, 2, cfg.size())
+	assert.True(t, cfg.HasCheck("latency/internal"))
+	assert.False(t, cfg.HasCheck("latency/external"))
+	assert.Same(t, internal, cfg.For("latency/internal"))
+	assert.Equal(t, map[string]checks.Runtime{"latency": cfg.For("latency"), "latency/internal": internal}, cfg.ByName())
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_Validate_Instances(t *testing.T) {
+	valid := &latency.Config{Interval: time.Minute, Timeout: time.Second}
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{name: "latency/internal"},
+		{name: "latency/internal-2.eu"},
+		{name: "latency"},
+		{name: "health", wantErr: true},
+		{name: "health/internal", wantErr: true},
+		{name: "latency/", wantErr: true},
+		{name: "latency/a/b", wantErr: true},
+		{name: "latency/history", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{Checks: map[string]checks.Runtime{tt.name: valid}}
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Config.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_AllowsExec(t *testing.T) {
+	probe := &exec.Config{Targets: []exec.Target{{Name: "probe", Command: "/usr/bin/probe"}}}
+	shell := &exec.Config{Targets: []exec.Target{{Name: "shell", Command: "sh"}}}
+	policy := exec.Policy{Enabled: true, Commands: []exec.AllowedCommand{{Command: "/usr/bin/probe"}}}
+
+	assert.NoError(t, Config{Checks: map[string]checks.Runtime{"latency": &latency.Config{}}}.AllowsExec(exec.Policy{}))
+	assert.Error(t, Config{Checks: map[string]checks.Runtime{"exec": probe}}.AllowsExec(exec.Policy{}))
+	assert.NoError(t, Config{Checks: map[string]checks.Runtime{"exec": probe, "exec/probe": probe}}.AllowsExec(policy))
+	assert.Error(t, Config{Checks: map[string]checks.Runtime{"exec": probe, "exec/shell": shell}}.AllowsExec(policy))
+}
+
+// exampleConfig is the runtime configuration of a check registered outside of sparrow
+type exampleConfig struct {
+	Targets []string `json:"targets" yaml:"targets"`
+}
+
+func (c *exampleConfig) For() string     { return "example" }
+func (c *exampleConfig) Validate() error { return nil }
+
+func TestConfig_Registered(t *testing.T) {
+	require.NoError(t, checks.Register("example", checks.Factory{
+		NewCheck:  func() checks.Check { return &checks.CheckMock{} },
+		NewConfig: func() checks.Runtime { return &exampleConfig{} },
+	}))
+
+	var cfg Config
+	require.NoError(t, yaml.Unmarshal([]byte(`
+latency:
+  interval: 1m
+  timeout: 1s
+example:
+  targets: [a]
+example/second:
+  targets: [b]
+unregistered:
+  targets: [c]
+`), &cfg))
+
+	assert.Equal(t, 3, cfg.size())
+	assert.True(t, cfg.HasCheck("example"))
+	assert.False(t, cfg.HasCheck("unregistered"))
+	assert.Equal(t, &exampleConfig{Targets: []string{"a"}}, cfg.For("example"))
+	assert.Equal(t, &exampleConfig{Targets: []string{"b"}}, cfg.For("example/second"))
+	assert.Equal(t, []checks.Runtime{cfg.For("example"), cfg.For("example/second"), cfg.For("latency")}, cfg.Iter())
+	assert.NoError(t, cfg.Validate())
+
+	b, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	var roundtrip Config
+	require.NoError(t, json.Unmarshal(b, &roundtrip))
+	assert.Equal(t, cfg, roundtrip)
+}
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/telekom/sparrow/pkg/checks"
+)
+
+// targetsField is the name of the field holding the targets of a check
+const targetsField = "Targets"
+
+// Merge deep-merges the given runtime configurations in order, so later
+// configurations take precedence over earlier ones:
+//   - A check configured in a single configuration is taken as is.
+//   - Targets are appended. A target with the same identity as
+//     a target of an earlier configuration replaces it in place.
+//   - Other lists replace the lists of earlier configurations if they are not empty.
+//   - Maps are merged by key.
+//   - Scalar fields override the fields of earlier configurations if they are set.
+//     A field can't be reset to its zero value by a later configuration.
+//
+// The merged configuration doesn't share any check configuration with the given ones.
+func Merge(configs ...Config) Config {
+	merged := make(map[string]checks.Runtime)
+	for _, c := range configs {
+		for name, cfg := range c.ByName() {
+			merged[name] = mergeCheck(merged[name], cfg)
+		}
+	}
+
+	var result Config
+	for name, cfg := range merged {
+		result.set(name, cfg)
+	}
+	return result
+}
+
+// mergeCheck returns a new check configuration with the overlay merged into the base.
+// The base may be nil. Configurations which aren't pointers to structs can't be merged,
+// so the overlay replaces the base.
+func mergeCheck(base, overlay checks.Runtime) checks.Runtime {
+	t := reflect.TypeOf(overlay)
+	if t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct {
+		return overlay
+	}
+	if base != nil && reflect.TypeOf(base) != t {
+		return overlay
+	}
+
+	merged := reflect.New(t.Elem())
+	if base != nil {
+		mergeValue(merged.Elem(), reflect.ValueOf(base).Elem())
+	}
+	mergeValue(merged.Elem(), reflect.ValueOf(overlay).Elem())
+	return merged.Interface().(checks.Runtime)
+}
+
+// mergeValue merges the src struct into the dst struct field by field
+func mergeValue(dst, src reflect.Value) {
+	for i := range src.NumField() {
+		f := src.Type().Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		mergeField(dst.Field(i), src.Field(i), f.Name == targetsField)
+	}
+}
+
+// mergeField merges the src field into the dst field
+func mergeField(dst, src reflect.Value, targets bool) {
+	switch src.Kind() {
+	case reflect.Struct:
+		mergeValue(dst, src)
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+		if src.Elem().Kind() != reflect.Struct {
+			dst.Set(src)
+			return
+		}
+		merged := reflect.New(src.Type().Elem())
+		if !dst.IsNil() {
+			mergeValue(merged.Elem(), dst.Elem())
+		}
+		mergeValue(merged.Elem(), src.Elem())
+		dst.Set(merged)
+	case reflect.Slice:
+		if targets {
+			dst.Set(mergeTargets(dst, src))
+			return
+		}
+		if src.Len() > 0 {
+			dst.Set(reflect.AppendSlice(reflect.MakeSlice(src.Type(), 0, src.Len()), src))
+		}
+	case reflect.Map:
+		if src.Len() == 0 {
+			return
+		}
+		merged := reflect.MakeMapWithSize(src.Type(), dst.Len()+src.Len())
+		for _, m := range []reflect.Value{dst, src} {
+			iter := m.MapRange()
+			for iter.Next() {
+				merged.SetMapIndex(iter.Key(), iter.Value())
+			}
+		}
+		dst.Set(merged)
+	default:
+		if !src.IsZero() {
+			dst.Set(src)
+		}
+	}
+}
+
+// mergeTargets returns a new list of the dst targets with the src targets appended.
+// A src target with the same identity as a dst target replaces it in place.
+func mergeTargets(dst, src reflect.Value) reflect.Value {
+	merged := reflect.AppendSlice(reflect.MakeSlice(dst.Type(), 0, dst.Len()+src.Len()), dst)
+	index := make(map[string]int, merged.Len())
+	for i := range merged.Len() {
+		index[targetKey(merged.Index(i))] = i
+	}
+
+	for i := range src.Len() {
+		t := src.Index(i)
+		key := targetKey(t)
+		if j, ok := index[key]; ok {
+			merged.Index(j).Set(t)
+			continue
+		}
+		index[key] = merged.Len()
+		merged = reflect.Append(merged, t)
+	}
+	return merged
+}
+
+// targetKey returns the identity of a target.
+// It is the key or string representation of the target if it provides one,
+// otherwise the first field of the target, which holds its url, address or name.
+func targetKey(t reflect.Value) string {
+	switch v := t.Interface().(type) {
+	case interface{ Key() string }:
+		return v.Key()
+	case fmt.Stringer:
+		return v.String()
+	}
+	if t.Kind() == reflect.Struct && t.NumField() > 0 {
+		return fmt.Sprint(t.Field(0).Interface())
+	}
+	return fmt.Sprint(t.Interface())
+}
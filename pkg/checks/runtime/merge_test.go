@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telekom/sparrow/internal/helper"
+	"github.com/telekom/sparrow/pkg/checks"
+	"github.com/telekom/sparrow/pkg/checks/dns"
+	"github.com/telekom/sparrow/pkg/checks/grpc"
+	"github.com/telekom/sparrow/pkg/checks/health"
+	"github.com/telekom/sparrow/pkg/checks/latency"
+	"gopkg.in/yaml.v3"
+)
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name    string
+		configs []string
+		want    Config
+	}{
+		{
+			name: "no configs",
+			want: Config{},
+		},
+		{
+			name: "disjoint checks",
+			configs: []string{
+				"health:\n  targets: [https://a.example.com]\n  interval: 1m\n",
+				"latency:\n  targets: [https://b.example.com]\n  interval: 1m\n",
+			},
+			want: Config{Checks: map[string]checks.Runtime{
+				"health":  &health.Config{Targets: []health.Target{{URL: "https://a.example.com"}}, Interval: time.Minute},
+				"latency": &latency.Config{Targets: []latency.Target{{URL: "https://b.example.com"}}, Interval: time.Minute},
+			}},
+		},
+		{
+			name: "targets are appended and scalars overridden",
+			configs: []string{
+				"health:\n  targets: [https://a.example.com, https://b.example.com]\n  interval: 1m\n  timeout: 5s\n  retry: {count: 3, delay: 1s}\n",
+				"health:\n  targets: [https://c.example.com]\n  timeout: 10s\n",
+			},
+			want: Config{Checks: map[string]checks.Runtime{
+				"health": &health.Config{
+					Targets:  []health.Target{{URL: "https://a.example.com"}, {URL: "https://b.example.com"}, {URL: "https://c.example.com"}},
+					Interval: time.Minute,
+					Timeout:  10 * time.Second,
+					Retry:    helper.RetryConfig{Count: 3, Delay: time.Second},
+				},
+			}},
+		},
+		{
+			name: "targets with the same identity are replaced in place",
+			configs: []string{
+				"health:\n  targets: [https://a.example.com, https://b.example.com]\n",
+				"health:\n  targets:\n    - url: https://a.example.com\n      method: HEAD\n      interval: 1h\n",
+			},
+			want: Config{Checks: map[string]checks.Runtime{
+				"health": &health.Config{Targets: []health.Target{
+					{URL: "https://a.example.com", Method: "HEAD", TargetOverrides: checks.TargetOverrides{Interval: time.Hour}},
+					{URL: "https://b.example.com"},
+				}},
+			}},
+		},
+		{
+			name: "targets are identified by their key",
+			configs: []string{
+				"dns:\n  targets: [example.com]\n",
+				"dns:\n  targets:\n    - example.com\n    - name: example.com\n      type: AAAA\n",
+			},
+			want: Config{Checks: map[string]checks.Runtime{
+				"dns": &dns.Config{Targets: []dns.Target{{Name: "example.com"}, {Name: "example.com", Type: "AAAA"}}},
+			}},
+		},
+		{
+			name: "named instances are merged",
+			configs: []string{
+				"latency/internal:\n  targets: [https://a.example.com]\n  interval: 1m\n",
+				"latency/internal:\n  targets: [https://b.example.com]\n",
+			},
+			want: Config{Checks: map[string]checks.Runtime{"latency/internal": &latency.Config{
+				Targets:  []latency.Target{{URL: "https://a.example.com"}, {URL: "https://b.example.com"}},
+				Interval: time.Minute,
+			},
+			}},
+		},
+		{
+			name: "booleans can be disabled again",
+			configs: []string{
+				"grpc:\n  targets: [a.example.com:443]\n  watch: true\n  tls:\n    enabled: true\n",
+				"grpc:\n  tls:\n    enabled: false\n",
+				"latency:\n  targets: [https://a.example.com]\n  phases: true\n",
+				"latency:\n  phases: false\n",
+			},
+			want: Config{Checks: map[string]checks.Runtime{
+				"grpc": &grpc.Config{
+					Targets: []grpc.Target{{Address: "a.example.com:443"}},
+					Watch:   new(true),
+					TLS:     grpc.TLSConfig{Enabled: new(false)},
+				},
+				"latency": &latency.Config{Targets: []latency.Target{{URL: "https://a.example.com"}}, Phases: new(false)},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configs := make([]Config, len(tt.configs))
+			for i, c := range tt.configs {
+				if err := yaml.Unmarshal([]byte(c), &configs[i]); err != nil {
+					t.Fatalf("Failed to unmarshal config: %v", err)
+				}
+			}
+			assert.Equal(t, tt.want, Merge(configs...))
+		})
+	}
+}
+
+func TestMerge_NoSharing(t *testing.T) {
+	base := Config{Checks: map[string]checks.Runtime{"health": &health.Config{
+		Targets: []health.Target{{URL: "https://a.example.com"}},
+	}}}
+	overlay := Config{Checks: map[string]checks.Runtime{"health": &health.Config{
+		Targets: []health.Target{{URL: "https://b.example.com"}},
+	}}}
+
+	merged := Merge(base, overlay)
+	baseHealth, overlayHealth := base.For("health").(*health.Config), overlay.For("health").(*health.Config)
+	mergedHealth := merged.For("health").(*health.Config)
+	assert.NotSame(t, baseHealth, mergedHealth)
+	assert.NotSame(t, overlayHealth, mergedHealth)
+
+	mergedHealth.Targets = append(mergedHealth.Targets, health.Target{URL: "https://c.example.com"})
+	mergedHealth.Targets[0].URL = "https://changed.example.com"
+	assert.Len(t, baseHealth.Targets, 1)
+	assert.Equal(t, "https://a.example.com", baseHealth.Targets[0].URL)
+	assert.Len(t, overlayHealth.Targets, 1)
+}
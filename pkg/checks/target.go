@@ -0,0 +1,241 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/telekom/sparrow/internal/helper"
+	"gopkg.in/yaml.v3"
+)
+
+// TargetOverrides holds the settings of a single target
+// which override the settings of the check for this target.
+// Unset fields fall back to the settings of the check.
+type TargetOverrides struct {
+	// Interval is the time to wait between two checks of the target
+	Interval time.Duration `json:"interval,omitempty" yaml:"interval,omitempty" mapstructure:"interval"`
+	// Timeout is the timeout of a single check of the target
+	Timeout time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty" mapstructure:"timeout"`
+	// Retry defines if and how to retry the target
+	Retry *helper.RetryConfig `json:"retry,omitempty" yaml:"retry,omitempty" mapstructure:"retry"`
+}
+
+// IsZero returns true if no setting is overridden
+func (o TargetOverrides) IsZero() bool {
+	return o.Interval == 0 && o.Timeout == 0 && o.Retry == nil
+}
+
+// IntervalOr returns the interval of the target or the given interval if it is not overridden
+func (o TargetOverrides) IntervalOr(interval time.Duration) time.Duration {
+	if o.Interval > 0 {
+		return o.Interval
+	}
+	return interval
+}
+
+// TimeoutOr returns the timeout of the target or the given timeout if it is not overridden
+func (o TargetOverrides) TimeoutOr(timeout time.Duration) time.Duration {
+	if o.Timeout > 0 {
+		return o.Timeout
+	}
+	return timeout
+}
+
+// RetryOr returns the retry configuration of the target or the given one if it is not overridden
+func (o TargetOverrides) RetryOr(retry helper.RetryConfig) helper.RetryConfig {
+	if o.Retry != nil {
+		return *o.Retry
+	}
+	return retry
+}
+
+// Validate checks if the overridden settings of the target at the given
+// field are valid for the check with the given name
+func (o TargetOverrides) Validate(check, field string, minInterval, minTimeout time.Duration) error {
+	if o.Interval != 0 && o.Interval < minInterval {
+		return ErrInvalidConfig{CheckName: check, Field: field + ".interval", Reason: fmt.Sprintf("interval must be at least %v", minInterval)}
+	}
+	if o.Timeout != 0 && o.Timeout < minTimeout {
+		return ErrInvalidConfig{CheckName: check, Field: field + ".timeout", Reason: fmt.Sprintf("timeout must be at least %v", minTimeout)}
+	}
+	if o.Retry != nil && (o.Retry.Count < 0 || o.Retry.Delay < 0) {
+		return ErrInvalidConfig{CheckName: check, Field: field + ".retry", Reason: "retry count and delay must not be negative"}
+	}
+	return nil
+}
+
+// Target is implemented by the targets of the checks, which embed the TargetOverrides
+type Target interface {
+	IsZero() bool
+	IntervalOr(interval time.Duration) time.Duration
+}
+
+// Intervals returns the check interval of every target keyed by the given key of the target
+func Intervals[T Target](targets []T, key func(T) string, interval time.Duration) map[string]time.Duration {
+	intervals := make(map[string]time.Duration, len(targets))
+	for _, t := range targets {
+		intervals[key(t)] = t.IntervalOr(interval)
+	}
+	return intervals
+}
+
+// PlainTarget (un)marshals targets which can be configured either as a plain string,
+// which sets the key field of the target, or as an object.
+// F must be the target type without its custom (un)marshalers, declared as `type targetFields Target`.
+type PlainTarget[T Target, F any] struct {
+	// Key returns the key field of the target, like its url or address
+	Key func(t *T) *string
+	// IsPlain returns true if the target only consists of its key field.
+	// If nil, targets without overrides are plain
+	IsPlain func(t T) bool
+}
+
+// isPlain returns true if the target can be marshaled as its key field
+func (p PlainTarget[T, F]) isPlain(t T) bool {
+	if p.IsPlain == nil {
+		return t.IsZero()
+	}
+	return p.IsPlain(t)
+}
+
+// DecodeYAML decodes a target from either a plain string or an object
+func (p PlainTarget[T, F]) DecodeYAML(value *yaml.Node, t *T) error {
+	if value.Kind == yaml.ScalarNode {
+		var zero T
+		*t = zero
+		return value.Decode(p.Key(t))
+	}
+	return value.Decode(convert[*F](t))
+}
+
+// EncodeYAML encodes a plain target as its key field and other targets as an object
+func (p PlainTarget[T, F]) EncodeYAML(t T) (any, error) {
+	if p.isPlain(t) {
+		return *p.Key(&t), nil
+	}
+	return convert[F](t), nil
+}
+
+// DecodeJSON decodes a target from either a plain string or an object
+func (p PlainTarget[T, F]) DecodeJSON(b []byte, t *T) error {
+	var key string
+	if err := json.Unmarshal(b, &key); err == nil {
+		var zero T
+		*t = zero
+		*p.Key(t) = key
+		return nil
+	}
+	return json.Unmarshal(b, convert[*F](t))
+}
+
+// EncodeJSON encodes a plain target as its key field and other targets as an object
+func (p PlainTarget[T, F]) EncodeJSON(t T) ([]byte, error) {
+	if p.isPlain(t) {
+		return json.Marshal(*p.Key(&t))
+	}
+	return json.Marshal(convert[F](t))
+}
+
+// convert converts the value to the given type with the same underlying type
+func convert[To any](v any) To {
+	return reflect.ValueOf(v).Convert(reflect.TypeFor[To]()).Interface().(To)
+}
+
+// Scheduler keeps track of when the targets of a check were checked last,
+// so every target can be checked in its own interval.
+// It keeps the latest result of every target, so a check can report
+// the results of all targets even if only some of them were due.
+type Scheduler[T any] struct {
+	mu      sync.Mutex
+	last    map[string]time.Time
+	results map[string]T
+	checked []string
+}
+
+// Next returns the time to wait until the next target is due.
+// The intervals are keyed by target. Targets which were never checked
+// are due after their interval. If there are no targets, the fallback is returned.
+func (s *Scheduler[T]) Next(now time.Time, intervals map[string]time.Duration, fallback time.Duration) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next := time.Duration(-1)
+	for target, interval := range intervals {
+		wait := interval
+		if last, ok := s.last[target]; ok {
+			wait = max(last.Add(interval).Sub(now), 0)
+		}
+		if next < 0 || wait < next {
+			next = wait
+		}
+	}
+	if next < 0 {
+		return fallback
+	}
+	return next
+}
+
+// Due returns the targets which are due at the given time and marks them as checked.
+// Targets which were never checked are always due. Targets not contained
+// in the intervals are forgotten along with their latest result.
+func (s *Scheduler[T]) Due(now time.Time, intervals map[string]time.Duration) map[string]bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.last == nil {
+		s.last = make(map[string]time.Time)
+	}
+
+	for target := range s.last {
+		if _, ok := intervals[target]; !ok {
+			delete(s.last, target)
+			delete(s.results, target)
+		}
+	}
+
+	due := make(map[string]bool)
+	for target, interval := range intervals {
+		if last, ok := s.last[target]; ok && now.Sub(last) < interval {
+			continue
+		}
+		due[target] = true
+		s.last[target] = now
+	}
+	return due
+}
+
+// Merge stores the results of the checked targets and
+// returns the latest results of all known targets
+func (s *Scheduler[T]) Merge(results map[string]T) map[string]T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.results == nil {
+		s.results = make(map[string]T)
+	}
+
+	s.checked = make([]string, 0, len(results))
+	for target, res := range results {
+		if _, ok := s.last[target]; ok {
+			s.results[target] = res
+			s.checked = append(s.checked, target)
+		}
+	}
+	slices.Sort(s.checked)
+
+	return maps.Clone(s.results)
+}
+
+// Checked returns the targets whose results were stored by the latest merge
+func (s *Scheduler[T]) Checked() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.checked)
+}
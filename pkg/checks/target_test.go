@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package checks
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telekom/sparrow/internal/helper"
+	"gopkg.in/yaml.v3"
+)
+
+func TestTargetOverrides(t *testing.T) {
+	retry := helper.RetryConfig{Count: 1, Delay: time.Second}
+	noRetry := helper.RetryConfig{}
+
+	o := TargetOverrides{}
+	assert.True(t, o.IsZero())
+	assert.Equal(t, time.Minute, o.IntervalOr(time.Minute))
+	assert.Equal(t, time.Second, o.TimeoutOr(time.Second))
+	assert.Equal(t, retry, o.RetryOr(retry))
+
+	o = TargetOverrides{Interval: time.Hour, Timeout: 30 * time.Second, Retry: &noRetry}
+	assert.False(t, o.IsZero())
+	assert.Equal(t, time.Hour, o.IntervalOr(time.Minute))
+	assert.Equal(t, 30*time.Second, o.TimeoutOr(time.Second))
+	assert.Equal(t, noRetry, o.RetryOr(retry))
+}
+
+func TestTargetOverrides_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides TargetOverrides
+		wantField string
+	}{
+		{name: "no overrides"},
+		{name: "valid overrides", overrides: TargetOverrides{Interval: time.Minute, Timeout: time.Second, Retry: &helper.RetryConfig{}}},
+		{name: "interval too short", overrides: TargetOverrides{Interval: time.Millisecond}, wantField: "targets[0].interval"},
+		{name: "timeout too short", overrides: TargetOverrides{Timeout: time.Millisecond}, wantField: "targets[0].timeout"},
+		{name: "negative retry count", overrides: TargetOverrides{Retry: &helper.RetryConfig{Count: -1}}, wantField: "targets[0].retry"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.overrides.Validate("test", "targets[0]", time.Second, time.Second)
+			if tt.wantField == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var cErr ErrInvalidConfig
+			if assert.ErrorAs(t, err, &cErr) {
+				assert.Equal(t, tt.wantField, cErr.Field)
+			}
+		})
+	}
+}
+
+type testTarget struct {
+	Address         string `json:"address" yaml:"address"`
+	TargetOverrides `yaml:",inline"`
+}
+
+type testTargetFields testTarget
+
+var plainTestTarget = PlainTarget[testTarget, testTargetFields]{
+	Key: func(t *testTarget) *string { return &t.Address },
+}
+
+func (t *testTarget) UnmarshalYAML(value *yaml.Node) error {
+	return plainTestTarget.DecodeYAML(value, t)
+}
+func (t testTarget) MarshalYAML() (any, error)     { return plainTestTarget.EncodeYAML(t) }
+func (t *testTarget) UnmarshalJSON(b []byte) error { return plainTestTarget.DecodeJSON(b, t) }
+func (t testTarget) MarshalJSON() ([]byte, error)  { return plainTestTarget.EncodeJSON(t) }
+
+func TestPlainTarget(t *testing.T) {
+	want := []testTarget{
+		{Address: "a.example.com:443"},
+		{Address: "b.example.com:443", TargetOverrides: TargetOverrides{Interval: time.Minute}},
+	}
+
+	tests := []struct {
+		name      string
+		yaml      string
+		json      string
+		marshal   func(any) ([]byte, error)
+		unmarshal func([]byte, any) error
+	}{
+		{
+			name:      "yaml",
+			yaml:      "- a.example.com:443\n- address: b.example.com:443\n  interval: 1m0s\n",
+			marshal:   yaml.Marshal,
+			unmarshal: yaml.Unmarshal,
+		},
+		{
+			name:      "json",
+			json:      `["a.example.com:443",{"address":"b.example.com:443","interval":60000000000}]`,
+			marshal:   json.Marshal,
+			unmarshal: json.Unmarshal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := tt.yaml + tt.json
+			var got []testTarget
+			require.NoError(t, tt.unmarshal([]byte(doc), &got))
+			assert.Equal(t, want, got)
+
+			b, err := tt.marshal(got)
+			require.NoError(t, err)
+			assert.Equal(t, doc, string(b))
+		})
+	}
+}
+
+func TestIntervals(t *testing.T) {
+	targets := []testTarget{
+		{Address: "a.example.com:443"},
+		{Address: "b.example.com:443", TargetOverrides: TargetOverrides{Interval: time.Hour}},
+	}
+
+	got := Intervals(targets, func(t testTarget) string { return t.Address }, time.Minute)
+	assert.Equal(t, map[string]time.Duration{
+		"a.example.com:443": time.Minute,
+		"b.example.com:443": time.Hour,
+	}, got)
+}
+
+func TestScheduler(t *testing.T) {
+	var s Scheduler[int]
+	now := time.Now()
+	intervals := map[string]time.Duration{"fast": time.Second, "slow": time.Minute}
+
+	// targets which were never checked are due after their interval,
+	// but are checked immediately when the check runs
+	assert.Equal(t, time.Second, s.Next(now, intervals, time.Hour))
+	assert.Equal(t, map[string]bool{"fast": true, "slow": true}, s.Due(now, intervals))
+	assert.Equal(t, map[string]int{"fast": 1, "slow": 1}, s.Merge(map[string]int{"fast": 1, "slow": 1}))
+	assert.Equal(t, []string{"fast", "slow"}, s.Checked())
+
+	// only the fast target is due, the result of the slow target is kept
+	now = now.Add(time.Second)
+	assert.Equal(t, time.Duration(0), s.Next(now, intervals, time.Hour))
+	assert.Equal(t, map[string]bool{"fast": true}, s.Due(now, intervals))
+	assert.Equal(t, map[string]int{"fast": 2, "slow": 1}, s.Merge(map[string]int{"fast": 2}))
+	assert.Equal(t, []string{"fast"}, s.Checked())
+	assert.Equal(t, time.Second, s.Next(now, intervals, time.Hour))
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, map[string]bool{"fast": true, "slow": true}, s.Due(now, intervals))
+
+	// removed targets are forgotten
+	delete(intervals, "slow")
+	assert.Equal(t, map[string]bool{}, s.Due(now.Add(time.Millisecond), intervals))
+	assert.Equal(t, map[string]int{"fast": 2}, s.Merge(map[string]int{"slow": 3}))
+
+	// without targets, the fallback is returned
+	assert.Equal(t, time.Hour, s.Next(now, nil, time.Hour))
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tcp
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/telekom/sparrow/internal/helper"
+	"github.com/telekom/sparrow/pkg/checks"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	minInterval = 100 * time.Millisecond
+	minTimeout  = 100 * time.Millisecond
+	maxPort     = 65535
+)
+
+// Config defines the configuration parameters for a tcp check
+type Config struct {
+	// Targets is a list of "host:port" addresses to connect to
+	Targets  []Target           `json:"targets,omitempty" yaml:"targets,omitempty"`
+	Interval time.Duration      `json:"interval" yaml:"interval"`
+	Timeout  time.Duration      `json:"timeout" yaml:"timeout"`
+	Retry    helper.RetryConfig `json:"retry" yaml:"retry"`
+}
+
+// Target defines a tcp check target.
+// A target can be configured as a plain "host:port" string,
+// in which case the settings of the check are used.
+type Target struct {
+	// Address is the "host:port" address to connect to
+	Address string `json:"address" yaml:"address"`
+	// TargetOverrides optionally override the interval, timeout and retry of the check
+	checks.TargetOverrides `yaml:",inline"`
+}
+
+// targetFields is used to (un)marshal a Target without its custom (un)marshalers
+type targetFields Target
+
+// plainTarget allows targets to be configured as a plain address string
+var plainTarget = checks.PlainTarget[Target, targetFields]{
+	Key: func(t *Target) *string { return &t.Address },
+}
+
+// UnmarshalYAML allows targets to be either a plain address string or an object
+func (t *Target) UnmarshalYAML(value *yaml.Node) error {
+	return plainTarget.DecodeYAML(value, t)
+}
+
+// MarshalYAML marshals targets without overrides as a plain address string
+func (t Target) MarshalYAML() (any, error) {
+	return plainTarget.EncodeYAML(t)
+}
+
+// UnmarshalJSON allows targets to be either a plain address string or an object
+func (t *Target) UnmarshalJSON(b []byte) error {
+	return plainTarget.DecodeJSON(b, t)
+}
+
+// MarshalJSON marshals targets without overrides as a plain address string
+func (t Target) MarshalJSON() ([]byte, error) {
+	return plainTarget.EncodeJSON(t)
+}
+
+// intervals returns the check interval of every target
+func (c *Config) intervals() map[string]time.Duration {
+	return checks.Intervals(c.Targets, func(t Target) string { return t.Address }, c.Interval)
+}
+
+// For returns the name of the check
+func (c *Config) For() string {
+	return CheckName
+}
+
+// Validate checks if the configuration is valid
+func (c *Config) Validate() error {
+	for i, t := range c.Targets {
+		host, port, err := net.SplitHostPort(t.Address)
+		if err != nil || host == "" {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("targets[%d]", i), Reason: "target must be in the format 'host:port'"}
+		}
+
+		p, err := strconv.Atoi(port)
+		if err != nil || p <= 0 || p > maxPort {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("targets[%d]", i), Reason: fmt.Sprintf("port must be between 1 and %d", maxPort)}
+		}
+
+		if err := t.Validate(c.For(), fmt.Sprintf("targets[%d]", i), minInterval, minTimeout); err != nil {
+			return err
+		}
+	}
+
+	if c.Interval < minInterval {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "interval", Reason: fmt.Sprintf("interval must be at least %v", minInterval)}
+	}
+
+	if c.Timeout < minTimeout {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "timeout", Reason: fmt.Sprintf("timeout must be at least %v", minTimeout)}
+	}
+
+	return nil
+}
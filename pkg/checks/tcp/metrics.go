@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tcp
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/telekom/sparrow/pkg/checks"
+)
+
+const (
+	statusMetric    = "sparrow_tcp_status"
+	durationMetric  = "sparrow_tcp_duration_seconds"
+	countMetric     = "sparrow_tcp_check_count"
+	histogramMetric = "sparrow_tcp_duration"
+	errorsMetric    = "sparrow_tcp_errors_total"
+
+	labelClass = "class"
+)
+
+// metrics defines the metric collectors of the tcp check
+type metrics struct {
+	status    *prometheus.GaugeVec
+	duration  *prometheus.GaugeVec
+	count     *prometheus.CounterVec
+	histogram *prometheus.HistogramVec
+	errors    *prometheus.CounterVec
+}
+
+// newMetrics initializes metric collectors of the tcp check
+func newMetrics() metrics {
+	return metrics{
+		status: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: statusMetric,
+				Help: "Specifies if a tcp connection to the target can be established.",
+			},
+			[]string{checks.LabelTarget},
+		),
+		duration: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: durationMetric,
+				Help: "Duration of the tcp connection establishment in seconds.",
+			},
+			[]string{checks.LabelTarget},
+		),
+		count: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: countMetric,
+				Help: "Total number of tcp checks performed on the target.",
+			},
+			[]string{checks.LabelTarget},
+		),
+		histogram: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name: histogramMetric,
+				Help: "Histogram of tcp connection times in seconds.",
+			},
+			[]string{checks.LabelTarget},
+		),
+		errors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: errorsMetric,
+				Help: "Total number of failed tcp connections by error class.",
+			},
+			[]string{checks.LabelTarget, labelClass},
+		),
+	}
+}
+
+// GetCollectors returns all metric collectors
+func (m *metrics) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.status,
+		m.duration,
+		m.count,
+		m.histogram,
+		m.errors,
+	}
+}
+
+// Set sets the metrics of one target result
+func (m *metrics) Set(target string, res result) {
+	status := 0.0
+	if res.Success {
+		status = 1
+		m.histogram.WithLabelValues(target).Observe(res.Total)
+	} else {
+		m.errors.WithLabelValues(target, res.ErrorClass).Inc()
+	}
+	m.duration.WithLabelValues(target).Set(res.Total)
+	m.status.WithLabelValues(target).Set(status)
+	m.count.WithLabelValues(target).Inc()
+}
+
+// Remove removes the metrics of one target
+func (m *metrics) Remove(target string) error {
+	if !m.status.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+
+	if !m.duration.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+
+	if !m.count.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+
+	// The histogram and error counters are only set for
+	// successful or failed connections respectively
+	m.histogram.DeleteLabelValues(target)
+	m.errors.DeletePartialMatch(prometheus.Labels{checks.LabelTarget: target})
+
+	return nil
+}
@@ -0,0 +1,271 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tcp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/telekom/sparrow/internal/helper"
+	"github.com/telekom/sparrow/internal/logger"
+	"github.com/telekom/sparrow/pkg/checks"
+)
+
+var (
+	_ checks.Check   = (*TCP)(nil)
+	_ checks.Runtime = (*Config)(nil)
+)
+
+const CheckName = "tcp"
+
+// Error classes of a failed tcp connection attempt
+const (
+	errClassRefused     = "refused"
+	errClassTimeout     = "timeout"
+	errClassUnreachable = "unreachable"
+	errClassUnknown     = "unknown"
+)
+
+// TCP is a check that measures if a tcp connection to a target can be established
+type TCP struct {
+	checks.CheckBase
+	config  Config
+	metrics metrics
+	// schedule tracks when the targets are due
+	schedule checks.Scheduler[result]
+}
+
+func init() {
+	checks.MustRegister(CheckName, checks.Factory{
+		NewCheck:  NewCheck,
+		NewConfig: func() checks.Runtime { return &Config{} },
+	})
+}
+
+// NewCheck creates a new instance of the tcp check
+func NewCheck() checks.Check {
+	return &TCP{
+		CheckBase: checks.CheckBase{
+			Mu:       sync.Mutex{},
+			DoneChan: make(chan struct{}, 1),
+		},
+		config: Config{
+			Retry: checks.DefaultRetry,
+		},
+		metrics: newMetrics(),
+	}
+}
+
+// result represents the result of a single tcp check for a specific target
+type result struct {
+	Success    bool    `json:"success"`
+	Error      *string `json:"error"`
+	ErrorClass string  `json:"errorClass,omitempty"`
+	Total      float64 `json:"total"`
+}
+
+// Run starts the tcp check
+func (t *TCP) Run(ctx context.Context, cResult chan checks.ResultDTO) error {
+	ctx, cancel := logger.NewContextWithLogger(ctx)
+	defer cancel()
+	log := logger.FromContext(ctx)
+
+	cfg := t.GetConfig().(*Config)
+
+	log.Info("Starting tcp check", "interval", cfg.Interval.String())
+	for {
+		select {
+		case <-ctx.Done():
+			log.Error("Context canceled", "err", ctx.Err())
+			return ctx.Err()
+		case <-t.DoneChan:
+			return nil
+		case <-time.After(t.schedule.Next(time.Now(), cfg.intervals(), cfg.Interval)):
+			res := t.check(ctx)
+
+			cResult <- checks.ResultDTO{
+				Name: t.Name(),
+				Result: &checks.Result{
+					Data:      res,
+					Timestamp: time.Now(),
+					Checked:   t.schedule.Checked(),
+				},
+			}
+			log.Debug("Successfully finished tcp check run")
+
+			// Re-read config in case it was updated
+			cfg = t.GetConfig().(*Config)
+		}
+	}
+}
+
+// Shutdown is called once when the check is unregistered or sparrow shuts down
+func (t *TCP) Shutdown() {
+	t.DoneChan <- struct{}{}
+	close(t.DoneChan)
+}
+
+// UpdateConfig sets the configuration for the tcp check
+func (t *TCP) UpdateConfig(cfg checks.Runtime) error {
+	if c, ok := cfg.(*Config); ok {
+		t.Mu.Lock()
+		defer t.Mu.Unlock()
+
+		for _, target := range t.config.Targets {
+			if !slices.ContainsFunc(c.Targets, func(n Target) bool { return n.Address == target.Address }) {
+				err := t.metrics.Remove(target.Address)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		t.config = *c
+		return nil
+	}
+
+	return checks.ErrConfigMismatch{
+		Expected: CheckName,
+		Current:  cfg.For(),
+	}
+}
+
+// GetConfig returns a copy of the current configuration of the tcp check
+func (t *TCP) GetConfig() checks.Runtime {
+	t.Mu.Lock()
+	defer t.Mu.Unlock()
+	// Return a copy to prevent race conditions when the config is read while being updated
+	configCopy := t.config
+	return &configCopy
+}
+
+// Name returns the name of the check
+func (t *TCP) Name() string {
+	return CheckName
+}
+
+// Schema provides the schema of the data that will be provided
+// by the tcp check
+func (t *TCP) Schema() (*openapi3.SchemaRef, error) {
+	return checks.OpenapiFromPerfData(make(map[string]result))
+}
+
+// GetMetricCollectors returns all metric collectors of check
+func (t *TCP) GetMetricCollectors() []prometheus.Collector {
+	return t.metrics.GetCollectors()
+}
+
+// RemoveLabelledMetrics removes the metrics which have the passed
+// target as a label
+func (t *TCP) RemoveLabelledMetrics(target string) error {
+	return t.metrics.Remove(target)
+}
+
+// check performs a tcp connection attempt to all targets using a retry function.
+// Returns a map where each target is associated with its tcp check result.
+func (t *TCP) check(ctx context.Context) map[string]result {
+	log := logger.FromContext(ctx)
+	log.Debug("Checking tcp connections")
+
+	// Get a copy of the config to avoid race conditions
+	cfg := t.GetConfig().(*Config)
+
+	due := t.schedule.Due(time.Now(), cfg.intervals())
+	if len(cfg.Targets) == 0 {
+		log.Debug("No targets defined")
+		return map[string]result{}
+	}
+	log.Debug("Connecting to each due target in separate routine", "amount", len(due))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := map[string]result{}
+
+	for _, target := range cfg.Targets {
+		if !due[target.Address] {
+			continue
+		}
+		addr := target.Address
+		wg.Add(1)
+		lo := log.With("target", addr)
+
+		dialer := &net.Dialer{
+			Timeout: target.TimeoutOr(cfg.Timeout),
+		}
+		connectRetry := helper.Retry(func(ctx context.Context) error {
+			res, err := connect(ctx, dialer, addr)
+			mu.Lock()
+			defer mu.Unlock()
+			results[addr] = res
+			return err
+		}, target.RetryOr(cfg.Retry))
+
+		go func() {
+			defer wg.Done()
+
+			lo.Debug("Starting retry routine to connect to target")
+			if err := connectRetry(ctx); err != nil {
+				lo.Warn("Error while connecting to target", "error", err)
+			}
+			lo.Debug("TCP check completed for target")
+
+			mu.Lock()
+			defer mu.Unlock()
+			t.metrics.Set(addr, results[addr])
+		}()
+	}
+
+	log.Debug("Waiting for all routines to finish")
+	wg.Wait()
+
+	log.Debug("Finished tcp connection attempts to all due targets")
+	return t.schedule.Merge(results)
+}
+
+// connect opens a tcp connection to the given address and closes it immediately.
+// Returns a result struct containing the outcome of the connection attempt.
+func connect(ctx context.Context, d *net.Dialer, address string) (result, error) {
+	log := logger.FromContext(ctx).With("address", address)
+	var res result
+
+	start := time.Now()
+	conn, err := d.DialContext(ctx, "tcp", address)
+	res.Total = time.Since(start).Seconds()
+	if err != nil {
+		log.Debug("Error while connecting to address", "error", err)
+		errval := err.Error()
+		res.Error = &errval
+		res.ErrorClass = classifyError(err)
+		return res, err
+	}
+
+	if cErr := conn.Close(); cErr != nil {
+		log.Debug("Failed to close tcp connection", "error", cErr)
+	}
+	res.Success = true
+	return res, nil
+}
+
+// classifyError maps a dial error to one of the known error classes
+func classifyError(err error) string {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return errClassRefused
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return errClassTimeout
+	case errors.Is(err, syscall.EHOSTUNREACH), errors.Is(err, syscall.ENETUNREACH):
+		return errClassUnreachable
+	default:
+		return errClassUnknown
+	}
+}
@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telekom/sparrow/internal/helper"
+	"github.com/telekom/sparrow/pkg/checks"
+	"github.com/telekom/sparrow/pkg/checks/health"
+)
+
+func TestTCP_Run(t *testing.T) {
+	open := newListener(t)
+	closed := closedAddress(t)
+
+	tests := []struct {
+		name    string
+		targets []Target
+		want    map[string]result
+	}{
+		{
+			name:    "success with no targets",
+			targets: []Target{},
+			want:    map[string]result{},
+		},
+		{
+			name:    "success with one open port",
+			targets: []Target{{Address: open}},
+			want: map[string]result{
+				open: {Success: true},
+			},
+		},
+		{
+			name:    "open and refused ports",
+			targets: []Target{{Address: open}, {Address: closed}},
+			want: map[string]result{
+				open:   {Success: true},
+				closed: {Success: false, ErrorClass: errClassRefused},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCheck()
+			cResult := make(chan checks.ResultDTO, 1)
+			defer close(cResult)
+
+			err := c.UpdateConfig(&Config{
+				Targets:  tt.targets,
+				Interval: 100 * time.Millisecond,
+				Timeout:  time.Second,
+				Retry:    helper.RetryConfig{Count: 0},
+			})
+			if err != nil {
+				t.Fatalf("TCP.UpdateConfig() error = %v", err)
+			}
+
+			go func() {
+				err := c.Run(context.Background(), cResult)
+				if err != nil {
+					t.Errorf("TCP.Run() error = %v", err)
+				}
+			}()
+			defer c.Shutdown()
+
+			r := <-cResult
+			got, ok := r.Result.Data.(map[string]result)
+			if !ok {
+				t.Fatalf("TCP.Run() result data has wrong type %T", r.Result.Data)
+			}
+			assert.Len(t, got, len(tt.want))
+			for target, want := range tt.want {
+				assert.Equal(t, want.Success, got[target].Success, "success of %s", target)
+				assert.Equal(t, want.ErrorClass, got[target].ErrorClass, "error class of %s", target)
+				if !want.Success {
+					assert.NotNil(t, got[target].Error, "error of %s", target)
+				}
+			}
+		})
+	}
+}
+
+func TestTCP_UpdateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   checks.Runtime
+		want    Config
+		wantErr bool
+	}{
+		{
+			name: "simple config",
+			input: &Config{
+				Targets:  []Target{{Address: "example.com:5432"}},
+				Interval: 10 * time.Second,
+				Timeout:  30 * time.Second,
+			},
+			want: Config{
+				Targets:  []Target{{Address: "example.com:5432"}},
+				Interval: 10 * time.Second,
+				Timeout:  30 * time.Second,
+			},
+		},
+		{
+			name:    "wrong type",
+			input:   &health.Config{Targets: []health.Target{{URL: "https://example.com"}}},
+			want:    Config{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &TCP{metrics: newMetrics()}
+			if err := c.UpdateConfig(tt.input); (err != nil) != tt.wantErr {
+				t.Errorf("TCP.UpdateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, c.config)
+		})
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "connection refused",
+			err:  &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)},
+			want: errClassRefused,
+		},
+		{
+			name: "host unreachable",
+			err:  &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.EHOSTUNREACH)},
+			want: errClassUnreachable,
+		},
+		{
+			name: "network unreachable",
+			err:  &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ENETUNREACH)},
+			want: errClassUnreachable,
+		},
+		{
+			name: "deadline exceeded",
+			err:  fmt.Errorf("dial: %w", context.DeadlineExceeded),
+			want: errClassTimeout,
+		},
+		{
+			name: "unknown error",
+			err:  errors.New("something else"),
+			want: errClassUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, classifyError(tt.err))
+		})
+	}
+}
+
+// newListener starts a tcp listener accepting connections until the test ends
+func newListener(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = lis.Close() })
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+	return lis.Addr().String()
+}
+
+// closedAddress returns a local address that has no listener
+func closedAddress(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	addr := lis.Addr().String()
+	_ = lis.Close()
+	return addr
+}
@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tls
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/telekom/sparrow/internal/helper"
+	"github.com/telekom/sparrow/pkg/checks"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	minInterval = 1 * time.Second
+	minTimeout  = 100 * time.Millisecond
+	defaultPort = "443"
+	maxPort     = 65535
+)
+
+// Config defines the configuration parameters for a tls check
+type Config struct {
+	// Targets is a list of "host:port" addresses to perform a tls handshake with.
+	// If the port is omitted, 443 is used.
+	Targets  []Target           `json:"targets,omitempty" yaml:"targets,omitempty"`
+	Interval time.Duration      `json:"interval" yaml:"interval"`
+	Timeout  time.Duration      `json:"timeout" yaml:"timeout"`
+	Retry    helper.RetryConfig `json:"retry" yaml:"retry"`
+	// CaPath is an optional path to a PEM encoded bundle of additional trusted root certificates
+	CaPath string `json:"caPath,omitempty" yaml:"caPath,omitempty"`
+}
+
+// Target defines a tls check target.
+// A target can be configured as a plain "host[:port]" string,
+// in which case the settings of the check are used.
+type Target struct {
+	// Address is the "host[:port]" address to perform the tls handshake with
+	Address string `json:"address" yaml:"address"`
+	// TargetOverrides optionally override the interval, timeout and retry of the check
+	checks.TargetOverrides `yaml:",inline"`
+}
+
+// targetFields is used to (un)marshal a Target without its custom (un)marshalers
+type targetFields Target
+
+// plainTarget allows targets to be configured as a plain address string
+var plainTarget = checks.PlainTarget[Target, targetFields]{
+	Key: func(t *Target) *string { return &t.Address },
+}
+
+// UnmarshalYAML allows targets to be either a plain address string or an object
+func (t *Target) UnmarshalYAML(value *yaml.Node) error {
+	return plainTarget.DecodeYAML(value, t)
+}
+
+// MarshalYAML marshals targets without overrides as a plain address string
+func (t Target) MarshalYAML() (any, error) {
+	return plainTarget.EncodeYAML(t)
+}
+
+// UnmarshalJSON allows targets to be either a plain address string or an object
+func (t *Target) UnmarshalJSON(b []byte) error {
+	return plainTarget.DecodeJSON(b, t)
+}
+
+// MarshalJSON marshals targets without overrides as a plain address string
+func (t Target) MarshalJSON() ([]byte, error) {
+	return plainTarget.EncodeJSON(t)
+}
+
+// intervals returns the check interval of every target
+func (c *Config) intervals() map[string]time.Duration {
+	return checks.Intervals(c.Targets, func(t Target) string { return t.Address }, c.Interval)
+}
+
+// For returns the name of the check
+func (c *Config) For() string {
+	return CheckName
+}
+
+// Validate checks if the configuration is valid
+func (c *Config) Validate() error {
+	for i, t := range c.Targets {
+		if strings.Contains(t.Address, "://") {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("targets[%d]", i), Reason: "target must be in the format 'host[:port]' without a scheme"}
+		}
+
+		_, port, err := splitTarget(t.Address)
+		if err != nil {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("targets[%d]", i), Reason: "target must be in the format 'host[:port]'"}
+		}
+
+		p, err := strconv.Atoi(port)
+		if err != nil || p <= 0 || p > maxPort {
+			return checks.ErrInvalidConfig{CheckName: c.For(), Field: fmt.Sprintf("targets[%d]", i), Reason: fmt.Sprintf("port must be between 1 and %d", maxPort)}
+		}
+
+		if err := t.Validate(c.For(), fmt.Sprintf("targets[%d]", i), minInterval, minTimeout); err != nil {
+			return err
+		}
+	}
+
+	if c.Interval < minInterval {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "interval", Reason: fmt.Sprintf("interval must be at least %v", minInterval)}
+	}
+
+	if c.Timeout < minTimeout {
+		return checks.ErrInvalidConfig{CheckName: c.For(), Field: "timeout", Reason: fmt.Sprintf("timeout must be at least %v", minTimeout)}
+	}
+
+	return nil
+}
+
+// splitTarget splits a target into its host and port.
+// If the target has no port, the default tls port is returned.
+func splitTarget(target string) (host, port string, err error) {
+	host, port, err = net.SplitHostPort(target)
+	if err != nil {
+		// Assume that the target has no port
+		if strings.Contains(err.Error(), "missing port") {
+			host = strings.Trim(target, "[]")
+			if host == "" {
+				return "", "", fmt.Errorf("empty host in target %q", target)
+			}
+			return host, defaultPort, nil
+		}
+		return "", "", err
+	}
+	if host == "" {
+		return "", "", fmt.Errorf("empty host in target %q", target)
+	}
+	return host, port, nil
+}
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tls
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{
+			name: "valid config",
+			config: Config{
+				Targets:  []Target{{Address: "example.com"}, {Address: "example.com:8443"}, {Address: "10.0.0.1:636"}, {Address: "[::1]:443"}},
+				Interval: 1 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid targets - scheme",
+			config: Config{
+				Targets:  []Target{{Address: "https://example.com"}},
+				Interval: 1 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid targets - port out of range",
+			config: Config{
+				Targets:  []Target{{Address: "example.com:0"}},
+				Interval: 1 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid interval",
+			config: Config{
+				Targets:  []Target{{Address: "example.com"}},
+				Interval: 100 * time.Millisecond,
+				Timeout:  1 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid timeout",
+			config: Config{
+				Targets:  []Target{{Address: "example.com"}},
+				Interval: 1 * time.Second,
+				Timeout:  10 * time.Millisecond,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Config.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSplitTarget(t *testing.T) {
+	tests := []struct {
+		target   string
+		wantHost string
+		wantPort string
+		wantErr  bool
+	}{
+		{target: "example.com", wantHost: "example.com", wantPort: "443"},
+		{target: "example.com:8443", wantHost: "example.com", wantPort: "8443"},
+		{target: "[::1]:636", wantHost: "::1", wantPort: "636"},
+		{target: ":443", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			host, port, err := splitTarget(tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("splitTarget() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if host != tt.wantHost || port != tt.wantPort {
+				t.Errorf("splitTarget() = %s, %s, want %s, %s", host, port, tt.wantHost, tt.wantPort)
+			}
+		})
+	}
+}
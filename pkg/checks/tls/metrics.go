@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tls
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/telekom/sparrow/pkg/checks"
+)
+
+const (
+	expiryMetric = "sparrow_tls_cert_expiry_seconds"
+	validMetric  = "sparrow_tls_valid"
+)
+
+// metrics defines the metric collectors of the tls check
+type metrics struct {
+	expiry *prometheus.GaugeVec
+	valid  *prometheus.GaugeVec
+}
+
+// newMetrics initializes metric collectors of the tls check
+func newMetrics() metrics {
+	return metrics{
+		expiry: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: expiryMetric,
+				Help: "Seconds until the leaf certificate of the target expires. Negative if already expired.",
+			},
+			[]string{checks.LabelTarget},
+		),
+		valid: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: validMetric,
+				Help: "Specifies if the certificate chain of the target is valid and matches its hostname.",
+			},
+			[]string{checks.LabelTarget},
+		),
+	}
+}
+
+// GetCollectors returns all metric collectors
+func (m *metrics) GetCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.expiry,
+		m.valid,
+	}
+}
+
+// Set sets the metrics of one target result
+func (m *metrics) Set(target string, res result) {
+	valid := 0.0
+	if res.Valid {
+		valid = 1
+	}
+	m.valid.WithLabelValues(target).Set(valid)
+
+	// Without a handshake there is no certificate to report the expiry for
+	if !res.ExpiresAt.IsZero() {
+		m.expiry.WithLabelValues(target).Set(res.ExpirySeconds)
+	}
+}
+
+// Remove removes the metrics of one target
+func (m *metrics) Remove(target string) error {
+	if !m.valid.DeleteLabelValues(target) {
+		return checks.ErrMetricNotFound{Label: target}
+	}
+	m.expiry.DeleteLabelValues(target)
+	return nil
+}
@@ -0,0 +1,377 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/telekom/sparrow/internal/helper"
+	"github.com/telekom/sparrow/internal/logger"
+	"github.com/telekom/sparrow/pkg/checks"
+)
+
+var (
+	_ checks.Check   = (*TLS)(nil)
+	_ checks.Runtime = (*Config)(nil)
+)
+
+const CheckName = "tls"
+
+const hoursPerDay = 24
+
+// TLS is a check that validates the certificates of tls endpoints
+type TLS struct {
+	checks.CheckBase
+	config  Config
+	metrics metrics
+	// schedule tracks when the targets are due
+	schedule checks.Scheduler[result]
+}
+
+func init() {
+	checks.MustRegister(CheckName, checks.Factory{
+		NewCheck:  NewCheck,
+		NewConfig: func() checks.Runtime { return &Config{} },
+	})
+}
+
+// NewCheck creates a new instance of the tls check
+func NewCheck() checks.Check {
+	return &TLS{
+		CheckBase: checks.CheckBase{
+			Mu:       sync.Mutex{},
+			DoneChan: make(chan struct{}, 1),
+		},
+		config: Config{
+			Retry: checks.DefaultRetry,
+		},
+		metrics: newMetrics(),
+	}
+}
+
+// result represents the result of a single tls check for a specific target
+type result struct {
+	// Valid is true if the certificate chain could be verified and matches the hostname
+	Valid bool `json:"valid"`
+	// HostnameMatch is true if the SANs of the leaf certificate match the hostname of the target
+	HostnameMatch bool `json:"hostnameMatch"`
+	// ExpiresAt is the expiry date of the leaf certificate
+	ExpiresAt time.Time `json:"expiresAt"`
+	// ExpirySeconds is the time until the leaf certificate expires in seconds
+	ExpirySeconds float64 `json:"expirySeconds"`
+	// DaysUntilExpiry is the time until the leaf certificate expires in days
+	DaysUntilExpiry float64 `json:"daysUntilExpiry"`
+	// Version is the negotiated tls protocol version
+	Version string `json:"version"`
+	// CipherSuite is the negotiated cipher suite
+	CipherSuite string `json:"cipherSuite"`
+	// Chain is the certificate chain presented by the target, starting with the leaf
+	Chain []certificate `json:"chain"`
+	// VerificationErrors contains the reasons why the certificate is not valid
+	VerificationErrors []string `json:"verificationErrors,omitempty"`
+	// Error is set if the handshake could not be performed
+	Error *string `json:"error"`
+	// Total is the duration of the tls handshake in seconds
+	Total float64 `json:"total"`
+}
+
+// certificate holds the relevant information of a certificate in the chain
+type certificate struct {
+	Subject   string    `json:"subject"`
+	Issuer    string    `json:"issuer"`
+	NotBefore time.Time `json:"notBefore"`
+	NotAfter  time.Time `json:"notAfter"`
+}
+
+// Run starts the tls check
+func (t *TLS) Run(ctx context.Context, cResult chan checks.ResultDTO) error {
+	ctx, cancel := logger.NewContextWithLogger(ctx)
+	defer cancel()
+	log := logger.FromContext(ctx)
+
+	cfg := t.GetConfig().(*Config)
+
+	log.Info("Starting tls check", "interval", cfg.Interval.String())
+	for {
+		select {
+		case <-ctx.Done():
+			log.Error("Context canceled", "err", ctx.Err())
+			return ctx.Err()
+		case <-t.DoneChan:
+			return nil
+		case <-time.After(t.schedule.Next(time.Now(), cfg.intervals(), cfg.Interval)):
+			res := t.check(ctx)
+
+			cResult <- checks.ResultDTO{
+				Name: t.Name(),
+				Result: &checks.Result{
+					Data:      res,
+					Timestamp: time.Now(),
+					Checked:   t.schedule.Checked(),
+				},
+			}
+			log.Debug("Successfully finished tls check run")
+
+			// Re-read config in case it was updated
+			cfg = t.GetConfig().(*Config)
+		}
+	}
+}
+
+// Shutdown is called once when the check is unregistered or sparrow shuts down
+func (t *TLS) Shutdown() {
+	t.DoneChan <- struct{}{}
+	close(t.DoneChan)
+}
+
+// UpdateConfig sets the configuration for the tls check
+func (t *TLS) UpdateConfig(cfg checks.Runtime) error {
+	if c, ok := cfg.(*Config); ok {
+		t.Mu.Lock()
+		defer t.Mu.Unlock()
+
+		for _, target := range t.config.Targets {
+			if !slices.ContainsFunc(c.Targets, func(n Target) bool { return n.Address == target.Address }) {
+				err := t.metrics.Remove(target.Address)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		t.config = *c
+		return nil
+	}
+
+	return checks.ErrConfigMismatch{
+		Expected: CheckName,
+		Current:  cfg.For(),
+	}
+}
+
+// GetConfig returns a copy of the current configuration of the tls check
+func (t *TLS) GetConfig() checks.Runtime {
+	t.Mu.Lock()
+	defer t.Mu.Unlock()
+	// Return a copy to prevent race conditions when the config is read while being updated
+	configCopy := t.config
+	return &configCopy
+}
+
+// Name returns the name of the check
+func (t *TLS) Name() string {
+	return CheckName
+}
+
+// Schema provides the schema of the data that will be provided
+// by the tls check
+func (t *TLS) Schema() (*openapi3.SchemaRef, error) {
+	return checks.OpenapiFromPerfData(make(map[string]result))
+}
+
+// GetMetricCollectors returns all metric collectors of check
+func (t *TLS) GetMetricCollectors() []prometheus.Collector {
+	return t.metrics.GetCollectors()
+}
+
+// RemoveLabelledMetrics removes the metrics which have the passed
+// target as a label
+func (t *TLS) RemoveLabelledMetrics(target string) error {
+	return t.metrics.Remove(target)
+}
+
+// check performs a tls handshake with all targets using a retry function.
+// Returns a map where each target is associated with its tls check result.
+func (t *TLS) check(ctx context.Context) map[string]result {
+	log := logger.FromContext(ctx)
+	log.Debug("Checking tls certificates")
+
+	// Get a copy of the config to avoid race conditions
+	cfg := t.GetConfig().(*Config)
+
+	due := t.schedule.Due(time.Now(), cfg.intervals())
+	if len(cfg.Targets) == 0 {
+		log.Debug("No targets defined")
+		return map[string]result{}
+	}
+
+	roots, err := loadRoots(cfg.CaPath)
+	if err != nil {
+		log.Error("Failed to load additional root certificates, using system roots", "path", cfg.CaPath, "error", err)
+	}
+
+	log.Debug("Performing tls handshake with each due target in separate routine", "amount", len(due))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := map[string]result{}
+
+	for _, target := range cfg.Targets {
+		if !due[target.Address] {
+			continue
+		}
+		addr := target.Address
+		wg.Add(1)
+		lo := log.With("target", addr)
+
+		handshakeRetry := helper.Retry(func(ctx context.Context) error {
+			res, err := handshake(ctx, addr, target.TimeoutOr(cfg.Timeout), roots)
+			mu.Lock()
+			defer mu.Unlock()
+			results[addr] = res
+			return err
+		}, target.RetryOr(cfg.Retry))
+
+		go func() {
+			defer wg.Done()
+
+			lo.Debug("Starting retry routine to perform tls handshake")
+			if err := handshakeRetry(ctx); err != nil {
+				lo.Warn("Error while performing tls handshake", "error", err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if !results[addr].Valid {
+				lo.Warn("Certificate of target is not valid", "reasons", results[addr].VerificationErrors)
+			}
+			t.metrics.Set(addr, results[addr])
+		}()
+	}
+
+	log.Debug("Waiting for all routines to finish")
+	wg.Wait()
+
+	log.Debug("Finished tls checks of all due targets")
+	return t.schedule.Merge(results)
+}
+
+// handshake performs a tls handshake with the target and verifies the presented certificate chain.
+// An error is only returned if the handshake itself failed, verification problems are reported in the result.
+func handshake(ctx context.Context, target string, timeout time.Duration, roots *x509.CertPool) (result, error) {
+	log := logger.FromContext(ctx).With("target", target)
+	var res result
+
+	host, port, err := splitTarget(target)
+	if err != nil {
+		errval := err.Error()
+		res.Error = &errval
+		return res, err
+	}
+
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: timeout},
+		Config: &tls.Config{
+			ServerName: host,
+			// The chain is verified manually below to report all
+			// verification errors instead of aborting the handshake
+			InsecureSkipVerify: true, // #nosec G402
+		},
+	}
+
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+	res.Total = time.Since(start).Seconds()
+	if err != nil {
+		log.Debug("Error while performing tls handshake", "error", err)
+		errval := err.Error()
+		res.Error = &errval
+		return res, err
+	}
+	defer func() {
+		if cErr := conn.Close(); cErr != nil {
+			log.Debug("Failed to close tls connection", "error", cErr)
+		}
+	}()
+
+	state := conn.(*tls.Conn).ConnectionState()
+	res.Version = tls.VersionName(state.Version)
+	res.CipherSuite = tls.CipherSuiteName(state.CipherSuite)
+
+	if len(state.PeerCertificates) == 0 {
+		err = errors.New("no peer certificates presented")
+		errval := err.Error()
+		res.Error = &errval
+		return res, err
+	}
+
+	for _, cert := range state.PeerCertificates {
+		res.Chain = append(res.Chain, certificate{
+			Subject:   cert.Subject.String(),
+			Issuer:    cert.Issuer.String(),
+			NotBefore: cert.NotBefore,
+			NotAfter:  cert.NotAfter,
+		})
+	}
+
+	now := time.Now()
+	leaf := state.PeerCertificates[0]
+	res.ExpiresAt = leaf.NotAfter
+	res.ExpirySeconds = leaf.NotAfter.Sub(now).Seconds()
+	res.DaysUntilExpiry = leaf.NotAfter.Sub(now).Hours() / hoursPerDay
+	res.VerificationErrors = verify(state.PeerCertificates, host, roots, now)
+	res.HostnameMatch = leaf.VerifyHostname(host) == nil
+	res.Valid = len(res.VerificationErrors) == 0
+
+	return res, nil
+}
+
+// verify verifies the certificate chain and the hostname of the leaf certificate.
+// Returns a list of all verification errors.
+func verify(certs []*x509.Certificate, host string, roots *x509.CertPool, now time.Time) []string {
+	var verrs []string
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	if _, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+	}); err != nil {
+		verrs = append(verrs, err.Error())
+	}
+
+	if err := certs[0].VerifyHostname(host); err != nil {
+		verrs = append(verrs, err.Error())
+	}
+
+	return verrs
+}
+
+// loadRoots returns the system root certificates extended by the certificates in the given file.
+// If no path is given, nil is returned so the system roots are used.
+func loadRoots(path string) (*x509.CertPool, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	b, err := os.ReadFile(path) // #nosec G304 // path is provided by the runtime configuration
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ca file: %w", err)
+	}
+
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	if !roots.AppendCertsFromPEM(b) {
+		return nil, errors.New("no certificates found in ca file")
+	}
+	return roots, nil
+}
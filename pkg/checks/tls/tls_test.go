@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tls
+
+import (
+	"context"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telekom/sparrow/internal/helper"
+	"github.com/telekom/sparrow/pkg/checks"
+)
+
+func TestTLS_Run(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	_, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	require.NoError(t, err)
+	ipTarget := net.JoinHostPort("127.0.0.1", port)
+	nameTarget := net.JoinHostPort("localhost", port)
+
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caPath, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: srv.Certificate().Raw,
+	}), 0o600))
+
+	tests := []struct {
+		name              string
+		targets           []Target
+		caPath            string
+		wantValid         bool
+		wantHostnameMatch bool
+		wantHandshakeErr  bool
+	}{
+		{
+			name:              "untrusted certificate",
+			targets:           []Target{{Address: ipTarget}},
+			wantValid:         false,
+			wantHostnameMatch: true,
+		},
+		{
+			name:              "trusted certificate",
+			targets:           []Target{{Address: ipTarget}},
+			caPath:            caPath,
+			wantValid:         true,
+			wantHostnameMatch: true,
+		},
+		{
+			name:              "trusted certificate with hostname mismatch",
+			targets:           []Target{{Address: nameTarget}},
+			caPath:            caPath,
+			wantValid:         false,
+			wantHostnameMatch: false,
+		},
+		{
+			name:             "handshake failure",
+			targets:          []Target{{Address: closedAddress(t)}},
+			wantHandshakeErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCheck()
+			cResult := make(chan checks.ResultDTO, 1)
+			defer close(cResult)
+
+			err := c.UpdateConfig(&Config{
+				Targets:  tt.targets,
+				Interval: 100 * time.Millisecond,
+				Timeout:  time.Second,
+				Retry:    helper.RetryConfig{Count: 0},
+				CaPath:   tt.caPath,
+			})
+			require.NoError(t, err)
+
+			go func() {
+				err := c.Run(context.Background(), cResult)
+				if err != nil {
+					t.Errorf("TLS.Run() error = %v", err)
+				}
+			}()
+			defer c.Shutdown()
+
+			r := <-cResult
+			got, ok := r.Result.Data.(map[string]result)
+			require.True(t, ok, "result data has wrong type %T", r.Result.Data)
+
+			res := got[tt.targets[0].Address]
+			if tt.wantHandshakeErr {
+				assert.NotNil(t, res.Error)
+				assert.False(t, res.Valid)
+				return
+			}
+
+			assert.Nil(t, res.Error)
+			assert.Equal(t, tt.wantValid, res.Valid, "verification errors: %v", res.VerificationErrors)
+			assert.Equal(t, tt.wantHostnameMatch, res.HostnameMatch)
+			assert.Equal(t, srv.Certificate().NotAfter, res.ExpiresAt)
+			assert.Positive(t, res.DaysUntilExpiry)
+			assert.NotEmpty(t, res.Version)
+			assert.NotEmpty(t, res.CipherSuite)
+			assert.Len(t, res.Chain, 1)
+		})
+	}
+}
+
+// closedAddress returns a local address that has no listener
+func closedAddress(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := lis.Addr().String()
+	_ = lis.Close()
+	return addr
+}
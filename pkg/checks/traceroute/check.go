@@ -31,12 +31,21 @@ type Target struct {
 	Addr string `json:"addr" yaml:"addr" mapstructure:"addr"`
 	// The port to traceroute to
 	Port int `json:"port" yaml:"port" mapstructure:"port"`
+	// TargetOverrides optionally override the interval, timeout and retry of the check
+	checks.TargetOverrides `yaml:",inline" mapstructure:",squash"`
 }
 
 func (t Target) String() string {
 	return fmt.Sprintf("%s:%d", t.Addr, t.Port)
 }
 
+func init() {
+	checks.MustRegister(CheckName, checks.Factory{
+		NewCheck:  NewCheck,
+		NewConfig: func() checks.Runtime { return &Config{} },
+	})
+}
+
 func NewCheck() checks.Check {
 	c := &Traceroute{
 		CheckBase: checks.CheckBase{
@@ -57,6 +66,8 @@ type Traceroute struct {
 	traceroute tracerouteFactory
 	metrics    metrics
 	tracer     trace.Tracer
+	// schedule tracks when the targets are due
+	schedule checks.Scheduler[result]
 }
 
 type tracerouteConfig struct {
@@ -82,8 +93,8 @@ func (tr *Traceroute) Run(ctx context.Context, cResult chan checks.ResultDTO) er
 	defer cancel()
 	log := logger.FromContext(ctx)
 
-	interval := tr.GetConfig().(*Config).Interval
-	log.InfoContext(ctx, "Starting traceroute check", "interval", interval.String())
+	cfg := tr.GetConfig().(*Config)
+	log.InfoContext(ctx, "Starting traceroute check", "interval", cfg.Interval.String())
 	for {
 		select {
 		case <-ctx.Done():
@@ -91,7 +102,7 @@ func (tr *Traceroute) Run(ctx context.Context, cResult chan checks.ResultDTO) er
 			return ctx.Err()
 		case <-tr.DoneChan:
 			return nil
-		case <-time.After(interval):
+		case <-time.After(tr.schedule.Next(time.Now(), cfg.intervals(), cfg.Interval)):
 			res := tr.check(ctx)
 			tr.metrics.MinHops(res)
 			cResult <- checks.ResultDTO{
@@ -99,12 +110,13 @@ func (tr *Traceroute) Run(ctx context.Context, cResult chan checks.ResultDTO) er
 				Result: &checks.Result{
 					Data:      res,
 					Timestamp: time.Now(),
+					Checked:   tr.schedule.Checked(),
 				},
 			}
 			log.DebugContext(ctx, "Successfully finished traceroute check run")
 
-			// Re-read interval in case config was updated
-			interval = tr.GetConfig().(*Config).Interval
+			// Re-read config in case it was updated
+			cfg = tr.GetConfig().(*Config)
 		}
 	}
 }
@@ -130,25 +142,30 @@ func (tr *Traceroute) check(ctx context.Context) map[string]result {
 		res  result
 	}
 
+	due := tr.schedule.Due(time.Now(), cfg.intervals())
 	cResult := make(chan internalResult, len(cfg.Targets))
 	var wg sync.WaitGroup
 	start := time.Now()
-	wg.Add(len(cfg.Targets))
 
 	for _, t := range cfg.Targets {
+		if !due[t.Addr] {
+			continue
+		}
+		wg.Add(1)
 		go func(t Target) {
 			defer wg.Done()
 			l := log.With("target", t.String())
 			l.DebugContext(ctx, "Running traceroute")
 
+			timeout, retry := t.TimeoutOr(cfg.Timeout), t.RetryOr(cfg.Retry)
 			c, span := tr.tracer.Start(ctx, t.String(), trace.WithAttributes(
 				attribute.String("target.addr", t.Addr),
 				attribute.Int("target.port", t.Port),
-				attribute.Stringer("config.interval", cfg.Interval),
-				attribute.Stringer("config.timeout", cfg.Timeout),
+				attribute.Stringer("config.interval", t.IntervalOr(cfg.Interval)),
+				attribute.Stringer("config.timeout", timeout),
 				attribute.Int("config.max_hops", cfg.MaxHops),
-				attribute.Int("config.retry.count", cfg.Retry.Count),
-				attribute.Stringer("config.retry.delay", cfg.Retry.Delay),
+				attribute.Int("config.retry.count", retry.Count),
+				attribute.Stringer("config.retry.delay", retry.Delay),
 			))
 			defer span.End()
 
@@ -156,9 +173,9 @@ func (tr *Traceroute) check(ctx context.Context) map[string]result {
 			hops, err := tr.traceroute(c, tracerouteConfig{
 				Dest:    t.Addr,
 				Port:    t.Port,
-				Timeout: cfg.Timeout,
+				Timeout: timeout,
 				MaxHops: cfg.MaxHops,
-				Rc:      cfg.Retry,
+				Rc:      retry,
 			})
 			elapsed := time.Since(s)
 
@@ -204,7 +221,7 @@ func (tr *Traceroute) check(ctx context.Context) map[string]result {
 
 	elapsed := time.Since(start)
 	log.InfoContext(ctx, "Finished traceroute check", "duration", elapsed)
-	return res
+	return tr.schedule.Merge(res)
 }
 
 // Shutdown is called once when the check is unregistered or sparrow shuts down
@@ -222,7 +239,7 @@ func (tr *Traceroute) UpdateConfig(cfg checks.Runtime) error {
 		defer tr.Mu.Unlock()
 
 		for _, target := range tr.config.Targets {
-			if !slices.Contains(c.Targets, target) {
+			if !slices.ContainsFunc(c.Targets, func(t Target) bool { return t.String() == target.String() }) {
 				err := tr.metrics.Remove(target.Addr)
 				if err != nil {
 					return err
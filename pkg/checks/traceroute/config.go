@@ -28,6 +28,11 @@ type Config struct {
 	Timeout time.Duration `json:"timeout" yaml:"timeout" mapstructure:"timeout"`
 }
 
+// intervals returns the check interval of every target
+func (c *Config) intervals() map[string]time.Duration {
+	return checks.Intervals(c.Targets, func(t Target) string { return t.Addr }, c.Interval)
+}
+
 func (c *Config) For() string {
 	return CheckName
 }
@@ -41,6 +46,11 @@ func (c *Config) Validate() error {
 	}
 
 	for i, t := range c.Targets {
+		// Overrides must only be positive, as the check has no lower bounds
+		if err := t.Validate(CheckName, fmt.Sprintf("traceroute.targets[%d]", i), time.Nanosecond, time.Nanosecond); err != nil {
+			return err
+		}
+
 		ip := net.ParseIP(t.Addr)
 		if ip != nil {
 			continue
@@ -10,7 +10,9 @@ import (
 	"fmt"
 	"math/rand/v2"
 	"net"
+	"os"
 	"slices"
+	"strconv"
 	"sync"
 	"syscall"
 	"time"
@@ -45,15 +47,32 @@ func randomPort() int {
 	return rand.N(portRange) + basePort // #nosec G404 // math.rand is fine here, we're not doing encryption
 }
 
+// isIPv6 returns true if the address is an IPv6 address
+func isIPv6(addr net.Addr) bool {
+	ip := ipFromAddr(addr)
+	return ip != nil && ip.To4() == nil
+}
+
+// hopLimitOption returns the socket option level and name to set the TTL,
+// which is IP_TTL for IPv4 and IPV6_UNICAST_HOPS for IPv6
+func hopLimitOption(v6 bool) (level, opt int) {
+	if v6 {
+		return unix.IPPROTO_IPV6, unix.IPV6_UNICAST_HOPS
+	}
+	return unix.IPPROTO_IP, unix.IP_TTL
+}
+
 // tcpHop attempts to connect to the target host using TCP with the specified TTL and timeout.
+// The TTL is set as hop limit for IPv6 targets.
 // It returns a [net.Conn], the port used for the connection, and an error if the connection failed.
 func tcpHop(ctx context.Context, addr net.Addr, ttl int, timeout time.Duration) (net.Conn, int, error) {
 	span := trace.SpanFromContext(ctx)
+	level, opt := hopLimitOption(isIPv6(addr))
 
 	for {
 		port := randomPort()
 
-		// Dialer with control function to set IP_TTL
+		// Dialer with control function to set IP_TTL or IPV6_UNICAST_HOPS
 		dialer := net.Dialer{
 			LocalAddr: &net.TCPAddr{
 				Port: port,
@@ -62,7 +81,7 @@ func tcpHop(ctx context.Context, addr net.Addr, ttl int, timeout time.Duration)
 			Control: func(_, _ string, c syscall.RawConn) error {
 				var opErr error
 				if err := c.Control(func(fd uintptr) {
-					opErr = unix.SetsockoptInt(int(fd), unix.IPPROTO_IP, unix.IP_TTL, ttl) // #nosec G115 // The net package is safe to use
+					opErr = unix.SetsockoptInt(int(fd), level, opt, ttl) // #nosec G115 // The net package is safe to use
 				}); err != nil {
 					return err
 				}
@@ -114,8 +133,8 @@ func tcpHop(ctx context.Context, addr net.Addr, ttl int, timeout time.Duration)
 // it reads the address of the router that dropped created the icmp packet. It also reads the source port
 // from the payload and finds the source port used by the previous tcp connection. If any error is returned,
 // an icmp packet was either not received, or the received packet was not a time exceeded.
-func readIcmpMessage(ctx context.Context, icmpListener *icmp.PacketConn, timeout time.Duration) (int, net.Addr, error) {
-	log := logger.FromContext(ctx)
+// The packet is parsed as ICMPv6 if v6 is true, otherwise as ICMP.
+func readIcmpMessage(ctx context.Context, icmpListener *icmp.PacketConn, v6 bool, timeout time.Duration) (int, net.Addr, error) {
 	// Expected to fail due to TTL expiry, listen for ICMP response
 	if err := icmpListener.SetReadDeadline(time.Now().Add(timeout)); err != nil {
 		return 0, nil, fmt.Errorf("failed to set icmp read deadline: %w", err)
@@ -127,28 +146,54 @@ func readIcmpMessage(ctx context.Context, icmpListener *icmp.PacketConn, timeout
 		return 0, nil, fmt.Errorf("failed to read from icmp connection: %w", err)
 	}
 
-	// Parse the ICMP message
-	msg, err := icmp.ParseMessage(ipv4.ICMPTypeTimeExceeded.Protocol(), buffer[:n])
+	destPort, err := parseTimeExceeded(ctx, buffer[:n], v6)
 	if err != nil {
 		return 0, nil, err
 	}
+	return destPort, routerAddr, nil
+}
+
+// parseTimeExceeded parses an ICMP or ICMPv6 'Time Exceeded' message and returns the
+// source port of the tcp segment that caused it.
+func parseTimeExceeded(ctx context.Context, b []byte, v6 bool) (int, error) {
+	proto := ipv4.ICMPTypeTimeExceeded.Protocol()
+	if v6 {
+		proto = ipv6.ICMPTypeTimeExceeded.Protocol()
+	}
+
+	// Parse the ICMP message
+	msg, err := icmp.ParseMessage(proto, b)
+	if err != nil {
+		return 0, err
+	}
 
 	// Extract the TCP segment from the ICMP message
 	var tcpSegment []byte
 	switch msg.Type {
 	case ipv4.ICMPTypeTimeExceeded:
-		tcpSegment = msg.Body.(*icmp.TimeExceeded).Data[IPv4HeaderSize:]
+		data := msg.Body.(*icmp.TimeExceeded).Data
+		if len(data) < IPv4HeaderSize {
+			return 0, errors.New("time exceeded message is too short")
+		}
+		// The header length is given in 32-bit words and may include options
+		tcpSegment = data[min(int(data[0]&0x0f)*4, len(data)):]
 	case ipv6.ICMPTypeTimeExceeded:
-		tcpSegment = msg.Body.(*icmp.TimeExceeded).Data[IPv6HeaderSize:]
+		data := msg.Body.(*icmp.TimeExceeded).Data
+		if len(data) < IPv6HeaderSize {
+			return 0, errors.New("time exceeded message is too short")
+		}
+		tcpSegment = data[IPv6HeaderSize:]
 	default:
-		log.DebugContext(ctx, "message is not 'Time Exceeded'", "type", msg.Type.Protocol())
-		return 0, nil, errors.New("message is not 'Time Exceeded'")
+		logger.FromContext(ctx).DebugContext(ctx, "message is not 'Time Exceeded'", "type", msg.Type)
+		return 0, errors.New("message is not 'Time Exceeded'")
 	}
 
-	// Extract the source port from the TCP segment
-	destPort := int(tcpSegment[0])<<8 + int(tcpSegment[1])
+	if len(tcpSegment) < 2 {
+		return 0, errors.New("time exceeded message does not contain a tcp segment")
+	}
 
-	return destPort, routerAddr, nil
+	// Extract the source port from the TCP segment
+	return int(tcpSegment[0])<<8 + int(tcpSegment[1]), nil
 }
 
 // TraceRoute performs a traceroute to the specified host using TCP and listens for ICMP Time Exceeded messages using ICMP.
@@ -166,7 +211,7 @@ func TraceRoute(ctx context.Context, cfg tracerouteConfig) (map[int][]Hop, error
 	hops := make(map[int][]Hop)
 	log := logger.FromContext(ctx).With("target", cfg.Dest)
 
-	addr, err := net.ResolveTCPAddr("tcp", fmt.Sprintf("%s:%d", cfg.Dest, cfg.Port))
+	addr, err := net.ResolveTCPAddr("tcp", net.JoinHostPort(cfg.Dest, strconv.Itoa(cfg.Port)))
 	if err != nil {
 		sp.SetStatus(codes.Error, err.Error())
 		sp.RecordError(err)
@@ -252,13 +297,16 @@ func TraceRoute(ctx context.Context, cfg tracerouteConfig) (map[int][]Hop, error
 func doHop(ctx context.Context, addr net.Addr, ttl int, timeout time.Duration) (*Hop, error) {
 	span := trace.SpanFromContext(ctx)
 	log := logger.FromContext(ctx)
-	canIcmp, icmpListener, err := newIcmpListener()
-	if err != nil {
+	v6 := isIPv6(addr)
+	// Unprivileged icmp sockets don't receive the time exceeded messages of the hops
+	icmpListener, _, err := helper.ListenICMP(v6, false)
+	if err != nil && !errors.Is(err, os.ErrPermission) {
 		span.SetStatus(codes.Error, err.Error())
 		span.RecordError(err)
 		log.ErrorContext(ctx, "Failed to open ICMP socket", "err", err.Error())
 		return nil, err
 	}
+	canIcmp := err == nil
 	defer closeIcmpListener(canIcmp, icmpListener)
 
 	start := time.Now()
@@ -286,7 +334,7 @@ func doHop(ctx context.Context, addr net.Addr, ttl int, timeout time.Duration) (
 		}, nil
 	}
 
-	hop := handleIcmpResponse(ctx, icmpListener, clientPort, ttl, timeout)
+	hop := handleIcmpResponse(ctx, icmpListener, v6, clientPort, ttl, timeout)
 	hop.Latency = latency
 	if !hop.Reached {
 		span.AddEvent("ICMP hop not reached", trace.WithAttributes(
@@ -305,18 +353,6 @@ func doHop(ctx context.Context, addr net.Addr, ttl int, timeout time.Duration) (
 	return &hop, nil
 }
 
-// newIcmpListener creates a new ICMP listener and returns a boolean indicating if the necessary permissions were granted.
-func newIcmpListener() (bool, *icmp.PacketConn, error) {
-	icmpListener, err := icmp.ListenPacket("ip4:icmp", "0.0.0.0")
-	if err != nil {
-		if !errors.Is(err, unix.EPERM) {
-			return false, nil, err
-		}
-		return false, nil, nil
-	}
-	return true, icmpListener, nil
-}
-
 // closeIcmpListener closes the ICMP listener if it is not nil and the permissions were granted.
 func closeIcmpListener(canIcmp bool, icmpListener *icmp.PacketConn) {
 	if canIcmp && icmpListener != nil {
@@ -370,13 +406,13 @@ func handleTcpSuccess(ctx context.Context, conn net.Conn, addr net.Addr, ttl int
 
 // handleIcmpResponse attempts to read a time exceeded packet that matches clientPort until timeout is reached
 // if an error occurs while reading from the socket, handleIcmpResponse will silently fail and return a hop with hop.Reached=false
-func handleIcmpResponse(ctx context.Context, icmpListener *icmp.PacketConn, clientPort, ttl int, timeout time.Duration) Hop {
+func handleIcmpResponse(ctx context.Context, icmpListener *icmp.PacketConn, v6 bool, clientPort, ttl int, timeout time.Duration) Hop {
 	log := logger.FromContext(ctx)
 	deadline := time.Now().Add(timeout)
 
 	for time.Now().Unix() < deadline.Unix() {
 		log.DebugContext(ctx, "Reading ICMP message")
-		gotPort, addr, err := readIcmpMessage(ctx, icmpListener, timeout)
+		gotPort, addr, err := readIcmpMessage(ctx, icmpListener, v6, timeout)
 		if err != nil {
 			log.DebugContext(ctx, "Failed to read ICMP message", "err", err.Error())
 			continue
@@ -5,9 +5,15 @@
 package traceroute
 
 import (
+	"context"
 	"net"
 	"reflect"
 	"testing"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+	"golang.org/x/sys/unix"
 )
 
 func TestHopAddress_String(t *testing.T) {
@@ -83,3 +89,104 @@ func Test_newHopAddress(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseTimeExceeded(t *testing.T) {
+	// tcp segment starting with source port 30001 (0x7531)
+	segment := []byte{0x75, 0x31, 0x00, 0x50, 0, 0, 0, 1}
+	ipv4Header := make([]byte, IPv4HeaderSize)
+	ipv4Header[0] = 0x45
+	ipv4HeaderWithOptions := make([]byte, IPv4HeaderSize+4)
+	ipv4HeaderWithOptions[0] = 0x46
+	ipv6Header := make([]byte, IPv6HeaderSize)
+	ipv6Header[0] = 0x60
+
+	tests := []struct {
+		name     string
+		msgType  icmp.Type
+		data     []byte
+		v6       bool
+		wantPort int
+		wantErr  bool
+	}{
+		{
+			name:     "ipv4 time exceeded",
+			msgType:  ipv4.ICMPTypeTimeExceeded,
+			data:     append(ipv4Header, segment...),
+			wantPort: 30001,
+		},
+		{
+			name:     "ipv4 time exceeded with header options",
+			msgType:  ipv4.ICMPTypeTimeExceeded,
+			data:     append(ipv4HeaderWithOptions, segment...),
+			wantPort: 30001,
+		},
+		{
+			name:     "ipv6 time exceeded",
+			msgType:  ipv6.ICMPTypeTimeExceeded,
+			data:     append(ipv6Header, segment...),
+			v6:       true,
+			wantPort: 30001,
+		},
+		{
+			name:    "ipv6 time exceeded too short",
+			msgType: ipv6.ICMPTypeTimeExceeded,
+			data:    ipv6Header[:IPv4HeaderSize],
+			v6:      true,
+			wantErr: true,
+		},
+		{
+			name:    "ipv4 destination unreachable",
+			msgType: ipv4.ICMPTypeDestinationUnreachable,
+			data:    append(ipv4Header, segment...),
+			wantErr: true,
+		},
+		{
+			name:    "ipv6 destination unreachable",
+			msgType: ipv6.ICMPTypeDestinationUnreachable,
+			data:    append(ipv6Header, segment...),
+			v6:      true,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body icmp.MessageBody = &icmp.TimeExceeded{Data: tt.data}
+			if tt.msgType != ipv4.ICMPTypeTimeExceeded && tt.msgType != ipv6.ICMPTypeTimeExceeded {
+				body = &icmp.DstUnreach{Data: tt.data}
+			}
+			b, err := (&icmp.Message{Type: tt.msgType, Body: body}).Marshal(nil)
+			if err != nil {
+				t.Fatalf("failed to marshal icmp message: %v", err)
+			}
+
+			got, err := parseTimeExceeded(context.Background(), b, tt.v6)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTimeExceeded() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.wantPort {
+				t.Errorf("parseTimeExceeded() = %v, want %v", got, tt.wantPort)
+			}
+		})
+	}
+}
+
+func Test_hopLimitOption(t *testing.T) {
+	tests := []struct {
+		name      string
+		addr      net.Addr
+		wantLevel int
+		wantOpt   int
+	}{
+		{name: "ipv4", addr: &net.TCPAddr{IP: net.ParseIP("100.1.1.7"), Port: 80}, wantLevel: unix.IPPROTO_IP, wantOpt: unix.IP_TTL},
+		{name: "ipv4-mapped ipv6", addr: &net.TCPAddr{IP: net.ParseIP("::ffff:100.1.1.7"), Port: 80}, wantLevel: unix.IPPROTO_IP, wantOpt: unix.IP_TTL},
+		{name: "ipv6", addr: &net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 80}, wantLevel: unix.IPPROTO_IPV6, wantOpt: unix.IPV6_UNICAST_HOPS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, opt := hopLimitOption(isIPv6(tt.addr))
+			if level != tt.wantLevel || opt != tt.wantOpt {
+				t.Errorf("hopLimitOption() = (%v, %v), want (%v, %v)", level, opt, tt.wantLevel, tt.wantOpt)
+			}
+		})
+	}
+}
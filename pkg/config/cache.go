@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/telekom/sparrow/internal/logger"
+	"github.com/telekom/sparrow/pkg/checks/exec"
+	"github.com/telekom/sparrow/pkg/checks/runtime"
+	"gopkg.in/yaml.v3"
+)
+
+// errEmptyConfig is returned if an empty runtime configuration would replace a configuration with checks
+var errEmptyConfig = errors.New("empty runtime configuration would replace the cached runtime configuration")
+
+// configCache persists the last successfully loaded runtime configuration to a local file,
+// so it can be used as a fallback if the runtime configuration can't be loaded.
+// A nil cache or a cache without a path is disabled, but still validates the runtime configurations.
+type configCache struct {
+	path string
+	// exec restricts the exec checks of the cached runtime configuration
+	exec exec.Policy
+	// checks is true if the last stored runtime configuration has checks
+	checks bool
+	// partial is true if the runtime configurations are those of a loader layer,
+	// which are only validated once merged
+	partial bool
+}
+
+// newConfigCache creates a cache for the runtime configuration persisted at the given path
+func newConfigCache(path string, policy exec.Policy) *configCache {
+	return &configCache{path: path, exec: policy}
+}
+
+// newLoaderCache creates the cache for the runtime configurations of the given loader
+func newLoaderCache(cfg *Config) *configCache {
+	c := newConfigCache(cfg.Loader.CachePath, cfg.Exec)
+	c.partial = cfg.Loader.layer
+	return c
+}
+
+// enabled returns true if a cache file is configured
+func (c *configCache) enabled() bool {
+	return c != nil && c.path != ""
+}
+
+// validate returns an error if the runtime configuration is invalid
+// or contains exec checks which are not allowed.
+// Partial configurations are only checked for exec checks.
+func (c *configCache) validate(cfg runtime.Config) error {
+	if c == nil {
+		return errors.Join(cfg.Validate(), cfg.AllowsExec(exec.Policy{}))
+	}
+	if c.partial {
+		return cfg.AllowsExec(c.exec)
+	}
+	return errors.Join(cfg.Validate(), cfg.AllowsExec(c.exec))
+}
+
+// store validates the given runtime configuration and persists it if the cache is enabled.
+// An empty configuration never replaces a stored or cached configuration with checks and [errEmptyConfig]
+// is returned instead, so a broken source can't remove all checks. The file is replaced atomically,
+// so a crash while writing never leaves a broken cache behind.
+func (c *configCache) store(ctx context.Context, cfg runtime.Config) error {
+	log := logger.FromContext(ctx)
+	if c.enabled() {
+		log = log.With("path", c.path)
+	}
+
+	if err := c.validate(cfg); err != nil {
+		log.Warn("Not storing invalid runtime configuration", "error", err)
+		return fmt.Errorf("invalid runtime configuration: %w", err)
+	}
+	if cfg.Empty() {
+		if c != nil && c.checks {
+			log.Warn("Not storing empty runtime configuration, since the last configuration has checks")
+			return errEmptyConfig
+		}
+		if cached, err := c.load(ctx); err == nil && !cached.Empty() {
+			log.Warn("Not storing empty runtime configuration, since the cached configuration has checks")
+			return errEmptyConfig
+		}
+	}
+	if c == nil {
+		return nil
+	}
+	c.checks = !cfg.Empty()
+	if !c.enabled() {
+		return nil
+	}
+
+	b, err := yaml.Marshal(cfg)
+	if err != nil {
+		log.Error("Failed to marshal runtime configuration for the cache", "error", err)
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), "."+filepath.Base(c.path)+"-")
+	if err != nil {
+		log.Error("Failed to create runtime configuration cache file", "error", err)
+		return err
+	}
+	defer func() {
+		if rErr := os.Remove(tmp.Name()); rErr != nil && !errors.Is(rErr, os.ErrNotExist) {
+			log.Warn("Failed to remove temporary cache file", "error", rErr)
+		}
+	}()
+
+	if _, err = tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		log.Error("Failed to write runtime configuration cache file", "error", err)
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		log.Error("Failed to close runtime configuration cache file", "error", err)
+		return err
+	}
+	if err = os.Rename(tmp.Name(), c.path); err != nil {
+		log.Error("Failed to replace runtime configuration cache file", "error", err)
+		return err
+	}
+
+	log.Debug("Stored runtime configuration in cache")
+	return nil
+}
+
+// load reads the cached runtime configuration.
+// Returns an error if the cache is disabled, missing or the cached configuration is invalid.
+func (c *configCache) load(ctx context.Context) (cfg runtime.Config, err error) {
+	if !c.enabled() {
+		return cfg, errors.New("runtime configuration cache is disabled")
+	}
+	log := logger.FromContext(ctx).With("path", c.path)
+
+	b, err := os.ReadFile(c.path)
+	if err != nil {
+		log.Warn("Failed to read runtime configuration cache file", "error", err)
+		return cfg, err
+	}
+	if err = yaml.Unmarshal(b, &cfg); err != nil {
+		log.Error("Failed to parse runtime configuration cache file", "error", err)
+		return cfg, fmt.Errorf("failed to parse cached runtime configuration: %w", err)
+	}
+	if err = c.validate(cfg); err != nil {
+		log.Error("Cached runtime configuration is invalid", "error", err)
+		return cfg, fmt.Errorf("invalid cached runtime configuration: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// initial returns the runtime configuration to use on startup.
+// A successfully loaded and valid configuration is stored in the cache and returned.
+// Otherwise the cached configuration is returned if available, so an unreachable source,
+// an invalid configuration or an empty configuration doesn't unregister all checks.
+// Without a cached configuration, an empty configuration is returned instead of an invalid one.
+func (c *configCache) initial(ctx context.Context, cfg runtime.Config, loadErr error) runtime.Config {
+	log := logger.FromContext(ctx)
+
+	if loadErr == nil {
+		if vErr := c.validate(cfg); vErr != nil {
+			log.Warn("Loaded runtime configuration is invalid", "error", vErr)
+		} else if err := c.store(ctx, cfg); !errors.Is(err, errEmptyConfig) {
+			return cfg
+		}
+	}
+
+	cached, err := c.load(ctx)
+	if err != nil {
+		return runtime.Config{}
+	}
+	log.Info("Using cached runtime configuration", "path", c.path)
+	c.checks = !cached.Empty()
+	return cached
+}
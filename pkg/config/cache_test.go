@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telekom/sparrow/pkg/checks"
+	"github.com/telekom/sparrow/pkg/checks/exec"
+	"github.com/telekom/sparrow/pkg/checks/health"
+	"github.com/telekom/sparrow/pkg/checks/runtime"
+)
+
+func TestConfigCache(t *testing.T) {
+	valid := runtime.Config{Checks: map[string]checks.Runtime{"health": &health.Config{
+		Targets:  []health.Target{{URL: "https://example.com"}},
+		Interval: time.Second,
+		Timeout:  time.Second,
+	}}}
+	invalid := runtime.Config{Checks: map[string]checks.Runtime{"health": &health.Config{Interval: time.Second}}}
+
+	t.Run("store and load", func(t *testing.T) {
+		c := newConfigCache(filepath.Join(t.TempDir(), "cache.yaml"), exec.Policy{})
+		require.NoError(t, c.store(t.Context(), valid))
+
+		got, err := c.load(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, valid, got)
+
+		entries, err := os.ReadDir(filepath.Dir(c.path))
+		require.NoError(t, err)
+		assert.Len(t, entries, 1, "temporary files should be removed")
+	})
+
+	t.Run("invalid config is not stored", func(t *testing.T) {
+		c := newConfigCache(filepath.Join(t.TempDir(), "cache.yaml"), exec.Policy{})
+		require.NoError(t, c.store(t.Context(), valid))
+		assert.Error(t, c.store(t.Context(), invalid))
+
+		got, err := c.load(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, valid, got)
+	})
+
+	t.Run("exec checks not allowed", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "cache.yaml")
+		withExec := runtime.Config{Checks: map[string]checks.Runtime{
+			"health": valid.For("health"),
+			"exec": &exec.Config{
+				Targets:  []exec.Target{{Name: "probe", Command: "true"}},
+				Interval: time.Second,
+				Timeout:  time.Second,
+			},
+		}}
+		c := newConfigCache(path, exec.Policy{Enabled: true, Commands: []exec.AllowedCommand{{Command: "true"}}})
+		require.NoError(t, c.store(t.Context(), withExec))
+
+		c = newConfigCache(path, exec.Policy{})
+		_, err := c.load(t.Context())
+		assert.Error(t, err)
+		assert.Error(t, c.store(t.Context(), withExec))
+	})
+
+	t.Run("missing cache", func(t *testing.T) {
+		c := newConfigCache(filepath.Join(t.TempDir(), "cache.yaml"), exec.Policy{})
+		_, err := c.load(t.Context())
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("corrupt cache", func(t *testing.T) {
+		c := newConfigCache(filepath.Join(t.TempDir(), "cache.yaml"), exec.Policy{})
+		require.NoError(t, os.WriteFile(c.path, []byte("health: ["), 0o600))
+		_, err := c.load(t.Context())
+		assert.Error(t, err)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		var c *configCache
+		assert.False(t, c.enabled())
+		assert.NoError(t, c.store(t.Context(), valid))
+		_, err := c.load(t.Context())
+		assert.Error(t, err)
+		assert.Equal(t, valid, c.initial(t.Context(), valid, nil))
+		assert.Equal(t, runtime.Config{}, c.initial(t.Context(), invalid, nil), "invalid configs must not be used")
+		assert.Error(t, c.store(t.Context(), invalid))
+	})
+
+	t.Run("disabled validates", func(t *testing.T) {
+		c := newConfigCache("", exec.Policy{})
+		assert.False(t, c.enabled())
+
+		withExec := runtime.Config{Checks: map[string]checks.Runtime{"exec": &exec.Config{
+			Targets:  []exec.Target{{Name: "probe", Command: "true"}},
+			Interval: time.Second,
+			Timeout:  time.Second,
+		}}}
+		assert.Equal(t, runtime.Config{}, c.initial(t.Context(), withExec, nil), "exec checks must not be used if not allowed")
+		assert.Error(t, c.store(t.Context(), withExec))
+		assert.Equal(t, runtime.Config{}, c.initial(t.Context(), invalid, nil))
+		assert.Equal(t, runtime.Config{}, c.initial(t.Context(), runtime.Config{}, errors.New("unreachable")))
+
+		// an empty config never replaces the last config with checks
+		assert.Equal(t, valid, c.initial(t.Context(), valid, nil))
+		assert.ErrorIs(t, c.store(t.Context(), runtime.Config{}), errEmptyConfig)
+		require.NoError(t, c.store(t.Context(), valid))
+	})
+
+	t.Run("partial", func(t *testing.T) {
+		c := newLoaderCache(&Config{Loader: LoaderConfig{CachePath: filepath.Join(t.TempDir(), "cache.yaml"), layer: true}})
+		partial := runtime.Config{Checks: map[string]checks.Runtime{"health": &health.Config{Targets: []health.Target{{URL: "https://example.com"}}}}}
+		assert.Equal(t, partial, c.initial(t.Context(), partial, nil), "the configurations of layers are validated once merged")
+
+		withExec := runtime.Config{Checks: map[string]checks.Runtime{"exec": &exec.Config{Targets: []exec.Target{{Name: "probe", Command: "true"}}}}}
+		assert.Error(t, c.store(t.Context(), withExec), "exec checks must not be allowed in layers")
+	})
+
+	t.Run("initial", func(t *testing.T) {
+		c := newConfigCache(filepath.Join(t.TempDir(), "cache.yaml"), exec.Policy{})
+
+		// without a cached config, the loaded config is used
+		assert.Equal(t, runtime.Config{}, c.initial(t.Context(), runtime.Config{}, errors.New("unreachable")))
+
+		assert.Equal(t, valid, c.initial(t.Context(), valid, nil))
+		assert.Equal(t, valid, c.initial(t.Context(), runtime.Config{}, errors.New("unreachable")))
+		assert.Equal(t, valid, c.initial(t.Context(), invalid, nil))
+
+		// an empty config never replaces a cached config with checks
+		assert.Equal(t, valid, c.initial(t.Context(), runtime.Config{}, nil))
+		got, err := c.load(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, valid, got)
+	})
+
+	t.Run("empty config", func(t *testing.T) {
+		c := newConfigCache(filepath.Join(t.TempDir(), "cache.yaml"), exec.Policy{})
+		require.NoError(t, c.store(t.Context(), runtime.Config{}), "an empty config is cached if nothing is cached")
+		require.NoError(t, c.store(t.Context(), valid))
+		assert.ErrorIs(t, c.store(t.Context(), runtime.Config{}), errEmptyConfig)
+
+		got, err := c.load(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, valid, got)
+	})
+}
@@ -5,13 +5,18 @@
 package config
 
 import (
+	"strconv"
 	"time"
 
+	"github.com/telekom/sparrow/pkg/sparrow/aggregator"
 	"github.com/telekom/sparrow/pkg/sparrow/metrics"
+	"github.com/telekom/sparrow/pkg/sparrow/notifier"
 	"github.com/telekom/sparrow/pkg/sparrow/targets"
 
 	"github.com/telekom/sparrow/internal/helper"
 	"github.com/telekom/sparrow/pkg/api"
+	"github.com/telekom/sparrow/pkg/checks/exec"
+	"github.com/telekom/sparrow/pkg/db"
 )
 
 // Metadata holds arbitrary key-value metadata for the Sparrow instance.
@@ -32,6 +37,14 @@ type Config struct {
 	TargetManager targets.TargetManagerConfig `yaml:"targetManager" mapstructure:"targetManager"`
 	// Telemetry is the configuration for the telemetry
 	Telemetry metrics.Config `yaml:"telemetry" mapstructure:"telemetry"`
+	// Db is the configuration for the result database
+	Db db.Config `yaml:"db" mapstructure:"db"`
+	// Aggregator is the configuration for pulling the results of the peer sparrows
+	Aggregator aggregator.Config `yaml:"aggregator" mapstructure:"aggregator"`
+	// Notifier is the configuration for the webhook notifications on state transitions
+	Notifier notifier.Config `yaml:"notifier" mapstructure:"notifier"`
+	// Exec restricts the exec checks of runtime configurations from remote sources
+	Exec exec.Policy `yaml:"exec" mapstructure:"exec"`
 }
 
 type LoaderType string
@@ -39,14 +52,27 @@ type LoaderType string
 const (
 	loaderHTTP LoaderType = "http"
 	loaderFile LoaderType = "file"
+	loaderGit  LoaderType = "git"
 )
 
 // LoaderConfig is the configuration for loader
 type LoaderConfig struct {
+	// Name identifies a layer of a layered loader in the logs and metrics. Defaults to the index of the layer
+	Name     string           `yaml:"name,omitempty" mapstructure:"name"`
 	Type     LoaderType       `yaml:"type" mapstructure:"type"`
 	Interval time.Duration    `yaml:"interval" mapstructure:"interval"`
 	Http     HttpLoaderConfig `yaml:"http" mapstructure:"http"`
 	File     FileLoaderConfig `yaml:"file" mapstructure:"file"`
+	Git      GitLoaderConfig  `yaml:"git" mapstructure:"git"`
+	// CachePath is the path of the file the last successfully loaded runtime configuration is stored in.
+	// The cached configuration is used if the runtime configuration can't be loaded. Disabled if empty
+	CachePath string `yaml:"cachePath" mapstructure:"cachePath"`
+	// Layers are the loaders whose runtime configurations are merged in order.
+	// If set, the other settings of this loader are ignored
+	Layers []LoaderConfig `yaml:"layers,omitempty" mapstructure:"layers"`
+	// layer is true if the loader runs as a layer of a layered loader.
+	// Its runtime configurations may be partial, so only the merged configuration is validated
+	layer bool
 }
 
 // HttpLoaderConfig is the configuration for the http loader
@@ -62,11 +88,50 @@ type FileLoaderConfig struct {
 	Path string `yaml:"path" mapstructure:"path"`
 }
 
+// GitLoaderConfig is the configuration for the git loader
+type GitLoaderConfig struct {
+	// Url is the url or local path of the repository
+	Url string `yaml:"url" mapstructure:"url"`
+	// Ref is the branch or tag to load the configuration from. Defaults to the default branch
+	Ref string `yaml:"ref" mapstructure:"ref"`
+	// Path is the path of the configuration file in the repository
+	Path string `yaml:"path" mapstructure:"path"`
+	// Username is the username used for the token authentication. Defaults to "oauth2"
+	Username string `yaml:"username" mapstructure:"username"`
+	// Token is the token used to authenticate at the repository over http(s)
+	Token string `yaml:"token" mapstructure:"token"`
+	// Timeout is the timeout for fetching the repository
+	Timeout time.Duration `yaml:"timeout" mapstructure:"timeout"`
+}
+
+// HasLayers returns true if the loader merges the runtime configurations of multiple layers
+func (c *LoaderConfig) HasLayers() bool {
+	return len(c.Layers) > 0
+}
+
+// layerName returns the name of the layer at the given index
+func (c *LoaderConfig) layerName(i int) string {
+	if c.Layers[i].Name != "" {
+		return c.Layers[i].Name
+	}
+	return strconv.Itoa(i)
+}
+
 // HasTargetManager returns true if the config has a target manager
 func (c *Config) HasTargetManager() bool {
 	return c.TargetManager.Enabled
 }
 
+// HasAggregator returns true if the config has the aggregator enabled
+func (c *Config) HasAggregator() bool {
+	return c.Aggregator.Enabled
+}
+
+// HasNotifier returns true if the config has the notifier enabled
+func (c *Config) HasNotifier() bool {
+	return c.Notifier.Enabled
+}
+
 // HasTelemetry returns true if the config has telemetry enabled
 func (c *Config) HasTelemetry() bool {
 	return c.Telemetry.Enabled
@@ -17,4 +17,16 @@ var (
 	ErrInvalidLoaderHttpRetryCount = errors.New("invalid loader http retry count")
 	// ErrInvalidLoaderFilePath is returned when the loader file path is invalid
 	ErrInvalidLoaderFilePath = errors.New("invalid loader file path")
+	// ErrInvalidLoaderGitURL is returned when the loader git url is invalid
+	ErrInvalidLoaderGitURL = errors.New("invalid loader git url")
+	// ErrInvalidLoaderGitPath is returned when the loader git path is invalid
+	ErrInvalidLoaderGitPath = errors.New("invalid loader git path")
+	// ErrInvalidLoaderGitRef is returned when the loader git ref is invalid
+	ErrInvalidLoaderGitRef = errors.New("invalid loader git ref")
+	// ErrInvalidLoaderGitTimeout is returned when the loader git timeout is invalid
+	ErrInvalidLoaderGitTimeout = errors.New("invalid loader git timeout")
+	// ErrInvalidLoaderLayers is returned when the loader layers are invalid
+	ErrInvalidLoaderLayers = errors.New("invalid loader layers")
+	// ErrAggregatorWithoutTargetManager is returned when the aggregator is enabled without a target manager
+	ErrAggregatorWithoutTargetManager = errors.New("aggregator requires the target manager")
 )
@@ -6,14 +6,17 @@ package config
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/telekom/sparrow/internal/logger"
 	"github.com/telekom/sparrow/pkg/checks/runtime"
 	"gopkg.in/yaml.v3"
@@ -21,11 +24,17 @@ import (
 
 var _ Loader = (*FileLoader)(nil)
 
+// fileWatchDebounce is the time to wait for further changes of the config file before it is reloaded,
+// since editors and Kubernetes change the file with several operations
+const fileWatchDebounce = 100 * time.Millisecond
+
 type FileLoader struct {
 	config   LoaderConfig
 	cRuntime chan<- runtime.Config
 	done     chan struct{}
 	fsys     fs.FS
+	// last is the fingerprint of the last sent runtime configuration
+	last string
 }
 
 func NewFileLoader(cfg *Config, cRuntime chan<- runtime.Config) *FileLoader {
@@ -38,8 +47,9 @@ func NewFileLoader(cfg *Config, cRuntime chan<- runtime.Config) *FileLoader {
 }
 
 // Run gets the runtime configuration from the local file.
-// The config will be loaded periodically defined by the loader interval configuration.
-// If the interval is 0, the configuration is only fetched once and the loader is disabled.
+// The file is watched for changes and additionally reloaded periodically defined by the loader interval configuration.
+// The configuration is only sent again if it differs from the last sent configuration.
+// If the interval is 0, the file is only watched and not reloaded periodically.
 func (f *FileLoader) Run(ctx context.Context) error {
 	ctx, cancel := logger.NewContextWithLogger(ctx)
 	defer cancel()
@@ -49,18 +59,27 @@ func (f *FileLoader) Run(ctx context.Context) error {
 	cfg, err := f.getRuntimeConfig(ctx)
 	if err != nil {
 		log.Warn("Could not get local runtime configuration", "error", err)
-		err = fmt.Errorf("could not get local runtime configuration: %w", err)
+	} else {
+		f.last = fingerprint(cfg)
 	}
 	f.cRuntime <- cfg
 
-	if f.config.Interval == 0 {
-		log.Info("File Loader disabled")
-		return err
+	changes, stop := f.watch(ctx)
+	defer stop()
+	// reload is set when the file changed and fires once no further change happened within the debounce
+	var reload <-chan time.Time
+
+	// tick stays nil and never fires if the periodic reload is disabled
+	var tick <-chan time.Time
+	var ticker *time.Ticker
+	if f.config.Interval > 0 {
+		ticker = time.NewTicker(f.config.Interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	} else {
+		log.Info("File Loader only watches the config file, since the interval is 0")
 	}
 
-	tick := time.NewTicker(f.config.Interval)
-	defer tick.Stop()
-
 	for {
 		select {
 		case <-f.done:
@@ -68,19 +87,104 @@ func (f *FileLoader) Run(ctx context.Context) error {
 			return nil
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-tick.C:
-			runtimeCfg, err := f.getRuntimeConfig(ctx)
-			if err != nil {
-				log.Warn("Could not get local runtime configuration", "error", err)
-				tick.Reset(f.config.Interval)
-				continue
+		case <-changes:
+			reload = time.After(fileWatchDebounce)
+		case <-reload:
+			reload = nil
+			f.reload(ctx)
+			if ticker != nil {
+				ticker.Reset(f.config.Interval)
 			}
+		case <-tick:
+			f.reload(ctx)
+		}
+	}
+}
+
+// reload gets the local runtime configuration and sends it if it changed
+func (f *FileLoader) reload(ctx context.Context) {
+	log := logger.FromContext(ctx)
 
-			log.Info("Successfully got local runtime configuration")
-			f.cRuntime <- runtimeCfg
-			tick.Reset(f.config.Interval)
+	runtimeCfg, err := f.getRuntimeConfig(ctx)
+	if err != nil {
+		log.Warn("Could not get local runtime configuration", "error", err)
+		return
+	}
+	fp := fingerprint(runtimeCfg)
+	if fp != "" && fp == f.last {
+		log.Debug("Local runtime configuration unchanged")
+		return
+	}
+
+	log.Info("Successfully got local runtime configuration")
+	f.last = fp
+	f.cRuntime <- runtimeCfg
+}
+
+// watch watches the directory of the config file and signals changes of the file.
+// The directory is watched instead of the file, so replacing the file and swapping
+// symlinks like Kubernetes does for ConfigMap volumes are noticed as well.
+// If the directory cannot be watched, the returned channel never signals
+// and the file is only reloaded in the loader interval.
+func (f *FileLoader) watch(ctx context.Context) (changes <-chan struct{}, stop func()) {
+	log := logger.FromContext(ctx)
+	dir, name := filepath.Split(filepath.Clean(f.config.File.Path))
+	if dir == "" {
+		dir = "."
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Warn("Could not watch the config file, falling back to the loader interval", "error", err)
+		return nil, func() {}
+	}
+	if err = watcher.Add(dir); err != nil {
+		log.Warn("Could not watch the config file, falling back to the loader interval", "error", err)
+		_ = watcher.Close()
+		return nil, func() {}
+	}
+
+	c := make(chan struct{}, 1)
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				// Kubernetes swaps the "..data" symlink the config file points to
+				base := filepath.Base(event.Name)
+				if base != name && !strings.HasPrefix(base, "..") {
+					continue
+				}
+				select {
+				case c <- struct{}{}:
+				default:
+				}
+			case wErr, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Warn("Error while watching the config file", "error", wErr)
+			}
 		}
+	}()
+
+	return c, func() {
+		if cErr := watcher.Close(); cErr != nil {
+			log.Warn("Failed to stop watching the config file", "error", cErr)
+		}
+	}
+}
+
+// fingerprint returns a representation of the runtime configuration to detect changes.
+// It is taken before the configuration is sent, since the receiver modifies the configuration.
+func fingerprint(cfg runtime.Config) string {
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return ""
 	}
+	return string(b)
 }
 
 // getRuntimeConfig gets the local runtime configuration from the specified file.
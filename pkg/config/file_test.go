@@ -8,10 +8,15 @@ import (
 	"context"
 	"fmt"
 	"io/fs"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telekom/sparrow/pkg/checks"
 	"github.com/telekom/sparrow/pkg/checks/health"
 	"github.com/telekom/sparrow/pkg/checks/runtime"
 	"github.com/telekom/sparrow/pkg/config/test"
@@ -48,13 +53,13 @@ func TestFileLoader_Run(t *testing.T) {
 					Path: "test/data/config.yaml",
 				},
 			},
-			want: runtime.Config{
-				Health: &health.Config{
-					Targets:  []string{"http://localhost:8080/health"},
+			want: runtime.Config{Checks: map[string]checks.Runtime{
+				"health": &health.Config{
+					Targets:  []health.Target{{URL: "http://localhost:8080/health"}},
 					Interval: 1 * time.Second,
 					Timeout:  1 * time.Second,
 				},
-			},
+			}},
 			wantErr: false,
 		},
 		{
@@ -66,13 +71,13 @@ func TestFileLoader_Run(t *testing.T) {
 					Path: "test/data/config.yaml",
 				},
 			},
-			want: runtime.Config{
-				Health: &health.Config{
-					Targets:  []string{"http://localhost:8080/health"},
+			want: runtime.Config{Checks: map[string]checks.Runtime{
+				"health": &health.Config{
+					Targets:  []health.Target{{URL: "http://localhost:8080/health"}},
 					Interval: 1 * time.Second,
 					Timeout:  1 * time.Second,
 				},
-			},
+			}},
 			wantErr: false,
 		},
 	}
@@ -225,3 +230,112 @@ func TestFileLoader_getRuntimeConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestFileLoader_Run_Watch(t *testing.T) {
+	config := func(target string) string {
+		return fmt.Sprintf("health:\n  targets: [%s]\n  interval: 1s\n  timeout: 1s\n", target)
+	}
+	receive := func(t *testing.T, result chan runtime.Config) runtime.Config {
+		t.Helper()
+		select {
+		case cfg := <-result:
+			return cfg
+		case <-time.After(5 * time.Second):
+			t.Fatal("configuration not loaded")
+			return runtime.Config{}
+		}
+	}
+	assertNothing := func(t *testing.T, result chan runtime.Config) {
+		t.Helper()
+		select {
+		case cfg := <-result:
+			t.Fatalf("unexpected configuration: %v", cfg)
+		case <-time.After(3 * fileWatchDebounce):
+		}
+	}
+	write := func(t *testing.T, path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("file changes", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		write(t, path, config("https://a.example.com"))
+		result := make(chan runtime.Config, 1)
+		f := NewFileLoader(&Config{Loader: LoaderConfig{Type: loaderFile, Interval: time.Hour, File: FileLoaderConfig{Path: path}}}, result)
+		go func() {
+			_ = f.Run(t.Context())
+		}()
+		defer f.Shutdown(t.Context())
+
+		assert.Equal(t, healthConfig("https://a.example.com"), receive(t, result))
+		// give the watcher time to start
+		time.Sleep(fileWatchDebounce)
+
+		// the same configuration is not sent again
+		write(t, path, "# comment\n"+config("https://a.example.com"))
+		assertNothing(t, result)
+
+		write(t, path, config("https://b.example.com"))
+		assert.Equal(t, healthConfig("https://b.example.com"), receive(t, result))
+	})
+
+	t.Run("file changes without interval", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		write(t, path, config("https://a.example.com"))
+		result := make(chan runtime.Config, 1)
+		f := NewFileLoader(&Config{Loader: LoaderConfig{Type: loaderFile, Interval: 0, File: FileLoaderConfig{Path: path}}}, result)
+		go func() {
+			_ = f.Run(t.Context())
+		}()
+		defer f.Shutdown(t.Context())
+
+		assert.Equal(t, healthConfig("https://a.example.com"), receive(t, result))
+		time.Sleep(fileWatchDebounce)
+
+		write(t, path, config("https://b.example.com"))
+		assert.Equal(t, healthConfig("https://b.example.com"), receive(t, result))
+	})
+
+	t.Run("kubernetes configmap", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, filepath.Join(dir, "..2025_01_01_00_00_00.1", "config.yaml"), config("https://a.example.com"))
+		require.NoError(t, os.Symlink("..2025_01_01_00_00_00.1", filepath.Join(dir, "..data")))
+		require.NoError(t, os.Symlink(filepath.Join("..data", "config.yaml"), filepath.Join(dir, "config.yaml")))
+
+		result := make(chan runtime.Config, 1)
+		f := NewFileLoader(&Config{Loader: LoaderConfig{Type: loaderFile, Interval: time.Hour, File: FileLoaderConfig{Path: filepath.Join(dir, "config.yaml")}}}, result)
+		go func() {
+			_ = f.Run(t.Context())
+		}()
+		defer f.Shutdown(t.Context())
+
+		assert.Equal(t, healthConfig("https://a.example.com"), receive(t, result))
+		time.Sleep(fileWatchDebounce)
+
+		// kubernetes writes the new data and atomically swaps the ..data symlink
+		write(t, filepath.Join(dir, "..2025_01_01_00_01_00.2", "config.yaml"), config("https://b.example.com"))
+		require.NoError(t, os.Symlink("..2025_01_01_00_01_00.2", filepath.Join(dir, "..data_tmp")))
+		require.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+		assert.Equal(t, healthConfig("https://b.example.com"), receive(t, result))
+	})
+
+	t.Run("unchanged configuration is not sent on interval", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		write(t, path, config("https://a.example.com"))
+		result := make(chan runtime.Config, 1)
+		f := NewFileLoader(&Config{Loader: LoaderConfig{Type: loaderFile, Interval: 10 * time.Millisecond, File: FileLoaderConfig{Path: path}}}, result)
+		go func() {
+			_ = f.Run(t.Context())
+		}()
+		defer f.Shutdown(t.Context())
+
+		assert.Equal(t, healthConfig("https://a.example.com"), receive(t, result))
+		assertNothing(t, result)
+	})
+}
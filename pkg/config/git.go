@@ -0,0 +1,329 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/telekom/sparrow/internal/logger"
+	execcheck "github.com/telekom/sparrow/pkg/checks/exec"
+	"github.com/telekom/sparrow/pkg/checks/runtime"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// defaultGitRef is the ref fetched if no ref is configured
+	defaultGitRef = "HEAD"
+	// defaultGitUsername is the username used for the token authentication if no username is configured
+	defaultGitUsername = "oauth2"
+	// maxGitConfigSize is the maximum size of the configuration file read from the repository
+	maxGitConfigSize = 10 << 20
+)
+
+var _ Loader = (*GitLoader)(nil)
+
+// GitLoader loads the runtime configuration from a file in a git repository.
+// It lists the refs of the repository and only fetches the configured ref
+// into memory and sends the configuration if its commit changed.
+// Local repositories are read directly.
+type GitLoader struct {
+	cfg      LoaderConfig
+	cRuntime chan<- runtime.Config
+	done     chan struct{}
+	cache    *configCache
+	// exec restricts the exec checks of the git runtime configuration
+	exec execcheck.Policy
+	// commit is the commit the last sent runtime configuration was loaded from
+	commit string
+}
+
+func NewGitLoader(cfg *Config, cRuntime chan<- runtime.Config) *GitLoader {
+	return &GitLoader{
+		cfg:      cfg.Loader,
+		cRuntime: cRuntime,
+		done:     make(chan struct{}, 1),
+		cache:    newLoaderCache(cfg),
+		exec:     cfg.Exec,
+	}
+}
+
+// Run gets the runtime configuration from the file of the configured git repository.
+// The repository will be fetched periodically defined by the loader interval configuration
+// and the configuration is only sent again if the commit of the ref changed.
+// If the configuration can't be loaded or is invalid on startup, the cached configuration is used instead.
+// Afterwards, invalid configurations and empty configurations replacing a cached configuration with checks are ignored.
+// If the interval is 0, the configuration is only fetched once and the loader is disabled.
+func (g *GitLoader) Run(ctx context.Context) error {
+	ctx, cancel := logger.NewContextWithLogger(ctx)
+	defer cancel()
+	log := logger.FromContext(ctx)
+
+	// Get the runtime configuration once on startup
+	cfg, commit, err := g.getRuntimeConfig(ctx)
+	if err != nil {
+		log.Warn("Could not get git runtime configuration", "error", err)
+		err = fmt.Errorf("could not get git runtime configuration: %w", err)
+	}
+	initial := g.cache.initial(ctx, cfg, err)
+	if fingerprint(initial) == fingerprint(cfg) {
+		g.commit = commit
+	}
+	g.cRuntime <- initial
+
+	if g.cfg.Interval == 0 {
+		log.Info("Git Loader disabled")
+		return err
+	}
+
+	tick := time.NewTicker(g.cfg.Interval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-g.done:
+			log.Info("Git Loader terminated")
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-tick.C:
+			runtimeCfg, commit, err := g.getRuntimeConfig(ctx)
+			if err != nil {
+				log.Warn("Could not get git runtime configuration", "error", err)
+				tick.Reset(g.cfg.Interval)
+				continue
+			}
+			if commit == "" {
+				log.Debug("Git runtime configuration unchanged", "commit", g.commit)
+				tick.Reset(g.cfg.Interval)
+				continue
+			}
+			if err = g.cache.store(ctx, runtimeCfg); errors.Is(err, errEmptyConfig) {
+				log.Warn("Ignoring empty git runtime configuration, since the cached configuration has checks", "commit", commit)
+				tick.Reset(g.cfg.Interval)
+				continue
+			}
+
+			log.Info("Successfully got git runtime configuration", "commit", commit)
+			g.commit = commit
+			g.cRuntime <- runtimeCfg
+			tick.Reset(g.cfg.Interval)
+		}
+	}
+}
+
+// getRuntimeConfig resolves the configured ref of the repository and reads and validates
+// the runtime configuration of its commit. The commit is only fetched if it changed.
+// Returns the fetched commit, which is empty if it is the commit of the last sent configuration.
+func (g *GitLoader) getRuntimeConfig(ctx context.Context) (cfg runtime.Config, commit string, err error) {
+	log := logger.FromContext(ctx).With("url", g.cfg.Git.Url, "ref", g.ref(), "path", g.cfg.Git.Path)
+	if g.cfg.Git.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.cfg.Git.Timeout)
+		defer cancel()
+	}
+
+	objects, hash, err := g.open(ctx)
+	if err != nil {
+		log.Error("Failed to fetch git repository", "error", err)
+		return cfg, "", err
+	}
+	if hash.String() == g.commit {
+		return cfg, "", nil
+	}
+
+	c, err := peel(objects, hash)
+	if err != nil {
+		log.Error("Failed to resolve fetched commit", "error", err)
+		return cfg, "", err
+	}
+	commit = c.Hash.String()
+	if commit == g.commit {
+		return cfg, "", nil
+	}
+
+	b, err := readFile(c, strings.TrimPrefix(g.cfg.Git.Path, "/"))
+	if err != nil {
+		log.Error("Failed to read config file from git repository", "commit", commit, "error", err)
+		return cfg, "", err
+	}
+	if err = yaml.Unmarshal(b, &cfg); err != nil {
+		log.Error("Failed to parse config file from git repository", "commit", commit, "error", err)
+		return cfg, "", fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if err = cfg.AllowsExec(g.exec); err != nil {
+		log.Error("Git runtime configuration contains exec checks which are not allowed", "commit", commit, "error", err)
+		return runtime.Config{}, "", err
+	}
+	// the configuration of a layer is only validated once merged
+	if err = cfg.Validate(); err != nil && !g.cfg.layer {
+		log.Error("Git runtime configuration is invalid", "commit", commit, "error", err)
+		return cfg, "", fmt.Errorf("invalid runtime configuration: %w", err)
+	}
+
+	return cfg, commit, nil
+}
+
+// open returns the objects of the repository and the hash the configured ref points to.
+// Local repositories are opened directly. Of remote repositories, the refs are listed
+// and the objects of the ref are only fetched into memory if its commit changed,
+// in which case the returned objects are empty.
+func (g *GitLoader) open(ctx context.Context) (storer.EncodedObjectStorer, plumbing.Hash, error) {
+	ep, err := transport.NewEndpoint(g.cfg.Git.Url)
+	if err != nil {
+		return nil, plumbing.ZeroHash, fmt.Errorf("invalid repository url: %w", err)
+	}
+
+	if ep.Protocol == "file" {
+		repo, err := git.PlainOpen(ep.Path)
+		if err != nil {
+			return nil, plumbing.ZeroHash, fmt.Errorf("failed to open repository: %w", err)
+		}
+		_, hash, err := resolveRef(g.ref(), func(name plumbing.ReferenceName) (*plumbing.Reference, error) {
+			return repo.Reference(name, false)
+		})
+		return repo.Storer, hash, err
+	}
+
+	storage := memory.NewStorage()
+	remote := git.NewRemote(storage, &gitconfig.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{g.cfg.Git.Url}})
+	refs, err := remote.ListContext(ctx, &git.ListOptions{Auth: g.auth(ep), PeelingOption: git.AppendPeeled})
+	if err != nil {
+		return nil, plumbing.ZeroHash, fmt.Errorf("failed to list refs: %w", err)
+	}
+	byName := make(map[plumbing.ReferenceName]*plumbing.Reference, len(refs))
+	for _, r := range refs {
+		byName[r.Name()] = r
+	}
+	name, hash, err := resolveRef(g.ref(), func(name plumbing.ReferenceName) (*plumbing.Reference, error) {
+		if r, ok := byName[name]; ok {
+			return r, nil
+		}
+		return nil, plumbing.ErrReferenceNotFound
+	})
+	if err != nil {
+		return nil, plumbing.ZeroHash, err
+	}
+	// annotated tags are compared by the commit they point to
+	if peeled, ok := byName[name+"^{}"]; ok && peeled.Hash().String() == g.commit {
+		return storage, peeled.Hash(), nil
+	}
+	if hash.String() == g.commit {
+		return storage, hash, nil
+	}
+
+	err = remote.FetchContext(ctx, &git.FetchOptions{
+		RefSpecs: []gitconfig.RefSpec{gitconfig.RefSpec(fmt.Sprintf("+%s:%s", name, name))},
+		Depth:    1,
+		Auth:     g.auth(ep),
+		Tags:     git.NoTags,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return nil, plumbing.ZeroHash, fmt.Errorf("failed to fetch %s: %w", name, err)
+	}
+	return storage, hash, nil
+}
+
+// auth returns the authentication for the repository. The token is only used over http(s).
+// Other transports like ssh use their default authentication.
+func (g *GitLoader) auth(ep *transport.Endpoint) transport.AuthMethod {
+	if g.cfg.Git.Token == "" || (ep.Protocol != "http" && ep.Protocol != "https") {
+		return nil
+	}
+	username := g.cfg.Git.Username
+	if username == "" {
+		username = defaultGitUsername
+	}
+	return &githttp.BasicAuth{Username: username, Password: g.cfg.Git.Token}
+}
+
+// resolveRef resolves the ref like git does for a short name, preferring tags over branches,
+// and returns the full name of the ref and the hash it points to
+func resolveRef(ref string, lookup func(plumbing.ReferenceName) (*plumbing.Reference, error)) (plumbing.ReferenceName, plumbing.Hash, error) {
+	names := []plumbing.ReferenceName{plumbing.ReferenceName(ref)}
+	if ref != defaultGitRef && !strings.HasPrefix(ref, "refs/") {
+		names = []plumbing.ReferenceName{plumbing.NewTagReferenceName(ref), plumbing.NewBranchReferenceName(ref)}
+	}
+
+	for _, name := range names {
+		r, err := lookup(name)
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			continue
+		}
+		if err != nil {
+			return "", plumbing.ZeroHash, err
+		}
+		if r.Type() == plumbing.SymbolicReference {
+			if r, err = lookup(r.Target()); err != nil {
+				return "", plumbing.ZeroHash, fmt.Errorf("failed to resolve %s: %w", name, err)
+			}
+		}
+		return r.Name(), r.Hash(), nil
+	}
+	return "", plumbing.ZeroHash, fmt.Errorf("ref %q not found", ref)
+}
+
+// peel returns the commit of the hash, which is either a commit or an annotated tag
+func peel(objects storer.EncodedObjectStorer, hash plumbing.Hash) (*object.Commit, error) {
+	obj, err := object.GetObject(objects, hash)
+	if err != nil {
+		return nil, err
+	}
+	switch o := obj.(type) {
+	case *object.Commit:
+		return o, nil
+	case *object.Tag:
+		return o.Commit()
+	default:
+		return nil, fmt.Errorf("%s is not a commit", hash)
+	}
+}
+
+// readFile reads the file at the path of the commit
+func readFile(c *object.Commit, path string) ([]byte, error) {
+	f, err := c.File(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if f.Size > maxGitConfigSize {
+		return nil, fmt.Errorf("%s exceeds %d bytes", path, maxGitConfigSize)
+	}
+	r, err := f.Reader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer r.Close() // #nosec G307
+	return io.ReadAll(r)
+}
+
+// ref returns the ref to fetch
+func (g *GitLoader) ref() string {
+	if g.cfg.Git.Ref != "" {
+		return g.cfg.Git.Ref
+	}
+	return defaultGitRef
+}
+
+// Shutdown stops the loader
+func (g *GitLoader) Shutdown(ctx context.Context) {
+	log := logger.FromContext(ctx)
+	select {
+	case g.done <- struct{}{}:
+		log.Debug("Sending signal to shut down git loader")
+	default:
+	}
+}
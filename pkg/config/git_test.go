@@ -0,0 +1,228 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/cgi"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telekom/sparrow/pkg/checks"
+	"github.com/telekom/sparrow/pkg/checks/health"
+	"github.com/telekom/sparrow/pkg/checks/runtime"
+)
+
+// newGitRepo creates a git repository with an initial commit of the given runtime configuration
+func newGitRepo(t *testing.T, config string) (dir string, repo *git.Repository) {
+	t.Helper()
+	dir = t.TempDir()
+	repo, err := git.PlainInitWithOptions(dir, &git.PlainInitOptions{
+		InitOptions: git.InitOptions{DefaultBranch: plumbing.NewBranchReferenceName("main")},
+	})
+	require.NoError(t, err)
+	commitConfig(t, repo, config)
+	return dir, repo
+}
+
+// commitConfig commits the runtime configuration to the git repository
+func commitConfig(t *testing.T, repo *git.Repository, config string) plumbing.Hash {
+	t.Helper()
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(wt.Filesystem.Root(), "sparrow"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(wt.Filesystem.Root(), "sparrow", "config.yaml"), []byte(config), 0o600))
+	_, err = wt.Add("sparrow/config.yaml")
+	require.NoError(t, err)
+	hash, err := wt.Commit("update config", &git.CommitOptions{
+		Author:            &object.Signature{Name: "sparrow", Email: "sparrow@example.com", When: time.Now()},
+		AllowEmptyCommits: true,
+	})
+	require.NoError(t, err)
+	return hash
+}
+
+func healthConfig(target string) runtime.Config {
+	return runtime.Config{Checks: map[string]checks.Runtime{"health": &health.Config{
+		Targets:  []health.Target{{URL: target}},
+		Interval: time.Second,
+		Timeout:  time.Second,
+	}}}
+}
+
+func TestGitLoader_Run(t *testing.T) {
+	dir, repo := newGitRepo(t, "health:\n  targets: [https://a.example.com]\n  interval: 1s\n  timeout: 1s\n")
+	ctx := context.Background()
+	result := make(chan runtime.Config, 1)
+	g := NewGitLoader(&Config{Loader: LoaderConfig{
+		Type:     loaderGit,
+		Interval: 20 * time.Millisecond,
+		Git:      GitLoaderConfig{Url: dir, Ref: "main", Path: "sparrow/config.yaml"},
+	}}, result)
+
+	errC := make(chan error, 1)
+	go func() {
+		errC <- g.Run(ctx)
+	}()
+
+	select {
+	case cfg := <-result:
+		assert.Equal(t, healthConfig("https://a.example.com"), cfg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("initial configuration not loaded")
+	}
+
+	// unchanged commits are not sent again
+	select {
+	case cfg := <-result:
+		t.Fatalf("unexpected configuration: %v", cfg)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// invalid configurations are not sent
+	commitConfig(t, repo, "health:\n  targets: [https://b.example.com]\n")
+	select {
+	case cfg := <-result:
+		t.Fatalf("unexpected configuration: %v", cfg)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	commitConfig(t, repo, "health:\n  targets: [https://b.example.com]\n  interval: 1s\n  timeout: 1s\n")
+	select {
+	case cfg := <-result:
+		assert.Equal(t, healthConfig("https://b.example.com"), cfg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("changed configuration not loaded")
+	}
+
+	g.Shutdown(ctx)
+	select {
+	case err := <-errC:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("git loader did not stop")
+	}
+}
+
+func TestGitLoader_getRuntimeConfig(t *testing.T) {
+	dir, repo := newGitRepo(t, "health:\n  targets: [https://a.example.com]\n  interval: 1s\n  timeout: 1s\n")
+	head, err := repo.Head()
+	require.NoError(t, err)
+	_, err = repo.CreateTag("v1", head.Hash(), nil)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("v1-annotated", head.Hash(), &git.CreateTagOptions{
+		Tagger:  &object.Signature{Name: "sparrow", Email: "sparrow@example.com", When: time.Now()},
+		Message: "v1",
+	})
+	require.NoError(t, err)
+	commitConfig(t, repo, "health: [")
+
+	tests := []struct {
+		name    string
+		git     GitLoaderConfig
+		want    runtime.Config
+		wantErr bool
+	}{
+		{name: "tag", git: GitLoaderConfig{Url: dir, Ref: "v1", Path: "sparrow/config.yaml"}, want: healthConfig("https://a.example.com")},
+		{name: "annotated tag", git: GitLoaderConfig{Url: dir, Ref: "v1-annotated", Path: "sparrow/config.yaml"}, want: healthConfig("https://a.example.com")},
+		{name: "full ref", git: GitLoaderConfig{Url: "file://" + dir, Ref: "refs/tags/v1", Path: "sparrow/config.yaml"}, want: healthConfig("https://a.example.com")},
+		{name: "leading slash", git: GitLoaderConfig{Url: dir, Ref: "v1", Path: "/sparrow/config.yaml", Timeout: time.Minute}, want: healthConfig("https://a.example.com")},
+		{name: "invalid config", git: GitLoaderConfig{Url: dir, Path: "sparrow/config.yaml"}, wantErr: true},
+		{name: "unknown ref", git: GitLoaderConfig{Url: dir, Ref: "unknown", Path: "sparrow/config.yaml"}, wantErr: true},
+		{name: "unknown path", git: GitLoaderConfig{Url: dir, Ref: "v1", Path: "config.yaml"}, wantErr: true},
+		{name: "unknown repository", git: GitLoaderConfig{Url: filepath.Join(dir, "unknown"), Path: "sparrow/config.yaml"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGitLoader(&Config{Loader: LoaderConfig{Git: tt.git}}, nil)
+			got, commit, err := g.getRuntimeConfig(t.Context())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getRuntimeConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			assert.NotEmpty(t, commit)
+			assert.Equal(t, tt.want, got)
+
+			g.commit = commit
+			_, commit, err = g.getRuntimeConfig(t.Context())
+			require.NoError(t, err)
+			assert.Empty(t, commit, "the commit of the last sent configuration should not be loaded again")
+		})
+	}
+}
+
+func TestGitLoader_token(t *testing.T) {
+	auth := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case auth <- r.Header.Get("Authorization"):
+		default:
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	g := NewGitLoader(&Config{Loader: LoaderConfig{Git: GitLoaderConfig{Url: srv.URL + "/sparrow.git", Path: "config.yaml", Token: "secret"}}}, nil)
+	_, _, err := g.getRuntimeConfig(t.Context())
+	assert.Error(t, err)
+	assert.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte("oauth2:secret")), <-auth)
+}
+
+func TestGitLoader_remote(t *testing.T) {
+	bin, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git is not installed")
+	}
+	dir, repo := newGitRepo(t, "health:\n  targets: [https://a.example.com]\n  interval: 1s\n  timeout: 1s\n")
+	srv := httptest.NewServer(&cgi.Handler{
+		Path: bin,
+		Args: []string{"http-backend"},
+		Env:  []string{"GIT_PROJECT_ROOT=" + dir, "GIT_HTTP_EXPORT_ALL=1"},
+	})
+	defer srv.Close()
+
+	head, err := repo.Head()
+	require.NoError(t, err)
+	_, err = repo.CreateTag("v1", head.Hash(), &git.CreateTagOptions{
+		Tagger:  &object.Signature{Name: "sparrow", Email: "sparrow@example.com", When: time.Now()},
+		Message: "v1",
+	})
+	require.NoError(t, err)
+
+	tag := NewGitLoader(&Config{Loader: LoaderConfig{Git: GitLoaderConfig{Url: srv.URL + "/.git", Ref: "v1", Path: "sparrow/config.yaml"}}}, nil)
+	got, commit, err := tag.getRuntimeConfig(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, head.Hash().String(), commit)
+	assert.Equal(t, healthConfig("https://a.example.com"), got)
+
+	tag.commit = commit
+	_, commit, err = tag.getRuntimeConfig(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, commit, "the commit of the last sent configuration should not be fetched again")
+
+	g := NewGitLoader(&Config{Loader: LoaderConfig{Git: GitLoaderConfig{Url: srv.URL + "/.git", Path: "sparrow/config.yaml"}}}, nil)
+	_, commit, err = g.getRuntimeConfig(t.Context())
+	require.NoError(t, err)
+	g.commit = commit
+
+	want := commitConfig(t, repo, "health:\n  targets: [https://b.example.com]\n  interval: 1s\n  timeout: 1s\n")
+	got, commit, err = g.getRuntimeConfig(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, want.String(), commit)
+	assert.Equal(t, healthConfig("https://b.example.com"), got)
+}
@@ -14,7 +14,9 @@ import (
 
 	"github.com/telekom/sparrow/internal/helper"
 	"github.com/telekom/sparrow/internal/logger"
+	"github.com/telekom/sparrow/pkg/checks/exec"
 	"github.com/telekom/sparrow/pkg/checks/runtime"
+	"github.com/telekom/sparrow/pkg/sparrow/metrics"
 	"gopkg.in/yaml.v3"
 )
 
@@ -23,9 +25,22 @@ type HttpLoader struct {
 	cRuntime chan<- runtime.Config
 	done     chan struct{}
 	client   *http.Client
+	metrics  *loaderMetrics
+	cache    *configCache
+	// exec restricts the exec checks of the remote runtime configuration
+	exec exec.Policy
+	// etag is the entity tag of the last loaded configuration
+	etag string
+	// lastModified is the modification time of the last loaded configuration
+	lastModified string
+	// last is the fingerprint of the last sent runtime configuration
+	last string
 }
 
-func NewHttpLoader(cfg *Config, cRuntime chan<- runtime.Config) *HttpLoader {
+func NewHttpLoader(cfg *Config, cRuntime chan<- runtime.Config, mp metrics.Provider) *HttpLoader {
+	m := newLoaderMetrics(loaderHTTP)
+	m.register(mp, cfg.Loader.Name)
+
 	return &HttpLoader{
 		cfg:      cfg.Loader,
 		cRuntime: cRuntime,
@@ -33,11 +48,18 @@ func NewHttpLoader(cfg *Config, cRuntime chan<- runtime.Config) *HttpLoader {
 		client: &http.Client{
 			Timeout: cfg.Loader.Http.Timeout,
 		},
+		metrics: m,
+		cache:   newLoaderCache(cfg),
+		exec:    cfg.Exec,
 	}
 }
 
 // Run gets the runtime configuration from the remote file of the configured http endpoint.
 // The config will be loaded periodically defined by the loader interval configuration.
+// Conditional requests are used to only download the config if it changed, and
+// the config is only sent again if it differs from the last sent configuration.
+// If the configuration can't be loaded or is invalid on startup, the cached configuration is used instead.
+// Afterwards, invalid configurations and empty configurations replacing a cached configuration with checks are ignored.
 // If the interval is 0, the configuration is only fetched once and the loader is disabled.
 func (h *HttpLoader) Run(ctx context.Context) error {
 	ctx, cancel := logger.NewContextWithLogger(ctx)
@@ -45,8 +67,9 @@ func (h *HttpLoader) Run(ctx context.Context) error {
 	log := logger.FromContext(ctx)
 
 	var cfg runtime.Config
+	var modified bool
 	getConfigRetry := helper.Retry(func(ctx context.Context) (err error) {
-		cfg, err = h.getRuntimeConfig(ctx)
+		cfg, modified, err = h.getRuntimeConfig(ctx)
 		return err
 	}, h.cfg.Http.RetryCfg)
 
@@ -55,7 +78,13 @@ func (h *HttpLoader) Run(ctx context.Context) error {
 	if err != nil {
 		log.Warn("Could not get remote runtime configuration", "error", err)
 		err = fmt.Errorf("could not get remote runtime configuration: %w", err)
+		h.metrics.failures.Inc()
+	} else {
+		h.metrics.lastSuccess.SetToCurrentTime()
+		h.metrics.lastChange.SetToCurrentTime()
 	}
+	cfg = h.cache.initial(ctx, cfg, err)
+	h.last = fingerprint(cfg)
 	h.cRuntime <- cfg
 
 	if h.cfg.Interval == 0 {
@@ -76,33 +105,68 @@ func (h *HttpLoader) Run(ctx context.Context) error {
 		case <-tick.C:
 			if err := getConfigRetry(ctx); err != nil {
 				log.Warn("Could not get remote runtime configuration", "error", err)
+				h.metrics.failures.Inc()
+				tick.Reset(h.cfg.Interval)
+				continue
+			}
+			if !modified {
+				h.metrics.lastSuccess.SetToCurrentTime()
+				tick.Reset(h.cfg.Interval)
+				continue
+			}
+			if err := h.cache.validate(cfg); err != nil {
+				log.Warn("Remote runtime configuration is invalid", "error", err)
+				h.metrics.failures.Inc()
+				tick.Reset(h.cfg.Interval)
+				continue
+			}
+			h.metrics.lastSuccess.SetToCurrentTime()
+
+			fp := fingerprint(cfg)
+			if fp != "" && fp == h.last {
+				log.Debug("Remote runtime configuration unchanged")
+				tick.Reset(h.cfg.Interval)
+				continue
+			}
+			if err := h.cache.store(ctx, cfg); errors.Is(err, errEmptyConfig) {
+				log.Warn("Ignoring empty remote runtime configuration, since the cached configuration has checks")
 				tick.Reset(h.cfg.Interval)
 				continue
 			}
 
 			log.Info("Successfully got remote runtime configuration")
+			h.last = fp
+			h.metrics.lastChange.SetToCurrentTime()
 			h.cRuntime <- cfg
 			tick.Reset(h.cfg.Interval)
 		}
 	}
 }
 
-// GetRuntimeConfig gets the remote runtime configuration
-func (hl *HttpLoader) getRuntimeConfig(ctx context.Context) (cfg runtime.Config, err error) {
+// getRuntimeConfig gets the remote runtime configuration.
+// The request is conditional on the entity tag and modification time of the last loaded configuration.
+// Returns false if the server answered that the configuration was not modified.
+func (hl *HttpLoader) getRuntimeConfig(ctx context.Context) (cfg runtime.Config, modified bool, err error) {
 	log := logger.FromContext(ctx).With("url", hl.cfg.Http.Url)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, hl.cfg.Http.Url, http.NoBody)
 	if err != nil {
 		log.Error("Could not create http GET request", "error", err.Error())
-		return cfg, err
+		return cfg, false, err
 	}
 	if hl.cfg.Http.Token != "" {
 		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", hl.cfg.Http.Token))
 	}
+	if hl.etag != "" {
+		req.Header.Set("If-None-Match", hl.etag)
+	}
+	if hl.lastModified != "" {
+		req.Header.Set("If-Modified-Since", hl.lastModified)
+	}
 
 	res, err := hl.client.Do(req) //nolint:bodyclose // Closed in defer below
 	if err != nil {
 		log.Error("Http get request failed", "error", err.Error())
-		return cfg, err
+		return cfg, false, err
 	}
 	defer func(Body io.ReadCloser) {
 		cErr := Body.Close()
@@ -112,24 +176,34 @@ func (hl *HttpLoader) getRuntimeConfig(ctx context.Context) (cfg runtime.Config,
 		}
 	}(res.Body)
 
+	if res.StatusCode == http.StatusNotModified {
+		log.Debug("Remote runtime configuration not modified")
+		return cfg, false, nil
+	}
 	if res.StatusCode != http.StatusOK {
 		log.Error("Http get request failed", "status", res.Status)
-		return cfg, fmt.Errorf("request failed, status is %s", res.Status)
+		return cfg, false, fmt.Errorf("request failed, status is %s", res.Status)
 	}
 
 	b, err := io.ReadAll(res.Body)
 	if err != nil {
 		log.Error("Could not read response body", "error", err.Error())
-		return cfg, err
+		return cfg, false, err
 	}
 	log.Debug("Successfully got response")
 
 	if err := yaml.Unmarshal(b, &cfg); err != nil {
 		log.Error("Could not unmarshal response", "error", err.Error())
-		return cfg, err
+		return cfg, false, err
+	}
+	if err := cfg.AllowsExec(hl.exec); err != nil {
+		log.Error("Remote runtime configuration contains exec checks which are not allowed", "error", err)
+		return runtime.Config{}, false, err
 	}
 
-	return cfg, nil
+	hl.etag = res.Header.Get("ETag")
+	hl.lastModified = res.Header.Get("Last-Modified")
+	return cfg, true, nil
 }
 
 // Shutdown stops the loader
@@ -9,18 +9,25 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"reflect"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/jarcoal/httpmock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/telekom/sparrow/internal/helper"
 	"github.com/telekom/sparrow/internal/logger"
+	"github.com/telekom/sparrow/pkg/checks"
+	"github.com/telekom/sparrow/pkg/checks/exec"
 	"github.com/telekom/sparrow/pkg/checks/health"
 	"github.com/telekom/sparrow/pkg/checks/runtime"
+	"github.com/telekom/sparrow/pkg/sparrow/metrics"
 	"gopkg.in/yaml.v3"
 )
 
@@ -51,13 +58,13 @@ func TestHttpLoader_GetRuntimeConfig(t *testing.T) {
 				statusCode: 200,
 				response:   httpmock.File("test/data/config.yaml").String(),
 			},
-			want: runtime.Config{
-				Health: &health.Config{
-					Targets:  []string{"http://localhost:8080/health"},
+			want: runtime.Config{Checks: map[string]checks.Runtime{
+				"health": &health.Config{
+					Targets:  []health.Target{{URL: "http://localhost:8080/health"}},
 					Interval: 1 * time.Second,
 					Timeout:  1 * time.Second,
 				},
-			},
+			}},
 		},
 		{
 			name: "Get runtime configuration with auth",
@@ -74,13 +81,48 @@ func TestHttpLoader_GetRuntimeConfig(t *testing.T) {
 				statusCode: 200,
 				response:   httpmock.File("test/data/config.yaml").String(),
 			},
-			want: runtime.Config{
-				Health: &health.Config{
-					Targets:  []string{"http://localhost:8080/health"},
+			want: runtime.Config{Checks: map[string]checks.Runtime{
+				"health": &health.Config{
+					Targets:  []health.Target{{URL: "http://localhost:8080/health"}},
 					Interval: 1 * time.Second,
 					Timeout:  1 * time.Second,
 				},
+			}},
+		},
+		{
+			name: "Get runtime configuration with allowed exec check",
+			cfg: &Config{
+				Loader: LoaderConfig{
+					Type:     loaderHTTP,
+					Interval: time.Second,
+				},
+				Exec: exec.Policy{Enabled: true, Commands: []exec.AllowedCommand{{Command: "true"}}},
+			},
+			httpResponder: httpResponder{
+				statusCode: 200,
+				response:   "exec:\n  targets:\n    - name: probe\n      command: \"true\"\n  interval: 1s\n  timeout: 1s\n",
+			},
+			want: runtime.Config{Checks: map[string]checks.Runtime{
+				"exec": &exec.Config{
+					Targets:  []exec.Target{{Name: "probe", Command: "true"}},
+					Interval: time.Second,
+					Timeout:  time.Second,
+				},
+			}},
+		},
+		{
+			name: "Get runtime configuration with exec check not enabled",
+			cfg: &Config{
+				Loader: LoaderConfig{
+					Type:     loaderHTTP,
+					Interval: time.Second,
+				},
 			},
+			httpResponder: httpResponder{
+				statusCode: 200,
+				response:   "exec:\n  targets:\n    - name: probe\n      command: \"true\"\n  interval: 1s\n  timeout: 1s\n",
+			},
+			wantErr: true,
 		},
 		{
 			name: "Get runtime configuration with statuscode 400",
@@ -123,10 +165,11 @@ func TestHttpLoader_GetRuntimeConfig(t *testing.T) {
 				client: &http.Client{
 					Timeout: tt.cfg.Loader.Http.Timeout,
 				},
+				exec: tt.cfg.Exec,
 			}
 			gl.cfg.Http.Url = endpoint
 
-			got, err := gl.getRuntimeConfig(ctx)
+			got, _, err := gl.getRuntimeConfig(ctx)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("HttpLoader.GetRuntimeConfig() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -168,23 +211,23 @@ func TestHttpLoader_Run(t *testing.T) {
 		{
 			name:     "config with health check",
 			interval: 500 * time.Millisecond,
-			response: runtime.Config{
-				Health: &health.Config{
-					Targets:  []string{"http://localhost:8080/health"},
+			response: runtime.Config{Checks: map[string]checks.Runtime{
+				"health": &health.Config{
+					Targets:  []health.Target{{URL: "http://localhost:8080/health"}},
 					Interval: 1 * time.Second,
 				},
-			},
+			}},
 			code: http.StatusOK,
 		},
 		{
 			name:     "continuous loading disabled",
 			interval: 0,
-			response: runtime.Config{
-				Health: &health.Config{
-					Targets:  []string{"http://localhost:8080/health"},
+			response: runtime.Config{Checks: map[string]checks.Runtime{
+				"health": &health.Config{
+					Targets:  []health.Target{{URL: "http://localhost:8080/health"}},
 					Interval: 1 * time.Second,
 				},
-			},
+			}},
 			code:    http.StatusOK,
 			wantErr: false,
 		},
@@ -215,7 +258,8 @@ func TestHttpLoader_Run(t *testing.T) {
 				client: &http.Client{
 					Transport: http.DefaultTransport,
 				},
-				done: make(chan struct{}, 1),
+				done:    make(chan struct{}, 1),
+				metrics: newLoaderMetrics(loaderHTTP),
 			}
 
 			// shutdown routine
@@ -273,12 +317,13 @@ func TestHttpLoader_Run_config_sent_to_channel(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
 
-	expected := runtime.Config{
-		Health: &health.Config{
-			Targets:  []string{"http://localhost:8080/health"},
+	expected := runtime.Config{Checks: map[string]checks.Runtime{
+		"health": &health.Config{
+			Targets:  []health.Target{{URL: "http://localhost:8080/health"}},
 			Interval: 1 * time.Second,
+			Timeout:  1 * time.Second,
 		},
-	}
+	}}
 	body, err := yaml.Marshal(expected)
 	if err != nil {
 		t.Fatalf("Failed marshaling yaml: %v", err)
@@ -304,7 +349,8 @@ func TestHttpLoader_Run_config_sent_to_channel(t *testing.T) {
 		client: &http.Client{
 			Transport: http.DefaultTransport,
 		},
-		done: make(chan struct{}, 1),
+		done:    make(chan struct{}, 1),
+		metrics: newLoaderMetrics(loaderHTTP),
 	}
 
 	ctx := context.Background()
@@ -360,7 +406,8 @@ func TestHttpLoader_Run_empty_config_sent_to_channel_500(t *testing.T) {
 		client: &http.Client{
 			Transport: http.DefaultTransport,
 		},
-		done: make(chan struct{}, 1),
+		done:    make(chan struct{}, 1),
+		metrics: newLoaderMetrics(loaderHTTP),
 	}
 
 	ctx := context.Background()
@@ -372,7 +419,7 @@ func TestHttpLoader_Run_empty_config_sent_to_channel_500(t *testing.T) {
 	}()
 
 	cfg := <-cRuntime
-	if cfg != (runtime.Config{}) {
+	if !reflect.DeepEqual(cfg, runtime.Config{}) {
 		t.Errorf("Config sent to channel: %v", cfg)
 	}
 
@@ -407,7 +454,8 @@ func TestHttpLoader_Run_empty_config_sent_to_channel_client_error(t *testing.T)
 		client: &http.Client{
 			Transport: http.DefaultTransport,
 		},
-		done: make(chan struct{}, 1),
+		done:    make(chan struct{}, 1),
+		metrics: newLoaderMetrics(loaderHTTP),
 	}
 
 	ctx := context.Background()
@@ -419,9 +467,218 @@ func TestHttpLoader_Run_empty_config_sent_to_channel_client_error(t *testing.T)
 	}()
 
 	cfg := <-cRuntime
-	if cfg != (runtime.Config{}) {
+	if !reflect.DeepEqual(cfg, runtime.Config{}) {
 		t.Errorf("Config sent to channel: %v", cfg)
 	}
 
 	hl.Shutdown(ctx)
 }
+
+// TestHttpLoader_Run_conditional tests that the config is only downloaded and sent if it changed
+func TestHttpLoader_Run_conditional(t *testing.T) {
+	var mu sync.Mutex
+	etag, body := `"v1"`, "health:\n  targets: [https://a.example.com]\n  interval: 1s\n  timeout: 1s\n"
+	lastModified := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC).Format(http.TimeFormat)
+	var downloads, notModified int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Header.Get("If-None-Match") == etag {
+			assert.Equal(t, lastModified, r.Header.Get("If-Modified-Since"))
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		downloads++
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", lastModified)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+	update := func(newEtag, newBody string) {
+		mu.Lock()
+		defer mu.Unlock()
+		etag, body = newEtag, newBody
+	}
+	receive := func(t *testing.T, cRuntime chan runtime.Config) runtime.Config {
+		t.Helper()
+		select {
+		case cfg := <-cRuntime:
+			return cfg
+		case <-time.After(time.Second):
+			t.Fatal("config not sent to channel")
+			return runtime.Config{}
+		}
+	}
+	assertNothing := func(t *testing.T, cRuntime chan runtime.Config) {
+		t.Helper()
+		select {
+		case cfg := <-cRuntime:
+			t.Fatalf("unexpected config sent to channel: %v", cfg)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	cRuntime := make(chan runtime.Config, 1)
+	mp := metrics.New(metrics.Config{})
+	hl := NewHttpLoader(&Config{Loader: LoaderConfig{
+		Type:     loaderHTTP,
+		Interval: 20 * time.Millisecond,
+		Http:     HttpLoaderConfig{Url: srv.URL, Timeout: time.Second},
+	}}, cRuntime, mp)
+	go func() {
+		_ = hl.Run(t.Context())
+	}()
+	defer hl.Shutdown(t.Context())
+
+	assert.Equal(t, []health.Target{{URL: "https://a.example.com"}}, receive(t, cRuntime).For("health").(*health.Config).Targets)
+	assertNothing(t, cRuntime)
+
+	// the same content with another entity tag is downloaded but not sent
+	update(`"v2"`, "health:\n  interval: 1s\n  timeout: 1s\n  targets: [https://a.example.com]\n")
+	assertNothing(t, cRuntime)
+
+	update(`"v3"`, "health:\n  targets: [https://b.example.com]\n  interval: 1s\n  timeout: 1s\n")
+	assert.Equal(t, []health.Target{{URL: "https://b.example.com"}}, receive(t, cRuntime).For("health").(*health.Config).Targets)
+
+	mu.Lock()
+	assert.Equal(t, 3, downloads)
+	assert.Positive(t, notModified)
+	mu.Unlock()
+	assert.Positive(t, testutil.ToFloat64(hl.metrics.lastSuccess))
+	assert.Positive(t, testutil.ToFloat64(hl.metrics.lastChange))
+	assert.Zero(t, testutil.ToFloat64(hl.metrics.failures))
+	count, err := testutil.GatherAndCount(mp.GetRegistry(), "sparrow_loader_failures_total")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+// TestHttpLoader_Run_cache tests if the cached config is sent to the channel
+// when the remote config can't be loaded on startup
+func TestHttpLoader_Run_cache(t *testing.T) {
+	var mu sync.Mutex
+	status := http.StatusOK
+	body := "health:\n  targets: [https://a.example.com]\n  interval: 1s\n  timeout: 1s\n"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	cfg := &Config{Loader: LoaderConfig{
+		Type:      loaderHTTP,
+		CachePath: filepath.Join(t.TempDir(), "cache.yaml"),
+		Http:      HttpLoaderConfig{Url: srv.URL, Timeout: time.Second},
+	}}
+	load := func(t *testing.T) runtime.Config {
+		t.Helper()
+		cRuntime := make(chan runtime.Config, 1)
+		hl := NewHttpLoader(cfg, cRuntime, metrics.New(metrics.Config{}))
+		_ = hl.Run(t.Context())
+		return <-cRuntime
+	}
+	want := []health.Target{{URL: "https://a.example.com"}}
+
+	got := load(t)
+	require.NotNil(t, got.For("health"))
+	assert.Equal(t, want, got.For("health").(*health.Config).Targets)
+
+	// the config server is down
+	mu.Lock()
+	status = http.StatusServiceUnavailable
+	mu.Unlock()
+	got = load(t)
+	require.NotNil(t, got.For("health"))
+	assert.Equal(t, want, got.For("health").(*health.Config).Targets)
+
+	// the config server responds with an invalid config
+	mu.Lock()
+	status, body = http.StatusOK, "health:\n  targets: [https://b.example.com]\n"
+	mu.Unlock()
+	got = load(t)
+	require.NotNil(t, got.For("health"))
+	assert.Equal(t, want, got.For("health").(*health.Config).Targets)
+
+	// the config server responds with an empty config
+	mu.Lock()
+	body = ""
+	mu.Unlock()
+	got = load(t)
+	require.NotNil(t, got.For("health"))
+	assert.Equal(t, want, got.For("health").(*health.Config).Targets)
+}
+
+func TestHttpLoader_Run_ignores_invalid_configs(t *testing.T) {
+	valid := func(target string) string {
+		return "health:\n  targets: [" + target + "]\n  interval: 1s\n  timeout: 1s\n"
+	}
+
+	for name, cachePath := range map[string]string{
+		"cache enabled":  filepath.Join(t.TempDir(), "cache.yaml"),
+		"cache disabled": "",
+	} {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			body := "health:\n  targets: [https://a.example.com]\n"
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				_, _ = w.Write([]byte(body))
+			}))
+			defer srv.Close()
+			serve := func(b string) {
+				mu.Lock()
+				defer mu.Unlock()
+				body = b
+			}
+
+			cRuntime := make(chan runtime.Config, 1)
+			hl := NewHttpLoader(&Config{Loader: LoaderConfig{
+				Type:      loaderHTTP,
+				Interval:  20 * time.Millisecond,
+				CachePath: cachePath,
+				Http:      HttpLoaderConfig{Url: srv.URL, Timeout: time.Second},
+			}}, cRuntime, metrics.New(metrics.Config{}))
+			go func() {
+				_ = hl.Run(t.Context())
+			}()
+			defer hl.Shutdown(t.Context())
+
+			receive := func(t *testing.T) *runtime.Config {
+				t.Helper()
+				select {
+				case cfg := <-cRuntime:
+					return &cfg
+				case <-time.After(200 * time.Millisecond):
+					return nil
+				}
+			}
+
+			got := receive(t)
+			require.NotNil(t, got)
+			assert.Equal(t, runtime.Config{}, *got, "an invalid configuration must not be sent on startup")
+
+			serve(valid("https://a.example.com"))
+			got = receive(t)
+			require.NotNil(t, got)
+			assert.Equal(t, []health.Target{{URL: "https://a.example.com"}}, got.For("health").(*health.Config).Targets)
+
+			serve("health:\n  targets: [https://b.example.com]\n")
+			assert.Nil(t, receive(t), "invalid configurations must not be sent")
+			assert.Positive(t, testutil.ToFloat64(hl.metrics.failures), "invalid configurations are failures")
+
+			serve("")
+			assert.Nil(t, receive(t), "empty configurations must not replace configurations with checks")
+
+			serve(valid("https://a.example.com"))
+			assert.Nil(t, receive(t), "the last sent configuration must not be sent again")
+
+			serve(valid("https://b.example.com"))
+			got = receive(t)
+			require.NotNil(t, got)
+			assert.Equal(t, []health.Target{{URL: "https://b.example.com"}}, got.For("health").(*health.Config).Targets)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/telekom/sparrow/internal/logger"
+	"github.com/telekom/sparrow/pkg/checks/runtime"
+	"github.com/telekom/sparrow/pkg/sparrow/metrics"
+)
+
+var _ Loader = (*LayeredLoader)(nil)
+
+// LayeredLoader runs a loader for every configured layer and
+// merges their runtime configurations in the order of the layers.
+// See [runtime.Merge] for how the configurations are merged.
+// The configurations of the layers may be partial, so only the merged configuration is validated.
+type LayeredLoader struct {
+	cRuntime chan<- runtime.Config
+	done     chan struct{}
+	layers   []layer
+	// last is the fingerprint of the last sent runtime configuration
+	last string
+}
+
+// layer is a single loader of the layered loader
+type layer struct {
+	name     string
+	loader   Loader
+	cRuntime chan runtime.Config
+}
+
+// layerEvent is a runtime configuration sent by a layer or
+// the result of its loader if done is true
+type layerEvent struct {
+	index int
+	cfg   runtime.Config
+	err   error
+	done  bool
+}
+
+func NewLayeredLoader(cfg *Config, cRuntime chan<- runtime.Config, mp metrics.Provider) *LayeredLoader {
+	layers := make([]layer, len(cfg.Loader.Layers))
+	for i, lc := range cfg.Loader.Layers {
+		lc.Name = cfg.Loader.layerName(i)
+		lc.layer = true
+		c := make(chan runtime.Config, 1)
+		layers[i] = layer{
+			name:     lc.Name,
+			loader:   NewLoader(&Config{SparrowName: cfg.SparrowName, Loader: lc, Exec: cfg.Exec}, c, mp),
+			cRuntime: c,
+		}
+	}
+
+	return &LayeredLoader{
+		cRuntime: cRuntime,
+		done:     make(chan struct{}, 1),
+		layers:   layers,
+	}
+}
+
+// Run starts the loaders of all layers and sends the merged runtime configuration
+// once every layer loaded its initial configuration. Afterwards the merged configuration
+// is sent again whenever a layer loads a configuration which changes it.
+// If the loader of a layer fails, its last configuration is kept and the other layers keep running.
+// The errors of the failed layers are returned once all layers stopped.
+func (l *LayeredLoader) Run(ctx context.Context) error {
+	ctx, cancel := logger.NewContextWithLogger(ctx)
+	defer cancel()
+	log := logger.FromContext(ctx)
+
+	lctx, stop := context.WithCancel(ctx)
+	defer stop()
+	events := make(chan layerEvent)
+	for i := range l.layers {
+		l.start(lctx, i, events)
+	}
+
+	configs := make([]runtime.Config, len(l.layers))
+	loaded := make([]bool, len(l.layers))
+	running := len(l.layers)
+	var done <-chan struct{} = l.done
+	ctxDone := ctx.Done()
+	stopped := false
+	var errs []error
+
+	for running > 0 {
+		select {
+		case <-done:
+			log.Info("Layered Loader terminated")
+			done, ctxDone, stopped, errs = nil, nil, true, nil
+			stop()
+		case <-ctxDone:
+			done, ctxDone, stopped, errs = nil, nil, true, []error{ctx.Err()}
+			stop()
+		case ev := <-events:
+			if ev.done {
+				running--
+				if ev.err != nil && !stopped {
+					log.Error("Loader layer failed, keeping its last runtime configuration", "layer", l.layers[ev.index].name, "error", ev.err)
+					errs = append(errs, fmt.Errorf("loader layer %q failed: %w", l.layers[ev.index].name, ev.err))
+				}
+				continue
+			}
+			if stopped {
+				continue
+			}
+
+			configs[ev.index], loaded[ev.index] = ev.cfg, true
+			l.send(ctx, configs, loaded)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// start runs the loader of the layer with the given index and forwards its runtime configurations to the events.
+// The result of the loader is only sent once all of its configurations were forwarded.
+func (l *LayeredLoader) start(ctx context.Context, index int, events chan<- layerEvent) {
+	ly := l.layers[index]
+	ctx = logger.IntoContext(ctx, logger.FromContext(ctx).With("layer", ly.name))
+
+	var err error
+	go func() {
+		err = ly.loader.Run(ctx)
+		close(ly.cRuntime)
+	}()
+	go func() {
+		for cfg := range ly.cRuntime {
+			events <- layerEvent{index: index, cfg: cfg}
+		}
+		events <- layerEvent{index: index, err: err, done: true}
+	}()
+}
+
+// send sends the merged runtime configuration of all layers if every layer
+// loaded its initial configuration and the merged configuration changed
+func (l *LayeredLoader) send(ctx context.Context, configs []runtime.Config, loaded []bool) {
+	log := logger.FromContext(ctx)
+	for i, ok := range loaded {
+		if !ok {
+			log.Debug("Waiting for the initial runtime configuration of the loader layers", "layer", l.layers[i].name)
+			return
+		}
+	}
+
+	cfg := runtime.Merge(configs...)
+	if err := cfg.Validate(); err != nil {
+		log.Warn("Merged runtime configuration of the loader layers is invalid", "error", err)
+		return
+	}
+	fp := fingerprint(cfg)
+	if fp != "" && fp == l.last {
+		log.Debug("Merged runtime configuration unchanged")
+		return
+	}
+
+	log.Info("Merged runtime configuration of the loader layers", "layers", len(l.layers))
+	l.last = fp
+	l.cRuntime <- cfg
+}
+
+// Shutdown stops the loader and all of its layers
+func (l *LayeredLoader) Shutdown(ctx context.Context) {
+	log := logger.FromContext(ctx)
+	select {
+	case l.done <- struct{}{}:
+		log.Debug("Sending signal to shut down layered loader")
+	default:
+	}
+}
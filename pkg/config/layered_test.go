@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telekom/sparrow/pkg/checks"
+	"github.com/telekom/sparrow/pkg/checks/health"
+	"github.com/telekom/sparrow/pkg/checks/runtime"
+	"github.com/telekom/sparrow/pkg/sparrow/metrics"
+)
+
+func TestLayeredLoader_Run(t *testing.T) {
+	receive := func(t *testing.T, cRuntime chan runtime.Config) runtime.Config {
+		t.Helper()
+		select {
+		case cfg := <-cRuntime:
+			return cfg
+		case <-time.After(5 * time.Second):
+			t.Fatal("configuration not loaded")
+			return runtime.Config{}
+		}
+	}
+	write := func(t *testing.T, path, content string) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	t.Run("layers are merged", func(t *testing.T) {
+		dir := t.TempDir()
+		base, overrides := filepath.Join(dir, "base.yaml"), filepath.Join(dir, "overrides.yaml")
+		write(t, base, "health:\n  targets: [https://a.example.com]\n  interval: 1s\n  timeout: 1s\n")
+		write(t, overrides, "health:\n  targets: [https://b.example.com]\n  timeout: 5s\n")
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// the layers only add targets and are not valid on their own
+			_, _ = w.Write([]byte("health:\n  targets: [https://team.example.com]\n"))
+		}))
+		defer srv.Close()
+
+		cRuntime := make(chan runtime.Config, 1)
+		mp := metrics.New(metrics.Config{})
+		l := NewLoader(&Config{Loader: LoaderConfig{Layers: []LoaderConfig{
+			{Type: loaderFile, File: FileLoaderConfig{Path: base}},
+			{Name: "team", Type: loaderHTTP, CachePath: filepath.Join(dir, "team.yaml"), Http: HttpLoaderConfig{Url: srv.URL, Timeout: time.Second}},
+			{Name: "overrides", Type: loaderFile, Interval: time.Hour, File: FileLoaderConfig{Path: overrides}},
+		}}}, cRuntime, mp)
+		require.IsType(t, &LayeredLoader{}, l)
+
+		cErr := make(chan error, 1)
+		go func() {
+			cErr <- l.Run(t.Context())
+		}()
+
+		assert.Equal(t, runtime.Config{Checks: map[string]checks.Runtime{"health": &health.Config{
+			Targets:  []health.Target{{URL: "https://a.example.com"}, {URL: "https://team.example.com"}, {URL: "https://b.example.com"}},
+			Interval: time.Second,
+			Timeout:  5 * time.Second,
+		}}}, receive(t, cRuntime))
+
+		// the layers are reloaded independently
+		time.Sleep(fileWatchDebounce)
+		write(t, overrides, "health:\n  targets: [https://c.example.com]\n")
+		assert.Equal(t, runtime.Config{Checks: map[string]checks.Runtime{"health": &health.Config{
+			Targets:  []health.Target{{URL: "https://a.example.com"}, {URL: "https://team.example.com"}, {URL: "https://c.example.com"}},
+			Interval: time.Second,
+			Timeout:  time.Second,
+		}}}, receive(t, cRuntime))
+
+		count, err := testutil.GatherAndCount(mp.GetRegistry(), "sparrow_loader_failures_total")
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		l.Shutdown(t.Context())
+		select {
+		case err := <-cErr:
+			assert.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("layered loader not shut down")
+		}
+	})
+
+	t.Run("failing layer", func(t *testing.T) {
+		dir := t.TempDir()
+		base := filepath.Join(dir, "base.yaml")
+		write(t, base, "health:\n  targets: [https://a.example.com]\n  interval: 1s\n  timeout: 1s\n")
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		cRuntime := make(chan runtime.Config, 1)
+		l := NewLayeredLoader(&Config{Loader: LoaderConfig{Layers: []LoaderConfig{
+			{Type: loaderFile, Interval: time.Hour, File: FileLoaderConfig{Path: base}},
+			{Name: "overrides", Type: loaderHTTP, Http: HttpLoaderConfig{Url: srv.URL, Timeout: time.Second}},
+		}}}, cRuntime, metrics.New(metrics.Config{}))
+
+		cErr := make(chan error, 1)
+		go func() {
+			cErr <- l.Run(t.Context())
+		}()
+
+		// the configuration of the other layers is still applied
+		assert.Equal(t, healthConfig("https://a.example.com"), receive(t, cRuntime))
+
+		// and the other layers keep running
+		time.Sleep(fileWatchDebounce)
+		write(t, base, "health:\n  targets: [https://b.example.com]\n  interval: 1s\n  timeout: 1s\n")
+		assert.Equal(t, healthConfig("https://b.example.com"), receive(t, cRuntime))
+		select {
+		case err := <-cErr:
+			t.Fatalf("layered loader stopped: %v", err)
+		default:
+		}
+
+		l.Shutdown(t.Context())
+		select {
+		case err := <-cErr:
+			assert.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("layered loader not shut down")
+		}
+	})
+
+	t.Run("failing layers", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		cRuntime := make(chan runtime.Config, 1)
+		l := NewLayeredLoader(&Config{Loader: LoaderConfig{Layers: []LoaderConfig{
+			{Name: "base", Type: loaderHTTP, Http: HttpLoaderConfig{Url: srv.URL, Timeout: time.Second}},
+			{Name: "overrides", Type: loaderHTTP, Http: HttpLoaderConfig{Url: srv.URL, Timeout: time.Second}},
+		}}}, cRuntime, metrics.New(metrics.Config{}))
+
+		// the errors are returned once all layers stopped
+		select {
+		case err := <-runAsync(t, l):
+			assert.ErrorContains(t, err, `loader layer "base" failed`)
+			assert.ErrorContains(t, err, `loader layer "overrides" failed`)
+		case <-time.After(5 * time.Second):
+			t.Fatal("layered loader did not fail")
+		}
+	})
+
+	t.Run("invalid merged configuration", func(t *testing.T) {
+		dir := t.TempDir()
+		base, overrides := filepath.Join(dir, "base.yaml"), filepath.Join(dir, "overrides.yaml")
+		write(t, base, "health:\n  targets: [https://a.example.com]\n  interval: 1s\n")
+		write(t, overrides, "health:\n  targets: [https://b.example.com]\n")
+
+		cRuntime := make(chan runtime.Config, 1)
+		l := NewLayeredLoader(&Config{Loader: LoaderConfig{Layers: []LoaderConfig{
+			{Type: loaderFile, File: FileLoaderConfig{Path: base}},
+			{Type: loaderFile, File: FileLoaderConfig{Path: overrides}},
+		}}}, cRuntime, metrics.New(metrics.Config{}))
+		runAsync(t, l)
+		defer l.Shutdown(t.Context())
+
+		select {
+		case cfg := <-cRuntime:
+			t.Fatalf("invalid configuration sent: %v", cfg)
+		case <-time.After(3 * fileWatchDebounce):
+		}
+
+		// the timeout set by a later layer makes the merged configuration valid
+		write(t, overrides, "health:\n  targets: [https://b.example.com]\n  timeout: 1s\n")
+		assert.Equal(t, runtime.Config{Checks: map[string]checks.Runtime{"health": &health.Config{
+			Targets:  []health.Target{{URL: "https://a.example.com"}, {URL: "https://b.example.com"}},
+			Interval: time.Second,
+			Timeout:  time.Second,
+		}}}, receive(t, cRuntime))
+	})
+}
+
+// runAsync runs the loader in the background and returns the channel its result is sent to
+func runAsync(t *testing.T, l Loader) <-chan error {
+	t.Helper()
+	cErr := make(chan error, 1)
+	go func() {
+		cErr <- l.Run(t.Context())
+	}()
+	return cErr
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 
 	"github.com/telekom/sparrow/pkg/checks/runtime"
+	"github.com/telekom/sparrow/pkg/sparrow/metrics"
 )
 
 //go:generate go tool moq -out loader_moq.go . Loader
@@ -23,10 +24,16 @@ type Loader interface {
 }
 
 // NewLoader Get a new typed runtime configuration loader
-func NewLoader(cfg *Config, cRuntime chan<- runtime.Config) Loader {
+func NewLoader(cfg *Config, cRuntime chan<- runtime.Config, mp metrics.Provider) Loader {
+	if cfg.Loader.HasLayers() {
+		return NewLayeredLoader(cfg, cRuntime, mp)
+	}
+
 	switch cfg.Loader.Type {
 	case loaderHTTP:
-		return NewHttpLoader(cfg, cRuntime)
+		return NewHttpLoader(cfg, cRuntime, mp)
+	case loaderGit:
+		return NewGitLoader(cfg, cRuntime)
 	default:
 		return NewFileLoader(cfg, cRuntime)
 	}
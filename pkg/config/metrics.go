@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/telekom/sparrow/pkg/sparrow/metrics"
+)
+
+// loaderMetrics contains the prometheus metrics of a loader
+type loaderMetrics struct {
+	lastSuccess prometheus.Gauge
+	lastChange  prometheus.Gauge
+	failures    prometheus.Counter
+}
+
+// newLoaderMetrics creates the metrics of the loader with the given type
+func newLoaderMetrics(loader LoaderType) *loaderMetrics {
+	labels := prometheus.Labels{"type": string(loader)}
+	return &loaderMetrics{
+		lastSuccess: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name:        "sparrow_loader_last_success_timestamp_seconds",
+			Help:        "Unix timestamp of the last successful load of the runtime configuration",
+			ConstLabels: labels,
+		}),
+		lastChange: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name:        "sparrow_loader_last_change_timestamp_seconds",
+			Help:        "Unix timestamp of the last load which changed the runtime configuration",
+			ConstLabels: labels,
+		}),
+		failures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name:        "sparrow_loader_failures_total",
+			Help:        "Count of failed loads of the runtime configuration",
+			ConstLabels: labels,
+		}),
+	}
+}
+
+// collectors returns the collectors of the loader metrics
+func (m *loaderMetrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{m.lastSuccess, m.lastChange, m.failures}
+}
+
+// register registers the loader metrics in the registry of the given provider.
+// The metrics of a layer of a layered loader are labelled with the name of the layer.
+func (m *loaderMetrics) register(mp metrics.Provider, layer string) {
+	var reg prometheus.Registerer = mp.GetRegistry()
+	if layer != "" {
+		reg = prometheus.WrapRegistererWith(prometheus.Labels{"layer": layer}, reg)
+	}
+	reg.MustRegister(m.collectors()...)
+}
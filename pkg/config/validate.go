@@ -10,7 +10,9 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"strings"
 
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/telekom/sparrow/internal/logger"
 )
 
@@ -34,6 +36,24 @@ func (c *Config) Validate(ctx context.Context) (err error) {
 		}
 	}
 
+	if c.HasAggregator() {
+		if !c.HasTargetManager() {
+			log.Error("The aggregator needs the target manager to discover its peers")
+			err = errors.Join(err, ErrAggregatorWithoutTargetManager)
+		}
+		if vErr := c.Aggregator.Validate(); vErr != nil {
+			log.Error("The aggregator configuration is invalid")
+			err = errors.Join(err, vErr)
+		}
+	}
+
+	if c.HasNotifier() {
+		if vErr := c.Notifier.Validate(); vErr != nil {
+			log.Error("The notifier configuration is invalid")
+			err = errors.Join(err, vErr)
+		}
+	}
+
 	if c.HasTelemetry() {
 		if vErr := c.Telemetry.Validate(ctx); vErr != nil {
 			log.Error("The telemetry configuration is invalid")
@@ -46,6 +66,11 @@ func (c *Config) Validate(ctx context.Context) (err error) {
 		err = errors.Join(err, vErr)
 	}
 
+	if vErr := c.Db.Validate(); vErr != nil {
+		log.Error("The db configuration is invalid")
+		err = errors.Join(err, vErr)
+	}
+
 	if err != nil {
 		return fmt.Errorf("validation of configuration failed: %w", err)
 	}
@@ -61,6 +86,10 @@ func (c *LoaderConfig) Validate(ctx context.Context) error {
 		return ErrInvalidLoaderInterval
 	}
 
+	if c.HasLayers() {
+		return c.validateLayers(ctx)
+	}
+
 	switch c.Type {
 	case loaderHTTP:
 		if _, err := url.ParseRequestURI(c.Http.Url); err != nil {
@@ -76,6 +105,50 @@ func (c *LoaderConfig) Validate(ctx context.Context) error {
 			log.Error("The loader file path cannot be empty")
 			return ErrInvalidLoaderFilePath
 		}
+	case loaderGit:
+		if c.Git.Url == "" {
+			log.Error("The loader git url cannot be empty")
+			return ErrInvalidLoaderGitURL
+		}
+		if c.Git.Path == "" {
+			log.Error("The loader git path cannot be empty")
+			return ErrInvalidLoaderGitPath
+		}
+		if !validGitRef(c.Git.Ref) {
+			log.Error("The loader git ref is not a valid branch, tag or ref name", "ref", c.Git.Ref)
+			return ErrInvalidLoaderGitRef
+		}
+		if c.Git.Timeout < 0 {
+			log.Error("The loader git timeout should be equal or above 0", "timeout", c.Git.Timeout)
+			return ErrInvalidLoaderGitTimeout
+		}
+	}
+
+	return nil
+}
+
+// validateLayers validates the layers of a layered loader
+func (c *LoaderConfig) validateLayers(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+
+	names := make(map[string]bool, len(c.Layers))
+	for i := range c.Layers {
+		layer := &c.Layers[i]
+		name := c.layerName(i)
+		if names[name] {
+			log.Error("The loader layer names must be unique", "layer", name)
+			return ErrInvalidLoaderLayers
+		}
+		names[name] = true
+
+		if layer.HasLayers() {
+			log.Error("The loader layers cannot have layers", "layer", name)
+			return ErrInvalidLoaderLayers
+		}
+		if err := layer.Validate(ctx); err != nil {
+			log.Error("The loader layer is invalid", "layer", name)
+			return err
+		}
 	}
 
 	return nil
@@ -86,3 +159,18 @@ func isDNSName(s string) bool {
 	re := regexp.MustCompile(`^([a-z0-9]([a-z0-9\-]{0,61}[a-z0-9])?\.)+[a-z]{2,}$`)
 	return re.MatchString(s)
 }
+
+// validGitRef checks if the given string is empty or a valid branch, tag or full ref name
+func validGitRef(ref string) bool {
+	if ref == "" || ref == defaultGitRef {
+		return true
+	}
+	if strings.HasPrefix(ref, "-") {
+		return false
+	}
+	name := plumbing.ReferenceName(ref)
+	if !strings.HasPrefix(ref, "refs/") {
+		name = plumbing.NewBranchReferenceName(ref)
+	}
+	return name.Validate() == nil
+}
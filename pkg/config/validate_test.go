@@ -11,6 +11,8 @@ import (
 
 	"github.com/telekom/sparrow/internal/helper"
 	"github.com/telekom/sparrow/pkg/api"
+	"github.com/telekom/sparrow/pkg/db"
+	"github.com/telekom/sparrow/pkg/sparrow/aggregator"
 )
 
 func TestConfig_Validate(t *testing.T) {
@@ -145,6 +147,167 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "db - file path missing",
+			config: Config{
+				Api: api.Config{
+					ListeningAddress: ":8080",
+				},
+				SparrowName: "sparrow.com",
+				Loader: LoaderConfig{
+					Type:     loaderFile,
+					File:     FileLoaderConfig{Path: "config.yaml"},
+					Interval: time.Second,
+				},
+				Db: db.Config{
+					Type: db.TypeFile,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "loader - git path missing",
+			config: Config{
+				Api: api.Config{
+					ListeningAddress: ":8080",
+				},
+				SparrowName: "sparrow.com",
+				Loader: LoaderConfig{
+					Type:     loaderGit,
+					Git:      GitLoaderConfig{Url: "https://gitlab.com/sparrow/config.git"},
+					Interval: time.Second,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "loader - git ref is an option",
+			config: Config{
+				Api: api.Config{
+					ListeningAddress: ":8080",
+				},
+				SparrowName: "sparrow.com",
+				Loader: LoaderConfig{
+					Type:     loaderGit,
+					Git:      GitLoaderConfig{Url: "https://gitlab.com/sparrow/config.git", Ref: "--upload-pack=touch /tmp/pwned", Path: "config.yaml"},
+					Interval: time.Second,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "loader - git ref invalid",
+			config: Config{
+				Api: api.Config{
+					ListeningAddress: ":8080",
+				},
+				SparrowName: "sparrow.com",
+				Loader: LoaderConfig{
+					Type:     loaderGit,
+					Git:      GitLoaderConfig{Url: "https://gitlab.com/sparrow/config.git", Ref: "main..dev", Path: "config.yaml"},
+					Interval: time.Second,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "loader - git ref valid",
+			config: Config{
+				Api: api.Config{
+					ListeningAddress: ":8080",
+				},
+				SparrowName: "sparrow.com",
+				Loader: LoaderConfig{
+					Type:     loaderGit,
+					Git:      GitLoaderConfig{Url: "https://gitlab.com/sparrow/config.git", Ref: "release/v1", Path: "config.yaml"},
+					Interval: time.Second,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "loader - valid layers",
+			config: Config{
+				Api: api.Config{
+					ListeningAddress: ":8080",
+				},
+				SparrowName: "sparrow.com",
+				Loader: LoaderConfig{
+					Type: loaderHTTP,
+					Layers: []LoaderConfig{
+						{Type: loaderFile, File: FileLoaderConfig{Path: "base.yaml"}},
+						{Name: "team", Type: loaderHTTP, Http: HttpLoaderConfig{Url: "https://config.example.com/team.yaml"}, Interval: time.Minute},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "loader - invalid layer",
+			config: Config{
+				Api: api.Config{
+					ListeningAddress: ":8080",
+				},
+				SparrowName: "sparrow.com",
+				Loader: LoaderConfig{
+					Layers: []LoaderConfig{
+						{Type: loaderFile, File: FileLoaderConfig{Path: "base.yaml"}},
+						{Type: loaderHTTP},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "loader - duplicate layer names",
+			config: Config{
+				Api: api.Config{
+					ListeningAddress: ":8080",
+				},
+				SparrowName: "sparrow.com",
+				Loader: LoaderConfig{
+					Layers: []LoaderConfig{
+						{Type: loaderFile, File: FileLoaderConfig{Path: "base.yaml"}},
+						{Name: "0", Type: loaderFile, File: FileLoaderConfig{Path: "overrides.yaml"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "loader - nested layers",
+			config: Config{
+				Api: api.Config{
+					ListeningAddress: ":8080",
+				},
+				SparrowName: "sparrow.com",
+				Loader: LoaderConfig{
+					Layers: []LoaderConfig{
+						{Layers: []LoaderConfig{{Type: loaderFile, File: FileLoaderConfig{Path: "base.yaml"}}}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "aggregator - target manager missing",
+			config: Config{
+				Api: api.Config{
+					ListeningAddress: ":8080",
+				},
+				SparrowName: "sparrow.com",
+				Loader: LoaderConfig{
+					Type:     loaderFile,
+					File:     FileLoaderConfig{Path: "config.yaml"},
+					Interval: time.Second,
+				},
+				Aggregator: aggregator.Config{
+					Enabled: true,
+					Checks:  []string{"health"},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
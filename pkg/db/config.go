@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultHistorySize is the number of results kept per check if no history size is configured
+	DefaultHistorySize = 100
+	// DefaultCompactionInterval is the interval in which the file database is compacted if no interval is configured
+	DefaultCompactionInterval = time.Hour
+)
+
+var (
+	// ErrInvalidHistorySize is returned if the configured history size is negative
+	ErrInvalidHistorySize = errors.New("the history size must not be negative")
+	// ErrInvalidType is returned if the configured database type is unknown
+	ErrInvalidType = errors.New("the database type must be one of: memory, file")
+	// ErrInvalidFilePath is returned if the file database has no path
+	ErrInvalidFilePath = errors.New("the file database path must not be empty")
+	// ErrInvalidRetention is returned if the configured retention is negative
+	ErrInvalidRetention = errors.New("the file database retention must not be negative")
+	// ErrInvalidCompactionInterval is returned if the configured compaction interval is negative
+	ErrInvalidCompactionInterval = errors.New("the file database compaction interval must not be negative")
+)
+
+// Type is the type of the result database
+type Type string
+
+const (
+	// TypeMemory keeps the results in memory only
+	TypeMemory Type = "memory"
+	// TypeFile persists the results in a file, so they survive restarts
+	TypeFile Type = "file"
+)
+
+// Config is the configuration of the result database
+type Config struct {
+	// Type is the type of the database. Defaults to TypeMemory
+	Type Type `yaml:"type" mapstructure:"type"`
+	// HistorySize is the number of results kept per check.
+	// Defaults to DefaultHistorySize
+	HistorySize int `yaml:"historySize" mapstructure:"historySize"`
+	// File is the configuration of the file database
+	File FileConfig `yaml:"file" mapstructure:"file"`
+}
+
+// FileConfig is the configuration of the file database
+type FileConfig struct {
+	// Path is the path of the file the results are stored in
+	Path string `yaml:"path" mapstructure:"path"`
+	// Retention is the maximum age of stored results.
+	// Older results are dropped when the database is loaded or compacted.
+	// A retention of 0 keeps all results within the history size
+	Retention time.Duration `yaml:"retention" mapstructure:"retention"`
+	// CompactionInterval is the interval in which the file is rewritten to only
+	// contain the results kept in the history. Defaults to DefaultCompactionInterval
+	CompactionInterval time.Duration `yaml:"compactionInterval" mapstructure:"compactionInterval"`
+}
+
+// Validate validates the database configuration
+func (c *Config) Validate() error {
+	if c.HistorySize < 0 {
+		return ErrInvalidHistorySize
+	}
+
+	switch c.Type {
+	case "", TypeMemory:
+	case TypeFile:
+		if c.File.Path == "" {
+			return ErrInvalidFilePath
+		}
+		if c.File.Retention < 0 {
+			return ErrInvalidRetention
+		}
+		if c.File.CompactionInterval < 0 {
+			return ErrInvalidCompactionInterval
+		}
+	default:
+		return ErrInvalidType
+	}
+	return nil
+}
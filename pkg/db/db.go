@@ -6,6 +6,7 @@ package db
 
 import (
 	"sync"
+	"time"
 
 	"github.com/telekom/sparrow/pkg/checks"
 )
@@ -14,27 +15,50 @@ type DB interface {
 	Save(result checks.ResultDTO)
 	Get(check string) (result checks.Result, ok bool)
 	List() map[string]checks.Result
+	// History returns the saved results of the check with a timestamp after since
+	// in chronological order. If limit is greater than 0, only the latest limit results are returned.
+	History(check string, since time.Time, limit int) (results []checks.Result, ok bool)
+	// Close releases the resources of the database
+	Close() error
+}
+
+// New creates the database configured in the given config
+func New(cfg Config) (DB, error) {
+	if cfg.Type == TypeFile {
+		return NewFile(cfg.HistorySize, cfg.File)
+	}
+	return NewInMemory(cfg.HistorySize), nil
 }
 
 var _ DB = (*InMemory)(nil)
 
 type InMemory struct {
-	// if we want to save a timeseries
-	// we can use a map of ringbuffers instead of a single value
-	// this ensures that we can save the last N results, where N is the size of the ringbuffer
-	// without having to worry about the size of the map
+	// data holds the latest result of every check
 	data sync.Map
+	// history holds a ring buffer with the last historySize results of every check
+	history sync.Map
+	// historySize is the number of results kept per check
+	historySize int
 }
 
-// NewInMemory creates a new in-memory database
-func NewInMemory() *InMemory {
+// NewInMemory creates a new in-memory database that keeps the last historySize results per check.
+// If historySize is not greater than 0, DefaultHistorySize is used.
+func NewInMemory(historySize int) *InMemory {
+	if historySize <= 0 {
+		historySize = DefaultHistorySize
+	}
 	return &InMemory{
-		data: sync.Map{},
+		data:        sync.Map{},
+		history:     sync.Map{},
+		historySize: historySize,
 	}
 }
 
 func (i *InMemory) Save(result checks.ResultDTO) {
 	i.data.Store(result.Name, result.Result)
+
+	buf, _ := i.history.LoadOrStore(result.Name, newRingBuffer(i.historySize))
+	buf.(*ringBuffer).Add(*result.Result)
 }
 
 func (i *InMemory) Get(check string) (checks.Result, bool) {
@@ -62,3 +86,19 @@ func (i *InMemory) List() map[string]checks.Result {
 
 	return results
 }
+
+// History returns the saved results of the check with a timestamp after since
+// in chronological order. If limit is greater than 0, only the latest limit results are returned.
+func (i *InMemory) History(check string, since time.Time, limit int) ([]checks.Result, bool) {
+	buf, ok := i.history.Load(check)
+	if !ok {
+		return nil, false
+	}
+	// this assertion should not fail, unless we have a bug somewhere
+	return buf.(*ringBuffer).List(since, limit), true
+}
+
+// Close does nothing, since the in-memory database holds no resources
+func (i *InMemory) Close() error {
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/telekom/sparrow/pkg/checks"
 )
@@ -28,9 +29,7 @@ func TestInMemory_Save(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			i := &InMemory{
-				data: sync.Map{},
-			}
+			i := NewInMemory(DefaultHistorySize)
 			for k, v := range tt.fields.data {
 				i.data.Store(k, v)
 			}
@@ -51,13 +50,15 @@ func TestInMemory_Save(t *testing.T) {
 func TestNewInMemory(t *testing.T) {
 	tests := []struct {
 		name string
+		size int
 		want *InMemory
 	}{
-		{name: "Creates without nil pointers", want: &InMemory{data: sync.Map{}}},
+		{name: "Creates without nil pointers", size: 10, want: &InMemory{data: sync.Map{}, history: sync.Map{}, historySize: 10}},
+		{name: "Uses default history size", size: 0, want: &InMemory{data: sync.Map{}, history: sync.Map{}, historySize: DefaultHistorySize}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewInMemory(); !reflect.DeepEqual(got, tt.want) {
+			if got := NewInMemory(tt.size); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewInMemory() = %v, want %v", got, tt.want)
 			}
 		})
@@ -158,7 +159,7 @@ func TestInMemory_List(t *testing.T) {
 }
 
 func TestInMemory_ListThreadsafe(t *testing.T) {
-	db := NewInMemory()
+	db := NewInMemory(DefaultHistorySize)
 	db.Save(checks.ResultDTO{Name: "alpha", Result: &checks.Result{Data: 0}})
 	db.Save(checks.ResultDTO{Name: "beta", Result: &checks.Result{Data: 1}})
 
@@ -174,3 +175,44 @@ func TestInMemory_ListThreadsafe(t *testing.T) {
 		t.Errorf("Expected alpha to be 0 but got %d", newGot["alpha"].Data)
 	}
 }
+
+func TestInMemory_History(t *testing.T) {
+	now := time.Now()
+	i := NewInMemory(3)
+	for n := range 5 {
+		i.Save(checks.ResultDTO{Name: "alpha", Result: &checks.Result{Data: n, Timestamp: now.Add(time.Duration(n) * time.Minute)}})
+	}
+
+	tests := []struct {
+		name   string
+		check  string
+		since  time.Time
+		limit  int
+		want   []int
+		wantOk bool
+	}{
+		{name: "keeps only the last results", check: "alpha", want: []int{2, 3, 4}, wantOk: true},
+		{name: "filters by timestamp", check: "alpha", since: now.Add(2 * time.Minute), want: []int{3, 4}, wantOk: true},
+		{name: "limits to the latest results", check: "alpha", limit: 1, want: []int{4}, wantOk: true},
+		{name: "nothing after since", check: "alpha", since: now.Add(time.Hour), want: []int{}, wantOk: true},
+		{name: "unknown check", check: "beta", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := i.History(tt.check, tt.since, tt.limit)
+			if ok != tt.wantOk {
+				t.Fatalf("History() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if !ok {
+				return
+			}
+			data := make([]int, 0, len(got))
+			for _, r := range got {
+				data = append(data, r.Data.(int))
+			}
+			if !reflect.DeepEqual(data, tt.want) {
+				t.Errorf("History() = %v, want %v", data, tt.want)
+			}
+		})
+	}
+}
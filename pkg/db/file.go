@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package db
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/telekom/sparrow/internal/logger"
+	"github.com/telekom/sparrow/pkg/checks"
+)
+
+var _ DB = (*File)(nil)
+
+// filePermissions are the permissions of the database file
+const filePermissions = 0o600
+
+// File is a database that keeps the results in memory and additionally
+// appends them to a file, so they survive restarts of the sparrow.
+// The file is periodically compacted to only contain the results kept in the history.
+type File struct {
+	*InMemory
+	// mu protects the file
+	mu sync.Mutex
+	// file is the file the results are appended to
+	file *os.File
+	// path is the path of the file
+	path string
+	// retention is the maximum age of stored results
+	retention time.Duration
+	// compactionInterval is the interval in which the file is compacted
+	compactionInterval time.Duration
+	// lastCompaction is the time of the last compaction
+	lastCompaction time.Time
+}
+
+// record is a single result as stored in the file
+type record struct {
+	Name   string        `json:"name"`
+	Result checks.Result `json:"result"`
+}
+
+// NewFile creates a new file database that keeps the last historySize results per check.
+// The results already stored in the file are loaded and the file is compacted.
+func NewFile(historySize int, cfg FileConfig) (*File, error) {
+	if cfg.CompactionInterval <= 0 {
+		cfg.CompactionInterval = DefaultCompactionInterval
+	}
+
+	f := &File{
+		InMemory:           NewInMemory(historySize),
+		path:               cfg.Path,
+		retention:          cfg.Retention,
+		compactionInterval: cfg.CompactionInterval,
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create database directory: %w", err)
+	}
+	if err := f.load(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.compact(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Save saves the result in memory and appends it to the file.
+// The file is compacted if the compaction interval has passed.
+func (f *File) Save(result checks.ResultDTO) {
+	log := logger.FromContext(context.Background()).With("check", result.Name)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.InMemory.Save(result)
+	if f.file == nil {
+		log.Error("Failed to persist result", "error", os.ErrClosed)
+		return
+	}
+
+	if time.Since(f.lastCompaction) >= f.compactionInterval {
+		err := f.compact()
+		if err == nil {
+			// The compacted file already contains the result
+			return
+		}
+		// Retry the compaction after the next interval and keep appending to the current file
+		log.Error("Failed to compact database file", "error", err)
+		f.lastCompaction = time.Now()
+	}
+
+	b, err := json.Marshal(record{Name: result.Name, Result: *result.Result})
+	if err != nil {
+		log.Error("Failed to encode result", "error", err)
+		return
+	}
+	if _, err = f.file.Write(append(b, '\n')); err != nil {
+		log.Error("Failed to persist result", "error", err)
+	}
+}
+
+// Close closes the database file
+func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// load reads the results stored in the file into memory.
+// Results older than the retention and incomplete records are skipped.
+func (f *File) load() error {
+	file, err := os.Open(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open database file: %w", err)
+	}
+	defer file.Close() // #nosec G307
+
+	cutoff := f.cutoff()
+	r := bufio.NewReader(file)
+	for {
+		line, rErr := r.ReadBytes('\n')
+		if len(line) > 0 {
+			var rec record
+			if err = json.Unmarshal(line, &rec); err != nil {
+				// Incomplete records can be left behind if the sparrow is killed while writing
+				logger.FromContext(context.Background()).Warn("Skipping invalid record in database file", "error", err)
+			} else if rec.Result.Timestamp.After(cutoff) {
+				f.InMemory.Save(checks.ResultDTO{Name: rec.Name, Result: &rec.Result})
+			}
+		}
+		if errors.Is(rErr, io.EOF) {
+			return nil
+		}
+		if rErr != nil {
+			return fmt.Errorf("failed to read database file: %w", rErr)
+		}
+	}
+}
+
+// compact drops the results older than the retention and rewrites the file
+// to only contain the results kept in memory. The caller must hold f.mu.
+// The compacted file is written next to the file and replaces it once it is complete.
+// If the compaction fails, the current file is kept and results are still appended to it.
+func (f *File) compact() error {
+	cutoff := f.cutoff()
+	tmp := f.path + ".tmp"
+	// The handle of the compacted file is kept open to append the following results,
+	// so the file doesn't need to be reopened after it replaced the current file
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC|os.O_APPEND, filePermissions) // #nosec G304 // the path is configured by the operator
+	if err != nil {
+		return fmt.Errorf("failed to create compacted database file: %w", err)
+	}
+
+	w := bufio.NewWriter(out)
+	enc := json.NewEncoder(w)
+	f.history.Range(func(key, value any) bool {
+		// this assertion should not fail, unless we have a bug somewhere
+		buf := value.(*ringBuffer)
+		buf.Prune(cutoff)
+		for _, res := range buf.List(time.Time{}, 0) {
+			if err = enc.Encode(record{Name: key.(string), Result: res}); err != nil {
+				return false
+			}
+		}
+		return true
+	})
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = out.Sync()
+	}
+	if err == nil {
+		err = os.Rename(tmp, f.path)
+	}
+	if err != nil {
+		_ = out.Close()
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write compacted database file: %w", err)
+	}
+
+	if f.file != nil {
+		_ = f.file.Close()
+	}
+	f.file = out
+	f.lastCompaction = time.Now()
+	return nil
+}
+
+// cutoff returns the time before which results are dropped
+func (f *File) cutoff() time.Time {
+	if f.retention <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(-f.retention)
+}
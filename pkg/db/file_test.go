@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package db
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telekom/sparrow/pkg/checks"
+)
+
+func TestFile_SurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "results.db")
+	now := time.Now().UTC().Truncate(time.Second)
+
+	f, err := NewFile(2, FileConfig{Path: path})
+	require.NoError(t, err)
+	for n := range 3 {
+		f.Save(checks.ResultDTO{Name: "alpha", Result: &checks.Result{Data: float64(n), Timestamp: now.Add(time.Duration(n) * time.Second)}})
+	}
+	f.Save(checks.ResultDTO{Name: "beta", Result: &checks.Result{Data: "up", Timestamp: now}})
+	require.NoError(t, f.Close())
+
+	reopened, err := NewFile(2, FileConfig{Path: path})
+	require.NoError(t, err)
+	defer reopened.Close() //nolint:errcheck // test cleanup
+
+	got, ok := reopened.Get("alpha")
+	require.True(t, ok)
+	assert.Equal(t, checks.Result{Data: float64(2), Timestamp: now.Add(2 * time.Second)}, got)
+
+	history, ok := reopened.History("alpha", time.Time{}, 0)
+	require.True(t, ok)
+	assert.Equal(t, []checks.Result{
+		{Data: float64(1), Timestamp: now.Add(time.Second)},
+		{Data: float64(2), Timestamp: now.Add(2 * time.Second)},
+	}, history)
+
+	got, ok = reopened.Get("beta")
+	require.True(t, ok)
+	assert.Equal(t, "up", got.Data)
+}
+
+func TestFile_Compaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.db")
+	now := time.Now()
+
+	f, err := NewFile(2, FileConfig{Path: path, CompactionInterval: time.Hour})
+	require.NoError(t, err)
+	defer f.Close() //nolint:errcheck // test cleanup
+
+	for n := range 5 {
+		f.Save(checks.ResultDTO{Name: "alpha", Result: &checks.Result{Data: n, Timestamp: now}})
+	}
+	assert.Equal(t, 5, countLines(t, path), "results should be appended until the compaction interval passed")
+
+	f.lastCompaction = now.Add(-2 * time.Hour)
+	f.Save(checks.ResultDTO{Name: "alpha", Result: &checks.Result{Data: 5, Timestamp: now}})
+	assert.Equal(t, 2, countLines(t, path), "compaction should only keep the history")
+}
+
+func TestFile_CompactionFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.db")
+	now := time.Now()
+
+	f, err := NewFile(2, FileConfig{Path: path, CompactionInterval: time.Hour})
+	require.NoError(t, err)
+	defer f.Close() //nolint:errcheck // test cleanup
+	f.Save(checks.ResultDTO{Name: "alpha", Result: &checks.Result{Data: 0, Timestamp: now}})
+
+	// the compacted file can't be created
+	require.NoError(t, os.Mkdir(path+".tmp", 0o750))
+	f.lastCompaction = now.Add(-2 * time.Hour)
+	f.Save(checks.ResultDTO{Name: "alpha", Result: &checks.Result{Data: 1, Timestamp: now}})
+	assert.Equal(t, 2, countLines(t, path), "result should be appended if the compaction failed")
+	assert.WithinDuration(t, time.Now(), f.lastCompaction, time.Second, "compaction should be retried after the interval")
+
+	f.Save(checks.ResultDTO{Name: "alpha", Result: &checks.Result{Data: 2, Timestamp: now}})
+	assert.Equal(t, 3, countLines(t, path), "results should be appended until the compaction is retried")
+
+	require.NoError(t, os.Remove(path+".tmp"))
+	f.lastCompaction = now.Add(-2 * time.Hour)
+	f.Save(checks.ResultDTO{Name: "alpha", Result: &checks.Result{Data: 3, Timestamp: now}})
+	assert.Equal(t, 2, countLines(t, path), "compaction should succeed again")
+
+	f.Save(checks.ResultDTO{Name: "alpha", Result: &checks.Result{Data: 4, Timestamp: now}})
+	assert.Equal(t, 3, countLines(t, path), "results should be appended to the compacted file")
+}
+
+func TestFile_Retention(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.db")
+	now := time.Now()
+	content := `{"name":"alpha","result":{"data":0,"timestamp":"` + now.Add(-2*time.Hour).Format(time.RFC3339Nano) + `"}}
+{"name":"alpha","result":{"data":1,"timestamp":"` + now.Add(-time.Minute).Format(time.RFC3339Nano) + `"}}
+{"name":"alpha","result":{"data":2,"timest`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	f, err := NewFile(10, FileConfig{Path: path, Retention: time.Hour})
+	require.NoError(t, err)
+	defer f.Close() //nolint:errcheck // test cleanup
+
+	history, ok := f.History("alpha", time.Time{}, 0)
+	require.True(t, ok)
+	require.Len(t, history, 1, "results older than the retention and incomplete records should be dropped")
+	assert.Equal(t, float64(1), history[0].Data)
+	assert.Equal(t, 1, countLines(t, path))
+}
+
+func TestNew(t *testing.T) {
+	d, err := New(Config{})
+	require.NoError(t, err)
+	assert.IsType(t, &InMemory{}, d)
+
+	d, err = New(Config{Type: TypeFile, File: FileConfig{Path: filepath.Join(t.TempDir(), "results.db")}})
+	require.NoError(t, err)
+	assert.IsType(t, &File{}, d)
+	assert.NoError(t, d.Close())
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr error
+	}{
+		{name: "default config", config: Config{}},
+		{name: "file config", config: Config{Type: TypeFile, HistorySize: 10, File: FileConfig{Path: "results.db", Retention: time.Hour}}},
+		{name: "negative history size", config: Config{HistorySize: -1}, wantErr: ErrInvalidHistorySize},
+		{name: "unknown type", config: Config{Type: "redis"}, wantErr: ErrInvalidType},
+		{name: "file without path", config: Config{Type: TypeFile}, wantErr: ErrInvalidFilePath},
+		{name: "negative retention", config: Config{Type: TypeFile, File: FileConfig{Path: "results.db", Retention: -time.Hour}}, wantErr: ErrInvalidRetention},
+		{name: "negative compaction interval", config: Config{Type: TypeFile, File: FileConfig{Path: "results.db", CompactionInterval: -time.Hour}}, wantErr: ErrInvalidCompactionInterval},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.config.Validate(), tt.wantErr)
+		})
+	}
+}
+
+// countLines returns the number of lines of the file
+func countLines(t *testing.T, path string) int {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return bytes.Count(b, []byte("\n"))
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package db
+
+import (
+	"sync"
+	"time"
+
+	"github.com/telekom/sparrow/pkg/checks"
+)
+
+// ringBuffer holds the last N results of a check.
+// When the buffer is full, the oldest result is overwritten.
+type ringBuffer struct {
+	mu sync.RWMutex
+	// results holds the results, with the oldest one at index start
+	results []checks.Result
+	// start is the index of the oldest result
+	start int
+	// size is the amount of results in the buffer
+	size int
+}
+
+// newRingBuffer creates a new ring buffer with the given capacity
+func newRingBuffer(capacity int) *ringBuffer {
+	return &ringBuffer{
+		results: make([]checks.Result, capacity),
+	}
+}
+
+// Add adds a result to the buffer, overwriting the oldest result if the buffer is full
+func (r *ringBuffer) Add(result checks.Result) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.size < len(r.results) {
+		r.results[(r.start+r.size)%len(r.results)] = result
+		r.size++
+		return
+	}
+	r.results[r.start] = result
+	r.start = (r.start + 1) % len(r.results)
+}
+
+// List returns the results with a timestamp after since in chronological order.
+// If limit is greater than 0, only the latest limit results are returned.
+func (r *ringBuffer) List(since time.Time, limit int) []checks.Result {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	results := make([]checks.Result, 0, r.size)
+	for i := range r.size {
+		res := r.results[(r.start+i)%len(r.results)]
+		if res.Timestamp.After(since) {
+			results = append(results, res)
+		}
+	}
+
+	if limit > 0 && len(results) > limit {
+		results = results[len(results)-limit:]
+	}
+	return results
+}
+
+// Prune removes all results with a timestamp before cutoff
+func (r *ringBuffer) Prune(cutoff time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for r.size > 0 && r.results[r.start].Timestamp.Before(cutoff) {
+		r.results[r.start] = checks.Result{}
+		r.start = (r.start + 1) % len(r.results)
+		r.size--
+	}
+}
@@ -17,12 +17,12 @@ import (
 
 var (
 	latencyCfg = &latency.Config{
-		Targets:  []string{"http://localhost:8080/health"},
+		Targets:  []latency.Target{{URL: "http://localhost:8080/health"}},
 		Interval: 1 * time.Second,
 		Timeout:  1 * time.Second,
 	}
 	healthCfg = &health.Config{
-		Targets:  []string{"http://localhost:8080/health"},
+		Targets:  []health.Target{{URL: "http://localhost:8080/health"}},
 		Interval: 1 * time.Second,
 		Timeout:  1 * time.Second,
 	}
@@ -42,9 +42,9 @@ func TestNewChecksFromConfig(t *testing.T) {
 		},
 		{
 			name: "healthcheck",
-			cfg: runtime.Config{
-				Health: healthCfg,
-			},
+			cfg: runtime.Config{Checks: map[string]checks.Runtime{
+				"health": healthCfg,
+			}},
 
 			want: map[string]checks.Check{
 				"health": newHealthCheck(),
@@ -52,9 +52,9 @@ func TestNewChecksFromConfig(t *testing.T) {
 		},
 		{
 			name: "latency",
-			cfg: runtime.Config{
-				Latency: latencyCfg,
-			},
+			cfg: runtime.Config{Checks: map[string]checks.Runtime{
+				"latency": latencyCfg,
+			}},
 
 			want: map[string]checks.Check{
 				"latency": newLatencyCheck(),
@@ -62,16 +62,28 @@ func TestNewChecksFromConfig(t *testing.T) {
 		},
 		{
 			name: "multiple checks",
-			cfg: runtime.Config{
-				Health:  healthCfg,
-				Latency: latencyCfg,
-			},
+			cfg: runtime.Config{Checks: map[string]checks.Runtime{
+				"health":  healthCfg,
+				"latency": latencyCfg,
+			}},
 
 			want: map[string]checks.Check{
 				"health":  newHealthCheck(),
 				"latency": newLatencyCheck(),
 			},
 		},
+		{
+			name: "named instances",
+			cfg: runtime.Config{Checks: map[string]checks.Runtime{
+				"latency":          latencyCfg,
+				"latency/internal": latencyCfg,
+			}},
+
+			want: map[string]checks.Check{
+				"latency":          newLatencyCheck(),
+				"latency/internal": checks.NewNamed(newLatencyCheck(), "latency/internal"),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -83,7 +95,13 @@ func TestNewChecksFromConfig(t *testing.T) {
 
 			// assert the configurations of the checks are equal -
 			// the checks themselves cannot be compared because of the done channels
+			if len(got) != len(tt.want) {
+				t.Errorf("NewChecksFromConfig() got %d checks, want %d", len(got), len(tt.want))
+			}
 			for name, check := range got {
+				if check.Name() != name {
+					t.Errorf("NewChecksFromConfig() check name = %v, want %v", check.Name(), name)
+				}
 				if !reflect.DeepEqual(check.GetConfig(), tt.want[name].GetConfig()) {
 					t.Errorf("NewChecksFromConfig() got = %v, want %v", check.GetConfig(), tt.want[name].GetConfig())
 				}
@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/telekom/sparrow/internal/logger"
+	"github.com/telekom/sparrow/pkg/checks"
+	"github.com/telekom/sparrow/pkg/sparrow/targets"
+)
+
+// maxResultSize is the maximum size of a result pulled from a peer
+const maxResultSize = 10 << 20
+
+// Result is the latest result of a check pulled from a peer
+type Result struct {
+	// Result is the latest result of the check on the peer
+	Result *checks.Result `json:"result,omitempty"`
+	// Error is set if the result could not be pulled
+	Error *string `json:"error,omitempty"`
+	// PulledAt is the time the result was pulled
+	PulledAt time.Time `json:"pulledAt"`
+}
+
+// Aggregator periodically pulls the check results of the peer sparrows
+// discovered by the target manager
+type Aggregator struct {
+	// name is the name of this sparrow, which is not pulled
+	name string
+	cfg  Config
+	// peers provides the global targets the results are pulled from
+	peers  targets.TargetManager
+	client *http.Client
+	// mu protects the results
+	mu sync.RWMutex
+	// results are the latest results keyed by check and source
+	results map[string]map[string]Result
+	// done is used to signal the pull routine to stop
+	done chan struct{}
+}
+
+// New creates a new aggregator pulling the results of the peers of the sparrow with the given name
+func New(name string, cfg Config, peers targets.TargetManager) *Aggregator { //nolint:gocritic // no performance concerns yet
+	return &Aggregator{
+		name:  name,
+		cfg:   cfg,
+		peers: peers,
+		client: &http.Client{
+			Timeout: cfg.timeout(),
+			// the token must not be sent in plain text, also not after a redirect
+			CheckRedirect: func(req *http.Request, _ []*http.Request) error {
+				if req.URL.Scheme != "https" {
+					req.Header.Del("Authorization")
+				}
+				return nil
+			},
+		},
+		results: map[string]map[string]Result{},
+		done:    make(chan struct{}, 1),
+	}
+}
+
+// Run periodically pulls the results of the peers until the aggregator is shut down
+func (a *Aggregator) Run(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	log.InfoContext(ctx, "Starting aggregator", "interval", a.cfg.interval().String())
+	for {
+		select {
+		case <-ctx.Done():
+			log.ErrorContext(ctx, "Error while aggregating results", "error", ctx.Err())
+			return ctx.Err()
+		case <-a.done:
+			log.InfoContext(ctx, "Aggregator stopped")
+			return nil
+		case <-timer.C:
+			a.pull(ctx)
+			timer.Reset(a.cfg.interval())
+		}
+	}
+}
+
+// Shutdown stops the aggregator
+func (a *Aggregator) Shutdown() {
+	select {
+	case a.done <- struct{}{}:
+	default:
+	}
+}
+
+// Results returns the latest results of the check pulled from the peers keyed by source
+func (a *Aggregator) Results(check string) map[string]Result {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return maps.Clone(a.results[check])
+}
+
+// pull pulls the results of all checks from all peers
+func (a *Aggregator) pull(ctx context.Context) {
+	log := logger.FromContext(ctx)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[string]map[string]Result, len(a.cfg.Checks))
+	for _, check := range a.cfg.Checks {
+		results[check] = map[string]Result{}
+	}
+
+	for _, gt := range a.peers.GetTargets() {
+		u, err := url.Parse(gt.Url)
+		if err != nil {
+			log.ErrorContext(ctx, "Failed to parse global target URL", "error", err, "url", gt.Url)
+			continue
+		}
+		source := u.Hostname()
+		if source == a.name {
+			continue
+		}
+
+		for _, check := range a.cfg.Checks {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				res := a.fetch(ctx, u, check)
+				if res.Error != nil {
+					log.WarnContext(ctx, "Failed to pull result from peer", "peer", source, "check", check, "error", *res.Error)
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				results[check][source] = res
+			}()
+		}
+	}
+	wg.Wait()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.results = results
+	log.DebugContext(ctx, "Pulled results from peers")
+}
+
+// fetch pulls the latest result of the check from the peer
+func (a *Aggregator) fetch(ctx context.Context, peer *url.URL, check string) Result {
+	res := Result{PulledAt: time.Now()}
+	fail := func(err error) Result {
+		errval := err.Error()
+		res.Error = &errval
+		return res
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, peer.JoinPath("v1", "metrics", check).String(), http.NoBody)
+	if err != nil {
+		return fail(err)
+	}
+	// the token is only sent to peers using https, so it's never sent in plain text
+	if a.cfg.Token != "" && peer.Scheme == "https" {
+		req.Header.Set("Authorization", "Bearer "+a.cfg.Token)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fail(err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fail(fmt.Errorf("unexpected status: %s", resp.Status))
+	}
+
+	// One byte more than the maximum is read to tell a result of the maximum size
+	// from a larger one, so a truncated result is never decoded
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxResultSize+1))
+	if err != nil {
+		return fail(fmt.Errorf("failed to read result: %w", err))
+	}
+	if len(b) > maxResultSize {
+		return fail(fmt.Errorf("result exceeds %d bytes", maxResultSize))
+	}
+
+	var r checks.Result
+	if err = json.Unmarshal(b, &r); err != nil {
+		return fail(fmt.Errorf("failed to decode result: %w", err))
+	}
+	res.Result = &r
+	return res
+}
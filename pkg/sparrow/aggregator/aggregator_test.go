@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telekom/sparrow/pkg/checks"
+	managermock "github.com/telekom/sparrow/pkg/sparrow/targets/test"
+)
+
+// newPeer returns a peer sparrow serving the given results over https and the authorization header of the last request
+func newPeer(t *testing.T, results map[string]checks.Result) (*httptest.Server, func() string) {
+	t.Helper()
+	var mu sync.Mutex
+	var auth string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		auth = r.Header.Get("Authorization")
+		mu.Unlock()
+
+		res, ok := results[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		return auth
+	}
+}
+
+func TestAggregator_pull(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	peer, auth := newPeer(t, map[string]checks.Result{
+		"/v1/metrics/health":           {Timestamp: now, Data: map[string]any{"https://example.com": "healthy"}},
+		"/v1/metrics/latency/internal": {Timestamp: now, Data: map[string]any{"https://internal.example.com": map[string]any{"total": 0.1}}},
+	})
+	tarMan := &managermock.MockTargetManager{Targets: []checks.GlobalTarget{
+		{Url: peer.URL},
+		{Url: "https://sparrow.example.com"},
+	}}
+
+	a := New("sparrow.example.com", Config{Checks: []string{"health", "latency/internal", "dns"}, Token: "secret"}, tarMan)
+	a.client.Transport = peer.Client().Transport
+	a.pull(t.Context())
+
+	assert.Equal(t, "Bearer secret", auth())
+	source := "127.0.0.1"
+
+	health := a.Results("health")
+	require.Len(t, health, 1, "only the peer should be pulled")
+	require.NotNil(t, health[source].Result)
+	assert.Nil(t, health[source].Error)
+	assert.Equal(t, now, health[source].Result.Timestamp)
+	assert.Equal(t, map[string]any{"https://example.com": "healthy"}, health[source].Result.Data)
+
+	latency := a.Results("latency/internal")
+	require.NotNil(t, latency[source].Result)
+	assert.Equal(t, map[string]any{"https://internal.example.com": map[string]any{"total": 0.1}}, latency[source].Result.Data)
+
+	dns := a.Results("dns")
+	assert.Nil(t, dns[source].Result)
+	require.NotNil(t, dns[source].Error)
+	assert.Contains(t, *dns[source].Error, "404")
+	assert.False(t, dns[source].PulledAt.IsZero())
+
+	assert.Nil(t, a.Results("unknown"))
+}
+
+func TestAggregator_Run(t *testing.T) {
+	peer, _ := newPeer(t, map[string]checks.Result{
+		"/v1/metrics/health": {Timestamp: time.Now(), Data: map[string]any{}},
+	})
+	tarMan := &managermock.MockTargetManager{Targets: []checks.GlobalTarget{{Url: peer.URL}}}
+	a := New("sparrow.example.com", Config{Interval: time.Hour, Checks: []string{"health"}}, tarMan)
+	a.client.Transport = peer.Client().Transport
+
+	errC := make(chan error, 1)
+	go func() {
+		errC <- a.Run(t.Context())
+	}()
+
+	assert.Eventually(t, func() bool {
+		return len(a.Results("health")) == 1
+	}, time.Second, 10*time.Millisecond, "results should be pulled immediately")
+
+	a.Shutdown()
+	select {
+	case err := <-errC:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("aggregator did not stop")
+	}
+}
+
+func TestAggregator_fetch(t *testing.T) {
+	t.Run("token is not sent over http", func(t *testing.T) {
+		var auth string
+		peer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			auth = r.Header.Get("Authorization")
+			_ = json.NewEncoder(w).Encode(checks.Result{Timestamp: time.Now()})
+		}))
+		defer peer.Close()
+		u, err := url.Parse(peer.URL)
+		require.NoError(t, err)
+
+		a := New("sparrow.example.com", Config{Checks: []string{"health"}, Token: "secret"}, &managermock.MockTargetManager{})
+		res := a.fetch(t.Context(), u, "health")
+		assert.Nil(t, res.Error)
+		assert.Empty(t, auth)
+	})
+
+	t.Run("token is not sent after a redirect to http", func(t *testing.T) {
+		var auth string
+		plain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			auth = r.Header.Get("Authorization")
+			_ = json.NewEncoder(w).Encode(checks.Result{Timestamp: time.Now()})
+		}))
+		defer plain.Close()
+		peer := httptest.NewTLSServer(http.RedirectHandler(plain.URL+"/v1/metrics/health", http.StatusFound))
+		defer peer.Close()
+		u, err := url.Parse(peer.URL)
+		require.NoError(t, err)
+
+		a := New("sparrow.example.com", Config{Checks: []string{"health"}, Token: "secret"}, &managermock.MockTargetManager{})
+		a.client.Transport = peer.Client().Transport
+		res := a.fetch(t.Context(), u, "health")
+		assert.Nil(t, res.Error)
+		assert.Empty(t, auth)
+	})
+
+	for _, size := range []int{maxResultSize, maxResultSize + 1} {
+		t.Run(fmt.Sprintf("result of %d bytes", size), func(t *testing.T) {
+			prefix, suffix := `{"data": "`, `"}`
+			peer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(prefix))
+				_, _ = w.Write(bytes.Repeat([]byte("a"), size-len(prefix)-len(suffix)))
+				_, _ = w.Write([]byte(suffix))
+			}))
+			defer peer.Close()
+			u, err := url.Parse(peer.URL)
+			require.NoError(t, err)
+
+			a := New("sparrow.example.com", Config{Checks: []string{"health"}}, &managermock.MockTargetManager{})
+			res := a.fetch(t.Context(), u, "health")
+			if size <= maxResultSize {
+				assert.Nil(t, res.Error)
+				require.NotNil(t, res.Result)
+				assert.Len(t, res.Result.Data, size-len(prefix)-len(suffix))
+				return
+			}
+			assert.Nil(t, res.Result)
+			require.NotNil(t, res.Error)
+			assert.Contains(t, *res.Error, "exceeds")
+		})
+	}
+}
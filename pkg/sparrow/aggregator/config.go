@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregator
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultInterval is the interval in which the results are pulled if no interval is configured
+	DefaultInterval = time.Minute
+	// DefaultTimeout is the timeout of a request to a peer if no timeout is configured
+	DefaultTimeout = 10 * time.Second
+)
+
+var (
+	// ErrInvalidInterval is returned if the configured interval is negative
+	ErrInvalidInterval = errors.New("the aggregator interval must not be negative")
+	// ErrInvalidTimeout is returned if the configured timeout is negative
+	ErrInvalidTimeout = errors.New("the aggregator timeout must not be negative")
+	// ErrNoChecks is returned if no checks are configured
+	ErrNoChecks = errors.New("the aggregator needs at least one check to pull")
+)
+
+// Config is the configuration of the aggregator
+type Config struct {
+	// Enabled enables pulling the check results of the peer sparrows
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// Interval is the interval in which the results are pulled. Defaults to DefaultInterval
+	Interval time.Duration `yaml:"interval" mapstructure:"interval"`
+	// Timeout is the timeout of a single request to a peer. Defaults to DefaultTimeout
+	Timeout time.Duration `yaml:"timeout" mapstructure:"timeout"`
+	// Checks are the names of the checks whose results are pulled
+	Checks []string `yaml:"checks" mapstructure:"checks"`
+	// Token is an optional bearer token used to authenticate at the api of the peers
+	Token string `yaml:"token" mapstructure:"token"`
+}
+
+// Validate validates the aggregator configuration
+func (c *Config) Validate() error {
+	if c.Interval < 0 {
+		return ErrInvalidInterval
+	}
+	if c.Timeout < 0 {
+		return ErrInvalidTimeout
+	}
+	if len(c.Checks) == 0 {
+		return ErrNoChecks
+	}
+	return nil
+}
+
+// interval returns the interval in which the results are pulled
+func (c *Config) interval() time.Duration {
+	if c.Interval > 0 {
+		return c.Interval
+	}
+	return DefaultInterval
+}
+
+// timeout returns the timeout of a single request to a peer
+func (c *Config) timeout() time.Duration {
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+	return DefaultTimeout
+}
@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{name: "defaults", config: Config{Enabled: true, Checks: []string{"health"}}},
+		{name: "interval and timeout", config: Config{Enabled: true, Interval: time.Second, Timeout: time.Second, Checks: []string{"health", "latency/internal"}}},
+		{name: "negative interval", config: Config{Enabled: true, Interval: -time.Second, Checks: []string{"health"}}, wantErr: true},
+		{name: "negative timeout", config: Config{Enabled: true, Timeout: -time.Second, Checks: []string{"health"}}, wantErr: true},
+		{name: "no checks", config: Config{Enabled: true}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Config.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
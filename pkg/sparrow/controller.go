@@ -11,12 +11,14 @@ import (
 	"net/http"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/telekom/sparrow/internal/logger"
 	"github.com/telekom/sparrow/pkg/checks"
 	"github.com/telekom/sparrow/pkg/checks/runtime"
 	"github.com/telekom/sparrow/pkg/db"
 	"github.com/telekom/sparrow/pkg/factory"
 	"github.com/telekom/sparrow/pkg/sparrow/metrics"
+	"github.com/telekom/sparrow/pkg/sparrow/notifier"
 )
 
 // ChecksController is responsible for managing checks.
@@ -27,6 +29,10 @@ type ChecksController struct {
 	cResult chan checks.ResultDTO
 	cErr    chan error
 	done    chan struct{}
+	// results fans out the check results to the stream subscribers
+	results *resultBroker
+	// notifier notifies about state transitions of the check targets, if enabled
+	notifier *notifier.Notifier
 }
 
 // NewChecksController creates a new ChecksController.
@@ -38,6 +44,7 @@ func NewChecksController(dbase db.DB, m metrics.Provider) *ChecksController {
 		cResult: make(chan checks.ResultDTO, 8), //nolint:mnd // Buffered channel to avoid blocking the checks
 		cErr:    make(chan error, 1),
 		done:    make(chan struct{}, 1),
+		results: newResultBroker(),
 	}
 }
 
@@ -49,6 +56,12 @@ func (cc *ChecksController) Run(ctx context.Context) error {
 		select {
 		case result := <-cc.cResult:
 			cc.db.Save(result)
+			if cc.notifier != nil {
+				cc.notifier.Observe(ctx, result)
+			}
+			if dropped := cc.results.Publish(result); dropped > 0 {
+				log.DebugContext(ctx, "Dropped result for slow stream subscribers", "check", result.Name, "subscribers", dropped)
+			}
 		case err := <-cc.cErr:
 			var runErr *ErrRunningCheck
 			if errors.As(err, &runErr) {
@@ -72,11 +85,25 @@ func (cc *ChecksController) Shutdown(ctx context.Context) {
 	for _, c := range cc.checks.Iter() {
 		cc.UnregisterCheck(ctx, c)
 	}
+	cc.CloseStreams()
 	cc.done <- struct{}{}
 	close(cc.done)
 	close(cc.cResult)
 }
 
+// SubscribeResults subscribes to the results of the given checks.
+// If no checks are given, the results of all checks are received.
+// The returned function must be called to cancel the subscription.
+func (cc *ChecksController) SubscribeResults(names ...string) (<-chan checks.ResultDTO, func()) {
+	sub := cc.results.Subscribe(names...)
+	return sub.results, func() { cc.results.Unsubscribe(sub) }
+}
+
+// CloseStreams closes all result subscriptions
+func (cc *ChecksController) CloseStreams() {
+	cc.results.Close()
+}
+
 // Reconcile reconciles the checks.
 // It registers new checks, updates existing checks and unregisters checks not in the new config.
 func (cc *ChecksController) Reconcile(ctx context.Context, cfg runtime.Config) {
@@ -120,8 +147,9 @@ func (cc *ChecksController) RegisterCheck(ctx context.Context, check checks.Chec
 	log := logger.FromContext(ctx).With("check", check.Name())
 
 	// Add prometheus collectors of check to registry
+	reg := cc.registererFor(check)
 	for _, collector := range check.GetMetricCollectors() {
-		if err := cc.metrics.GetRegistry().Register(collector); err != nil {
+		if err := reg.Register(collector); err != nil {
 			log.ErrorContext(ctx, "Could not add metrics collector to registry", "error", err)
 		}
 	}
@@ -145,8 +173,9 @@ func (cc *ChecksController) UnregisterCheck(ctx context.Context, check checks.Ch
 	log := logger.FromContext(ctx).With("check", check.Name())
 
 	// Remove prometheus collectors of check from registry
+	reg := cc.registererFor(check)
 	for _, metricsCollector := range check.GetMetricCollectors() {
-		if !cc.metrics.GetRegistry().Unregister(metricsCollector) {
+		if !reg.Unregister(metricsCollector) {
 			log.ErrorContext(ctx, "Could not remove metrics collector from registry")
 		}
 	}
@@ -155,6 +184,19 @@ func (cc *ChecksController) UnregisterCheck(ctx context.Context, check checks.Ch
 	cc.checks.Delete(check)
 }
 
+// registererFor returns the registerer for the metrics of the given check.
+// The metrics of named instances are labelled with their name, so the metrics
+// of multiple instances of the same check type can be told apart.
+// The label of the default instance is empty, which Prometheus treats like
+// a missing label, so its series stay the same as without named instances.
+func (cc *ChecksController) registererFor(check checks.Check) prometheus.Registerer {
+	label := ""
+	if _, instance := checks.SplitName(check.Name()); instance != "" {
+		label = check.Name()
+	}
+	return prometheus.WrapRegistererWith(prometheus.Labels{checks.LabelCheck: label}, cc.metrics.GetRegistry())
+}
+
 const applicationJSON = "application/json"
 
 var oapiBoilerplate = openapi3.T{
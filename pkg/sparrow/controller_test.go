@@ -8,12 +8,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/telekom/sparrow/internal/logger"
 	"github.com/telekom/sparrow/pkg/checks"
 	"github.com/telekom/sparrow/pkg/checks/dns"
@@ -22,13 +26,14 @@ import (
 	"github.com/telekom/sparrow/pkg/checks/runtime"
 	"github.com/telekom/sparrow/pkg/db"
 	"github.com/telekom/sparrow/pkg/sparrow/metrics"
+	"github.com/telekom/sparrow/pkg/sparrow/notifier"
 )
 
 func TestRun_CheckRunError(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	cc := NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}))
+	cc := NewChecksController(db.NewInMemory(db.DefaultHistorySize), metrics.New(metrics.Config{}))
 	mockCheck := &checks.CheckMock{
 		NameFunc: func() string { return "mockCheck" },
 		RunFunc: func(ctx context.Context, cResult chan checks.ResultDTO) error {
@@ -68,7 +73,7 @@ func TestRun_CheckRunError(t *testing.T) {
 func TestRun_ContextCancellation(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 
-	cc := NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}))
+	cc := NewChecksController(db.NewInMemory(db.DefaultHistorySize), metrics.New(metrics.Config{}))
 
 	done := make(chan struct{})
 	go func() {
@@ -89,6 +94,35 @@ func TestRun_ContextCancellation(t *testing.T) {
 	}
 }
 
+func TestRun_Notifier(t *testing.T) {
+	received := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		received <- string(b)
+	}))
+	defer srv.Close()
+
+	n, err := notifier.New("sparrow.example.com", notifier.Config{Webhooks: []notifier.Webhook{{Url: srv.URL, Template: "{{ .Target }} {{ .State }}"}}})
+	require.NoError(t, err)
+	cc := NewChecksController(db.NewInMemory(db.DefaultHistorySize), metrics.New(metrics.Config{}))
+	cc.notifier = n
+
+	go func() {
+		_ = n.Run(t.Context())
+	}()
+	go func() {
+		_ = cc.Run(t.Context())
+	}()
+
+	cc.cResult <- checks.ResultDTO{Name: "health", Result: &checks.Result{Data: map[string]string{"https://example.com": "unhealthy"}}}
+	select {
+	case body := <-received:
+		assert.Equal(t, "https://example.com failing", body)
+	case <-time.After(time.Second):
+		t.Fatal("no notification received")
+	}
+}
+
 // TestChecksController_Shutdown tests the shutdown of the ChecksController
 // when none, one or multiple checks are registered. The test checks that after shutdown no
 // checks are registered anymore (the checks slice is empty) and that the done channel is closed.
@@ -118,7 +152,7 @@ func TestChecksController_Shutdown(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cc := NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}))
+			cc := NewChecksController(db.NewInMemory(db.DefaultHistorySize), metrics.New(metrics.Config{}))
 
 			if tt.checks != nil {
 				for _, check := range tt.checks {
@@ -153,50 +187,50 @@ func TestChecksController_Reconcile(t *testing.T) {
 		{
 			name:   "no checks registered yet but register one",
 			checks: []checks.Check{},
-			newRuntimeConfig: runtime.Config{Health: &health.Config{
-				Targets:  []string{"https://gitlab.com"},
+			newRuntimeConfig: runtime.Config{Checks: map[string]checks.Runtime{"health": &health.Config{
+				Targets:  []health.Target{{URL: "https://gitlab.com"}},
 				Interval: 1 * time.Second,
 				Timeout:  1 * time.Second,
-			}},
+			}}},
 		},
 		{
 			name:   "no checks registered, register multiple new ones",
 			checks: []checks.Check{},
-			newRuntimeConfig: runtime.Config{
-				Health: &health.Config{
-					Targets:  []string{"https://gitlab.com"},
+			newRuntimeConfig: runtime.Config{Checks: map[string]checks.Runtime{
+				"health": &health.Config{
+					Targets:  []health.Target{{URL: "https://gitlab.com"}},
 					Interval: 1 * time.Second,
 					Timeout:  1 * time.Second,
 				},
-				Latency: &latency.Config{
-					Targets:  []string{"https://gitlab.com"},
+				"latency": &latency.Config{
+					Targets:  []latency.Target{{URL: "https://gitlab.com"}},
 					Interval: 1 * time.Second,
 					Timeout:  1 * time.Second,
 				},
-				Dns: &dns.Config{
-					Targets:  []string{"gitlab.com"},
+				"dns": &dns.Config{
+					Targets:  []dns.Target{{Name: "gitlab.com"}},
 					Interval: 1 * time.Second,
 					Timeout:  1 * time.Second,
 				},
-			},
+			}},
 		},
 		{
 			name: "one healthcheck registered, register latency check",
 			checks: []checks.Check{
 				health.NewCheck(),
 			},
-			newRuntimeConfig: runtime.Config{
-				Latency: &latency.Config{
-					Targets:  []string{"https://gitlab.com"},
+			newRuntimeConfig: runtime.Config{Checks: map[string]checks.Runtime{
+				"latency": &latency.Config{
+					Targets:  []latency.Target{{URL: "https://gitlab.com"}},
 					Interval: 1 * time.Second,
 					Timeout:  1 * time.Second,
 				},
-				Health: &health.Config{
-					Targets:  []string{"https://gitlab.com"},
+				"health": &health.Config{
+					Targets:  []health.Target{{URL: "https://gitlab.com"}},
 					Interval: 1 * time.Second,
 					Timeout:  1 * time.Second,
 				},
-			},
+			}},
 		},
 		{
 			name: "no checks registered but unregister all",
@@ -210,13 +244,13 @@ func TestChecksController_Reconcile(t *testing.T) {
 			checks: []checks.Check{
 				health.NewCheck(),
 			},
-			newRuntimeConfig: runtime.Config{
-				Latency: &latency.Config{
-					Targets:  []string{"https://gitlab.com"},
+			newRuntimeConfig: runtime.Config{Checks: map[string]checks.Runtime{
+				"latency": &latency.Config{
+					Targets:  []latency.Target{{URL: "https://gitlab.com"}},
 					Interval: 1 * time.Second,
 					Timeout:  1 * time.Second,
 				},
-			},
+			}},
 		},
 		{
 			name: "multiple checks registered, unregister some",
@@ -224,13 +258,13 @@ func TestChecksController_Reconcile(t *testing.T) {
 				health.NewCheck(),
 				latency.NewCheck(),
 			},
-			newRuntimeConfig: runtime.Config{
-				Health: &health.Config{
-					Targets:  []string{"https://gitlab.com"},
+			newRuntimeConfig: runtime.Config{Checks: map[string]checks.Runtime{
+				"health": &health.Config{
+					Targets:  []health.Target{{URL: "https://gitlab.com"}},
 					Interval: 1 * time.Second,
 					Timeout:  1 * time.Second,
 				},
-			},
+			}},
 		},
 		{
 			name: "multiple checks registered, unregister all",
@@ -244,7 +278,7 @@ func TestChecksController_Reconcile(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cc := NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}))
+			cc := NewChecksController(db.NewInMemory(db.DefaultHistorySize), metrics.New(metrics.Config{}))
 
 			for _, c := range tt.checks {
 				cc.RegisterCheck(ctx, c)
@@ -257,13 +291,13 @@ func TestChecksController_Reconcile(t *testing.T) {
 				cfg := c.GetConfig()
 				assert.NotNil(t, cfg)
 				if cfg.For() == health.CheckName {
-					assert.Equal(t, tt.newRuntimeConfig.Health, cfg)
+					assert.Equal(t, tt.newRuntimeConfig.For(health.CheckName), cfg)
 				}
 				if cfg.For() == latency.CheckName {
-					assert.Equal(t, tt.newRuntimeConfig.Latency, cfg)
+					assert.Equal(t, tt.newRuntimeConfig.For(latency.CheckName), cfg)
 				}
 				if cfg.For() == dns.CheckName {
-					assert.Equal(t, tt.newRuntimeConfig.Dns, cfg)
+					assert.Equal(t, tt.newRuntimeConfig.For(dns.CheckName), cfg)
 				}
 			}
 
@@ -299,13 +333,13 @@ func TestChecksController_Reconcile_Update(t *testing.T) {
 			checks: []checks.Check{
 				health.NewCheck(),
 			},
-			newRuntimeConfig: runtime.Config{
-				Health: &health.Config{
-					Targets:  []string{"https://new.com"},
+			newRuntimeConfig: runtime.Config{Checks: map[string]checks.Runtime{
+				"health": &health.Config{
+					Targets:  []health.Target{{URL: "https://new.com"}},
 					Interval: 200 * time.Millisecond,
 					Timeout:  1000 * time.Millisecond,
 				},
-			},
+			}},
 		},
 		{
 			name: "update health & latency check",
@@ -313,24 +347,24 @@ func TestChecksController_Reconcile_Update(t *testing.T) {
 				health.NewCheck(),
 				latency.NewCheck(),
 			},
-			newRuntimeConfig: runtime.Config{
-				Health: &health.Config{
-					Targets:  []string{"https://new.com"},
+			newRuntimeConfig: runtime.Config{Checks: map[string]checks.Runtime{
+				"health": &health.Config{
+					Targets:  []health.Target{{URL: "https://new.com"}},
 					Interval: 200 * time.Millisecond,
 					Timeout:  1000 * time.Millisecond,
 				},
-				Latency: &latency.Config{
-					Targets:  []string{"https://new.com"},
+				"latency": &latency.Config{
+					Targets:  []latency.Target{{URL: "https://new.com"}},
 					Interval: 200 * time.Millisecond,
 					Timeout:  1000 * time.Millisecond,
 				},
-			},
+			}},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cc := NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}))
+			cc := NewChecksController(db.NewInMemory(db.DefaultHistorySize), metrics.New(metrics.Config{}))
 
 			for _, c := range tt.checks {
 				cc.RegisterCheck(ctx, c)
@@ -342,10 +376,10 @@ func TestChecksController_Reconcile_Update(t *testing.T) {
 				switch c.GetConfig().For() {
 				case health.CheckName:
 					hc := c.(*health.Health)
-					assert.Equal(t, tt.newRuntimeConfig.Health.Targets, hc.GetConfig().(*health.Config).Targets)
+					assert.Equal(t, tt.newRuntimeConfig.For(health.CheckName).(*health.Config).Targets, hc.GetConfig().(*health.Config).Targets)
 				case latency.CheckName:
 					lc := c.(*latency.Latency)
-					assert.Equal(t, tt.newRuntimeConfig.Latency.Targets, lc.GetConfig().(*latency.Config).Targets)
+					assert.Equal(t, tt.newRuntimeConfig.For(latency.CheckName).(*latency.Config).Targets, lc.GetConfig().(*latency.Config).Targets)
 				}
 			}
 
@@ -362,6 +396,50 @@ func TestChecksController_Reconcile_Update(t *testing.T) {
 	}
 }
 
+func TestChecksController_Reconcile_Instances(t *testing.T) {
+	ctx, cancel := logger.NewContextWithLogger(context.Background())
+	defer cancel()
+
+	cc := NewChecksController(db.NewInMemory(db.DefaultHistorySize), metrics.New(metrics.Config{}))
+	cc.Reconcile(ctx, runtime.Config{Checks: map[string]checks.Runtime{
+		"latency":          &latency.Config{Interval: time.Minute, Timeout: time.Second},
+		"latency/internal": &latency.Config{Interval: 10 * time.Second, Timeout: time.Second},
+	}})
+
+	var names []string
+	for _, c := range cc.checks.Iter() {
+		names = append(names, c.Name())
+	}
+	assert.ElementsMatch(t, []string{"latency", "latency/internal"}, names)
+
+	// the metrics of the named instance are labelled with its name,
+	// the label of the default instance is empty to keep its series unchanged
+	labels := map[string]string{"latency": "", "latency/internal": "latency/internal"}
+	for _, c := range cc.checks.Iter() {
+		reg := prometheus.WrapRegistererWith(prometheus.Labels{checks.LabelCheck: labels[c.Name()]}, cc.metrics.GetRegistry())
+		for _, collector := range c.GetMetricCollectors() {
+			err := reg.Register(collector)
+			var are prometheus.AlreadyRegisteredError
+			assert.ErrorAs(t, err, &are, "Expected metric collector for check %s to be already registered", c.Name())
+		}
+	}
+
+	// the named instance is updated in place and removed without touching the default instance
+	cc.Reconcile(ctx, runtime.Config{Checks: map[string]checks.Runtime{
+		"latency":          &latency.Config{Interval: time.Minute, Timeout: time.Second},
+		"latency/internal": &latency.Config{Interval: 20 * time.Second, Timeout: time.Second},
+	}})
+	for _, c := range cc.checks.Iter() {
+		if c.Name() == "latency/internal" {
+			assert.Equal(t, 20*time.Second, c.GetConfig().(*latency.Config).Interval)
+		}
+	}
+
+	cc.Reconcile(ctx, runtime.Config{Checks: map[string]checks.Runtime{"latency": &latency.Config{Interval: time.Minute, Timeout: time.Second}}})
+	require.Len(t, cc.checks.Iter(), 1)
+	assert.Equal(t, "latency", cc.checks.Iter()[0].Name())
+}
+
 func TestChecksController_RegisterCheck(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -371,7 +449,7 @@ func TestChecksController_RegisterCheck(t *testing.T) {
 		{
 			name: "register one check",
 			setup: func() *ChecksController {
-				return NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}))
+				return NewChecksController(db.NewInMemory(db.DefaultHistorySize), metrics.New(metrics.Config{}))
 			},
 			check: health.NewCheck(),
 		},
@@ -408,7 +486,7 @@ func TestChecksController_UnregisterCheck(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cc := NewChecksController(db.NewInMemory(), metrics.New(metrics.Config{}))
+			cc := NewChecksController(db.NewInMemory(db.DefaultHistorySize), metrics.New(metrics.Config{}))
 
 			cc.UnregisterCheck(context.Background(), tt.check)
 
@@ -7,14 +7,24 @@ package sparrow
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/telekom/sparrow/internal/logger"
 	"github.com/telekom/sparrow/pkg/api"
+	"github.com/telekom/sparrow/pkg/checks"
+	"github.com/telekom/sparrow/pkg/checks/runtime"
+	"github.com/telekom/sparrow/pkg/sparrow/aggregator"
 	"gopkg.in/yaml.v3"
 )
 
@@ -22,9 +32,26 @@ type encoder interface {
 	Encode(v any) error
 }
 
-const urlParamCheckName = "checkName"
+const (
+	urlParamCheckName = "checkName"
+	urlParamInstance  = "instance"
+	queryParamSince   = "since"
+	queryParamLimit   = "limit"
+	queryParamCheck   = "check"
+	// streamKeepAlive is the interval in which a comment is sent to keep idle result streams open
+	streamKeepAlive = 15 * time.Second
+	// configPath is the path of the runtime configuration
+	configPath = "/v1/config"
+	// maxConfigSize is the maximum size of a runtime configuration pushed through the api
+	maxConfigSize = 1 << 20
+)
 
 func (s *Sparrow) startupAPI(ctx context.Context) error {
+	var gatherer prometheus.Gatherer = s.metrics.GetRegistry()
+	if s.config.TargetManager.MeshLabels {
+		gatherer = meshGatherer(gatherer, s.config.SparrowName, s.destinations)
+	}
+
 	routes := []api.Route{
 		{
 			Path: "/openapi", Method: http.MethodGet,
@@ -34,15 +61,50 @@ func (s *Sparrow) startupAPI(ctx context.Context) error {
 			Path: fmt.Sprintf("/v1/metrics/{%s}", urlParamCheckName), Method: http.MethodGet,
 			Handler: s.handleCheckMetrics,
 		},
+		{
+			Path: fmt.Sprintf("/v1/metrics/{%s}/history", urlParamCheckName), Method: http.MethodGet,
+			Handler: s.handleCheckHistory,
+		},
+		{
+			Path: fmt.Sprintf("/v1/metrics/{%s}/{%s}", urlParamCheckName, urlParamInstance), Method: http.MethodGet,
+			Handler: s.handleCheckMetrics,
+		},
+		{
+			Path: fmt.Sprintf("/v1/metrics/{%s}/{%s}/history", urlParamCheckName, urlParamInstance), Method: http.MethodGet,
+			Handler: s.handleCheckHistory,
+		},
+		{
+			Path: "/v1/results/stream", Method: http.MethodGet,
+			Handler: s.handleResultStream,
+		},
+		{
+			Path: "/v1/matrix", Method: http.MethodGet,
+			Handler: s.handleMatrix,
+		},
 		{
 			Path: "/metrics", Method: "*",
 			Handler: promhttp.HandlerFor(
-				s.metrics.GetRegistry(),
+				gatherer,
 				promhttp.HandlerOpts{Registry: s.metrics.GetRegistry()},
 			).ServeHTTP,
 		},
 	}
 
+	routes = append(routes, s.configRoutes(ctx)...)
+
+	if s.aggregator != nil {
+		routes = append(routes,
+			api.Route{
+				Path: fmt.Sprintf("/v1/aggregate/{%s}", urlParamCheckName), Method: http.MethodGet,
+				Handler: s.handleAggregate,
+			},
+			api.Route{
+				Path: fmt.Sprintf("/v1/aggregate/{%s}/{%s}", urlParamCheckName, urlParamInstance), Method: http.MethodGet,
+				Handler: s.handleAggregate,
+			},
+		)
+	}
+
 	err := s.api.RegisterRoutes(ctx, routes...)
 	if err != nil {
 		logger.FromContext(ctx).Error("Error while registering routes", "error", err)
@@ -51,6 +113,26 @@ func (s *Sparrow) startupAPI(ctx context.Context) error {
 	return s.api.Run(ctx)
 }
 
+// configRoutes returns the routes to read and push the runtime configuration.
+// The runtime configuration contains secrets like request headers and environment variables,
+// so it must never be readable or changeable by everyone.
+func (s *Sparrow) configRoutes(ctx context.Context) []api.Route {
+	if !s.config.Api.Auth.Protects(configPath) {
+		logger.FromContext(ctx).WarnContext(ctx, "The runtime configuration api is disabled, since the api is not authenticated", "path", configPath)
+		return nil
+	}
+	return []api.Route{
+		{
+			Path: configPath, Method: http.MethodGet,
+			Handler: s.handleGetConfig,
+		},
+		{
+			Path: configPath, Method: http.MethodPut,
+			Handler: s.handlePutConfig,
+		},
+	}
+}
+
 func (s *Sparrow) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
 	log := logger.FromContext(r.Context())
 	oapi, err := s.controller.GenerateCheckSpecs(r.Context())
@@ -90,7 +172,7 @@ func (s *Sparrow) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
 
 func (s *Sparrow) handleCheckMetrics(w http.ResponseWriter, r *http.Request) {
 	log := logger.FromContext(r.Context())
-	name := chi.URLParam(r, urlParamCheckName)
+	name := checkNameParam(r)
 	if name == "" {
 		w.WriteHeader(http.StatusBadRequest)
 		_, err := w.Write([]byte(http.StatusText(http.StatusBadRequest)))
@@ -123,3 +205,282 @@ func (s *Sparrow) handleCheckMetrics(w http.ResponseWriter, r *http.Request) {
 	}
 	w.Header().Add("Content-Type", applicationJSON)
 }
+
+// handleAggregate returns the latest results of a check of this sparrow
+// and of all peer sparrows pulled by the aggregator, keyed by source sparrow
+func (s *Sparrow) handleAggregate(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	name := checkNameParam(r)
+	if name == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err := w.Write([]byte(http.StatusText(http.StatusBadRequest)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+
+	res := s.aggregator.Results(name)
+	if res == nil {
+		res = make(map[string]aggregator.Result)
+	}
+	if own, ok := s.db.Get(name); ok {
+		res[s.config.SparrowName] = aggregator.Result{Result: &own, PulledAt: time.Now()}
+	}
+	if len(res) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		_, err := w.Write([]byte(http.StatusText(http.StatusNotFound)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+
+	w.Header().Add("Content-Type", applicationJSON)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(res); err != nil {
+		log.Error("failed to encode response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err = w.Write([]byte(http.StatusText(http.StatusInternalServerError)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+}
+
+// handleGetConfig returns the active runtime configuration including the global targets.
+// The configuration is encoded as JSON if requested, otherwise as YAML
+func (s *Sparrow) handleGetConfig(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	var marshaler encoder
+	switch r.Header.Get("Accept") {
+	case applicationJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		marshaler = enc
+		w.Header().Add("Content-Type", applicationJSON)
+	default:
+		marshaler = yaml.NewEncoder(w)
+		w.Header().Add("Content-Type", "text/yaml")
+	}
+
+	s.configMutex.RLock()
+	defer s.configMutex.RUnlock()
+	if err := marshaler.Encode(s.runtimeConfig); err != nil {
+		log.Error("failed to encode runtime configuration", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err = w.Write([]byte(http.StatusText(http.StatusInternalServerError)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+}
+
+// handlePutConfig validates the runtime configuration of the request body and applies it
+// like a configuration of the loader. The body is decoded as JSON if the content type
+// is application/json, otherwise as YAML
+func (s *Sparrow) handlePutConfig(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	b, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxConfigSize))
+	if err != nil {
+		status := http.StatusBadRequest
+		var mbErr *http.MaxBytesError
+		if errors.As(err, &mbErr) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		w.WriteHeader(status)
+		_, err = w.Write([]byte(http.StatusText(status)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+
+	var cfg runtime.Config
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == applicationJSON {
+		err = json.Unmarshal(b, &cfg)
+	} else {
+		err = yaml.Unmarshal(b, &cfg)
+	}
+	if err == nil {
+		err = errors.Join(cfg.Validate(), cfg.AllowsExec(s.config.Exec))
+	}
+	if err != nil {
+		log.Debug("Invalid runtime configuration", "error", err)
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte(fmt.Sprintf("invalid runtime configuration: %v", err)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+
+	select {
+	case s.cRuntime <- cfg:
+		log.Info("Runtime configuration pushed through the api")
+		w.WriteHeader(http.StatusAccepted)
+	case <-r.Context().Done():
+		log.Error("Failed to apply runtime configuration", "error", r.Context().Err())
+	}
+}
+
+// handleCheckHistory returns the saved results of a check in chronological order.
+// The results can be filtered with the query parameters
+//   - since: RFC3339 timestamp or duration like "1h" relative to now; only newer results are returned
+//   - limit: maximum number of results; the latest results are returned
+func (s *Sparrow) handleCheckHistory(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	name := checkNameParam(r)
+	since, limit, err := parseHistoryQuery(r)
+	if name == "" || err != nil {
+		if err != nil {
+			log.Debug("Invalid history query", "error", err)
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_, err = w.Write([]byte(http.StatusText(http.StatusBadRequest)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+
+	res, ok := s.db.History(name, since, limit)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, err = w.Write([]byte(http.StatusText(http.StatusNotFound)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+
+	w.Header().Add("Content-Type", applicationJSON)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err = enc.Encode(res); err != nil {
+		log.Error("failed to encode response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err = w.Write([]byte(http.StatusText(http.StatusInternalServerError)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+}
+
+// checkNameParam returns the name of the check requested by the url.
+// The name of a named check instance consists of the check and the instance url parameter.
+func checkNameParam(r *http.Request) string {
+	name := chi.URLParam(r, urlParamCheckName)
+	if instance := chi.URLParam(r, urlParamInstance); name != "" && instance != "" {
+		return name + checks.InstanceSeparator + instance
+	}
+	return name
+}
+
+// parseHistoryQuery parses the since and limit query parameters of a history request
+func parseHistoryQuery(r *http.Request) (since time.Time, limit int, err error) {
+	q := r.URL.Query()
+	if v := q.Get(queryParamSince); v != "" {
+		if d, dErr := time.ParseDuration(v); dErr == nil {
+			since = time.Now().Add(-d)
+		} else if since, err = time.Parse(time.RFC3339, v); err != nil {
+			return time.Time{}, 0, fmt.Errorf("since must be a RFC3339 timestamp or a duration: %w", err)
+		}
+	}
+
+	if v := q.Get(queryParamLimit); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil {
+			return time.Time{}, 0, fmt.Errorf("limit must be an integer: %w", err)
+		}
+		if limit < 1 {
+			return time.Time{}, 0, errors.New("limit must be greater than 0")
+		}
+	}
+
+	return since, limit, nil
+}
+
+// streamEvent is a check result as sent in the result stream
+type streamEvent struct {
+	Name   string         `json:"name"`
+	Result *checks.Result `json:"result"`
+}
+
+// handleResultStream streams the check results as Server-Sent Events as soon as they are available.
+// The stream can be limited to the results of some checks with the check query parameter,
+// either repeated or as comma separated list, e.g. ?check=health,latency
+func (s *Sparrow) handleResultStream(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		log.Error("Streaming is not supported by the response writer")
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err := w.Write([]byte(http.StatusText(http.StatusInternalServerError)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+
+	var names []string
+	for _, v := range r.URL.Query()[queryParamCheck] {
+		for n := range strings.SplitSeq(v, ",") {
+			if n = strings.TrimSpace(n); n != "" {
+				names = append(names, n)
+			}
+		}
+	}
+
+	results, unsubscribe := s.controller.SubscribeResults(names...)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// Disables response buffering of reverse proxies like nginx
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprint(w, ": connected\n\n"); err != nil {
+		log.Debug("Failed to write to result stream", "error", err)
+		return
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case res, ok := <-results:
+			if !ok {
+				return
+			}
+			b, err := json.Marshal(streamEvent{Name: res.Name, Result: res.Result})
+			if err != nil {
+				log.Error("Failed to encode result", "error", err, "check", res.Name)
+				continue
+			}
+			if _, err = fmt.Fprintf(w, "data: %s\n\n", b); err != nil {
+				log.Debug("Failed to write to result stream", "error", err)
+				return
+			}
+			flusher.Flush()
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				log.Debug("Failed to write to result stream", "error", err)
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -5,6 +5,7 @@
 package sparrow
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -12,14 +13,22 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telekom/sparrow/pkg/api"
 	"github.com/telekom/sparrow/pkg/checks"
+	"github.com/telekom/sparrow/pkg/checks/exec"
+	"github.com/telekom/sparrow/pkg/checks/health"
 	"github.com/telekom/sparrow/pkg/checks/runtime"
+	"github.com/telekom/sparrow/pkg/config"
 	"github.com/telekom/sparrow/pkg/db"
+	"github.com/telekom/sparrow/pkg/sparrow/metrics"
 	"gopkg.in/yaml.v3"
 )
 
@@ -102,7 +111,7 @@ func TestSparrow_handleCheckMetrics(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &Sparrow{
-				db: db.NewInMemory(),
+				db: db.NewInMemory(db.DefaultHistorySize),
 			}
 			if tt.wantCode == http.StatusOK {
 				s.db = testDb()
@@ -148,6 +157,149 @@ func TestSparrow_handleCheckMetrics(t *testing.T) {
 	}
 }
 
+func TestSparrow_handleCheckHistory(t *testing.T) {
+	now := time.Now()
+	d := db.NewInMemory(db.DefaultHistorySize)
+	for n := range 3 {
+		d.Save(checks.ResultDTO{Name: "alpha", Result: &checks.Result{Timestamp: now.Add(time.Duration(n-2) * time.Hour), Data: float64(n)}})
+	}
+	s := &Sparrow{db: d}
+
+	tests := []struct {
+		name     string
+		check    string
+		query    string
+		wantCode int
+		want     []float64
+	}{
+		{name: "full history", check: "alpha", wantCode: http.StatusOK, want: []float64{0, 1, 2}},
+		{name: "since duration", check: "alpha", query: "?since=90m", wantCode: http.StatusOK, want: []float64{1, 2}},
+		{name: "since timestamp", check: "alpha", query: "?since=" + now.Add(-time.Minute).UTC().Format(time.RFC3339), wantCode: http.StatusOK, want: []float64{2}},
+		{name: "limit", check: "alpha", query: "?limit=2", wantCode: http.StatusOK, want: []float64{1, 2}},
+		{name: "invalid since", check: "alpha", query: "?since=yesterday", wantCode: http.StatusBadRequest},
+		{name: "invalid limit", check: "alpha", query: "?limit=0", wantCode: http.StatusBadRequest},
+		{name: "missing check name", wantCode: http.StatusBadRequest},
+		{name: "unknown check", check: "beta", wantCode: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := chiRequest(httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/v1/metrics/"+tt.check+"/history"+tt.query, http.NoBody), tt.check)
+
+			s.handleCheckHistory(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantCode {
+				t.Fatalf("Sparrow.handleCheckHistory() = %v, want %v", resp.StatusCode, tt.wantCode)
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+
+			var got []checks.Result
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatalf("Expected valid json: %v", err)
+			}
+			data := make([]float64, 0, len(got))
+			for _, r := range got {
+				data = append(data, r.Data.(float64))
+			}
+			if !reflect.DeepEqual(data, tt.want) {
+				t.Errorf("Sparrow.handleCheckHistory() = %v, want %v", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestSparrow_handleResultStream(t *testing.T) {
+	cc := NewChecksController(db.NewInMemory(db.DefaultHistorySize), metrics.New(metrics.Config{}))
+	s := &Sparrow{controller: cc}
+	srv := httptest.NewServer(http.HandlerFunc(s.handleResultStream))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	go func() {
+		_ = cc.Run(ctx)
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"?check=latency&check=dns,tls", http.NoBody)
+	require.NoError(t, err)
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := bufio.NewReader(resp.Body)
+	// Wait until the subscription is established
+	line, err := events.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, ": connected\n", line)
+
+	now := time.Now().UTC()
+	cc.cResult <- checks.ResultDTO{Name: "health", Result: &checks.Result{Data: "filtered", Timestamp: now}}
+	cc.cResult <- checks.ResultDTO{Name: "latency", Result: &checks.Result{Data: "latency", Timestamp: now}}
+	cc.cResult <- checks.ResultDTO{Name: "tls", Result: &checks.Result{Data: "tls", Timestamp: now}}
+
+	for _, want := range []string{"latency", "tls"} {
+		var data string
+		for !strings.HasPrefix(data, "data: ") {
+			data, err = events.ReadString('\n')
+			require.NoError(t, err)
+		}
+
+		var got streamEvent
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(data, "data: ")), &got))
+		assert.Equal(t, want, got.Name)
+		assert.Equal(t, want, got.Result.Data)
+		assert.True(t, now.Equal(got.Result.Timestamp))
+	}
+
+	// The stream ends when the controller closes the streams
+	cc.CloseStreams()
+	_, err = io.ReadAll(events)
+	assert.NoError(t, err)
+}
+
+func TestSparrow_handleCheckMetrics_instance(t *testing.T) {
+	d := db.NewInMemory(db.DefaultHistorySize)
+	d.Save(checks.ResultDTO{Name: "latency", Result: &checks.Result{Timestamp: time.Now(), Data: "default"}})
+	d.Save(checks.ResultDTO{Name: "latency/internal", Result: &checks.Result{Timestamp: time.Now(), Data: "internal"}})
+	s := &Sparrow{db: d}
+
+	r := chi.NewRouter()
+	r.Get("/v1/metrics/{checkName}", s.handleCheckMetrics)
+	r.Get("/v1/metrics/{checkName}/history", s.handleCheckHistory)
+	r.Get("/v1/metrics/{checkName}/{instance}", s.handleCheckMetrics)
+	r.Get("/v1/metrics/{checkName}/{instance}/history", s.handleCheckHistory)
+
+	tests := []struct {
+		path     string
+		wantCode int
+		want     string
+	}{
+		{path: "/v1/metrics/latency", wantCode: http.StatusOK, want: `"default"`},
+		{path: "/v1/metrics/latency/internal", wantCode: http.StatusOK, want: `"internal"`},
+		{path: "/v1/metrics/latency/internal/history", wantCode: http.StatusOK, want: `"internal"`},
+		{path: "/v1/metrics/latency/history", wantCode: http.StatusOK, want: `"default"`},
+		{path: "/v1/metrics/latency/external", wantCode: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodGet, tt.path, http.NoBody))
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.want != "" {
+				assert.Contains(t, w.Body.String(), tt.want)
+			}
+		})
+	}
+}
+
 func chiRequest(r *http.Request, value string) *http.Request {
 	rctx := chi.NewRouteContext()
 	rctx.URLParams.Add("checkName", value)
@@ -157,9 +309,119 @@ func chiRequest(r *http.Request, value string) *http.Request {
 }
 
 func testDb() *db.InMemory {
-	d := db.NewInMemory()
+	d := db.NewInMemory(db.DefaultHistorySize)
 	d.Save(checks.ResultDTO{Name: "alpha", Result: &checks.Result{Timestamp: time.Now(), Data: 1}})
 	d.Save(checks.ResultDTO{Name: "beta", Result: &checks.Result{Timestamp: time.Now(), Data: 1}})
 
 	return d
 }
+
+func TestSparrow_configRoutes(t *testing.T) {
+	s := &Sparrow{config: &config.Config{}}
+	assert.Empty(t, s.configRoutes(t.Context()), "the runtime configuration must not be public")
+
+	s.config.Api.Auth.Bearer.Tokens = []string{"secret"}
+	routes := s.configRoutes(t.Context())
+	require.Len(t, routes, 2)
+	assert.Equal(t, http.MethodGet, routes[0].Method)
+	assert.Equal(t, http.MethodPut, routes[1].Method)
+
+	s.config.Api.Auth.Default = api.PolicyPublic
+	assert.Empty(t, s.configRoutes(t.Context()))
+}
+
+func TestSparrow_handlePutConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		exec        exec.Policy
+		wantCode    int
+		want        *runtime.Config
+	}{
+		{
+			name:     "yaml",
+			body:     "health:\n  targets: [https://example.com]\n  interval: 1m\n  timeout: 1s\n",
+			wantCode: http.StatusAccepted,
+			want:     &runtime.Config{Checks: map[string]checks.Runtime{"health": &health.Config{Targets: []health.Target{{URL: "https://example.com"}}, Interval: time.Minute, Timeout: time.Second}}},
+		},
+		{
+			name:        "json",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"health": {"targets": ["https://example.com"], "interval": 60000000000, "timeout": 1000000000}}`,
+			wantCode:    http.StatusAccepted,
+			want:        &runtime.Config{Checks: map[string]checks.Runtime{"health": &health.Config{Targets: []health.Target{{URL: "https://example.com"}}, Interval: time.Minute, Timeout: time.Second}}},
+		},
+		{name: "malformed", body: "health: [", wantCode: http.StatusBadRequest},
+		{name: "invalid", body: "health:\n  targets: [https://example.com]\n  interval: 1ms\n", wantCode: http.StatusBadRequest},
+		{name: "unknown check type", body: "unknown/internal: {}", wantCode: http.StatusBadRequest},
+		{
+			name:     "exec check allowed",
+			body:     "exec:\n  targets: [{name: probe, command: \"true\"}]\n  interval: 1m\n  timeout: 1s\n",
+			exec:     exec.Policy{Enabled: true, Commands: []exec.AllowedCommand{{Command: "true"}}},
+			wantCode: http.StatusAccepted,
+			want:     &runtime.Config{Checks: map[string]checks.Runtime{"exec": &exec.Config{Targets: []exec.Target{{Name: "probe", Command: "true"}}, Interval: time.Minute, Timeout: time.Second}}},
+		},
+		{
+			name:     "exec check not enabled",
+			body:     "exec:\n  targets: [{name: probe, command: \"true\"}]\n  interval: 1m\n  timeout: 1s\n",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "exec command not allowed",
+			body:     "exec:\n  targets: [{name: shell, command: sh}]\n  interval: 1m\n  timeout: 1s\n",
+			exec:     exec.Policy{Enabled: true, Commands: []exec.AllowedCommand{{Command: "true"}}},
+			wantCode: http.StatusBadRequest,
+		},
+		{name: "too large", body: strings.Repeat("#", maxConfigSize+1), wantCode: http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Sparrow{config: &config.Config{Exec: tt.exec}, cRuntime: make(chan runtime.Config, 1)}
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodPut, configPath, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+			s.handlePutConfig(w, req)
+
+			assert.Equal(t, tt.wantCode, w.Code, w.Body.String())
+			if tt.want == nil {
+				assert.Empty(t, s.cRuntime)
+				return
+			}
+			require.Len(t, s.cRuntime, 1)
+			assert.Equal(t, *tt.want, <-s.cRuntime)
+		})
+	}
+}
+
+func TestSparrow_handleGetConfig(t *testing.T) {
+	s := &Sparrow{runtimeConfig: runtime.Config{Checks: map[string]checks.Runtime{
+		"health": &health.Config{Targets: []health.Target{{URL: "https://example.com"}}, Interval: time.Minute, Timeout: time.Second},
+	}}}
+
+	t.Run("yaml", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.handleGetConfig(w, httptest.NewRequestWithContext(t.Context(), http.MethodGet, configPath, http.NoBody))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/yaml", w.Header().Get("Content-Type"))
+
+		var got runtime.Config
+		require.NoError(t, yaml.Unmarshal(w.Body.Bytes(), &got))
+		assert.Equal(t, s.runtimeConfig, got)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, configPath, http.NoBody)
+		req.Header.Set("Accept", applicationJSON)
+		s.handleGetConfig(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, applicationJSON, w.Header().Get("Content-Type"))
+
+		var got runtime.Config
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		assert.Equal(t, s.runtimeConfig, got)
+	})
+}
@@ -0,0 +1,212 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sparrow
+
+import (
+	"encoding/json"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/telekom/sparrow/internal/logger"
+	"github.com/telekom/sparrow/pkg/checks"
+	"github.com/telekom/sparrow/pkg/checks/health"
+	"github.com/telekom/sparrow/pkg/checks/latency"
+)
+
+const (
+	// labelSource is the label holding the name of the measuring sparrow
+	labelSource = "source"
+	// labelDestination is the label holding the name of the measured sparrow
+	labelDestination = "destination"
+)
+
+// matrixChecks are the checks whose results are part of the mesh matrix
+var matrixChecks = []string{health.CheckName, latency.CheckName}
+
+// meshMatrix holds the latest results of the checks between sparrow instances
+type meshMatrix struct {
+	// Sources are the names of the measuring sparrows
+	Sources []string `json:"sources"`
+	// Destinations are the names of the measured sparrows
+	Destinations []string `json:"destinations"`
+	// Results are the latest results keyed by check, source and destination
+	Results map[string]map[string]map[string]json.RawMessage `json:"results"`
+}
+
+// destinations returns the names of the global targets other than this sparrow,
+// keyed by every target label value the checks use for them
+func (s *Sparrow) destinations() map[string]string {
+	return s.targetNames(false)
+}
+
+// targetNames returns the names of the global targets keyed by every
+// target label value the checks use for them. This sparrow is only
+// included if self is true.
+func (s *Sparrow) targetNames(self bool) map[string]string {
+	names := make(map[string]string)
+	if s.tarMan == nil {
+		return names
+	}
+
+	for _, gt := range s.tarMan.GetTargets() {
+		u, err := url.Parse(gt.Url)
+		if err != nil {
+			continue
+		}
+		host := u.Hostname()
+		if host == s.config.SparrowName && !self {
+			continue
+		}
+
+		// the health and latency checks use the url, the dns check the host
+		// and the tcp check the address of the global target
+		names[u.String()] = host
+		names[host] = host
+		names[net.JoinHostPort(host, portFromURL(u))] = host
+	}
+	return names
+}
+
+// meshGatherer returns a gatherer which adds the source and destination labels
+// to the metric families of the given gatherer with metrics labelled with a global target.
+// All metrics of such a family are labelled, so the family has a consistent label set.
+// The destination of metrics not labelled with a global target is empty.
+func meshGatherer(g prometheus.Gatherer, source string, destinations func() map[string]string) prometheus.Gatherer {
+	return prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		mfs, err := g.Gather()
+		dests := destinations()
+		if len(dests) == 0 {
+			return mfs, err
+		}
+
+		for _, mf := range mfs {
+			if !isMeshFamily(mf, dests) {
+				continue
+			}
+			for _, m := range mf.GetMetric() {
+				sourceName, destinationName := labelSource, labelDestination
+				dest := dests[targetLabel(m)]
+				m.Label = append(m.Label,
+					&dto.LabelPair{Name: &sourceName, Value: &source},
+					&dto.LabelPair{Name: &destinationName, Value: &dest},
+				)
+				slices.SortFunc(m.Label, func(a, b *dto.LabelPair) int {
+					return strings.Compare(a.GetName(), b.GetName())
+				})
+			}
+		}
+		return mfs, err
+	})
+}
+
+// isMeshFamily returns true if a metric of the family is labelled with a global target.
+// Families which already have mesh labels are never labelled, so their labels aren't overwritten.
+func isMeshFamily(mf *dto.MetricFamily, dests map[string]string) bool {
+	mesh := false
+	for _, m := range mf.GetMetric() {
+		for _, l := range m.GetLabel() {
+			if l.GetName() == labelSource || l.GetName() == labelDestination {
+				return false
+			}
+		}
+		if _, ok := dests[targetLabel(m)]; ok {
+			mesh = true
+		}
+	}
+	return mesh
+}
+
+// targetLabel returns the value of the target label of the metric
+func targetLabel(m *dto.Metric) string {
+	for _, l := range m.GetLabel() {
+		if l.GetName() == checks.LabelTarget {
+			return l.GetValue()
+		}
+	}
+	return ""
+}
+
+// matrix returns the latest health and latency results of this sparrow for all global targets.
+// If the aggregator is enabled, the results pulled from the peer sparrows are added as further sources.
+func (s *Sparrow) matrix() meshMatrix {
+	source := s.config.SparrowName
+	dests := s.targetNames(s.aggregator != nil)
+	unique := make(map[string]bool, len(dests))
+	for _, d := range dests {
+		unique[d] = true
+	}
+
+	sources := map[string]bool{source: true}
+	m := meshMatrix{
+		Destinations: slices.Sorted(maps.Keys(unique)),
+		Results:      make(map[string]map[string]map[string]json.RawMessage),
+	}
+
+	for _, check := range matrixChecks {
+		m.Results[check] = make(map[string]map[string]json.RawMessage)
+		if res, ok := s.db.Get(check); ok {
+			m.Results[check][source] = matrixRow(res.Data, dests)
+		} else {
+			m.Results[check][source] = make(map[string]json.RawMessage)
+		}
+
+		if s.aggregator == nil {
+			continue
+		}
+		for peer, res := range s.aggregator.Results(check) {
+			sources[peer] = true
+			if res.Result == nil {
+				m.Results[check][peer] = make(map[string]json.RawMessage)
+				continue
+			}
+			m.Results[check][peer] = matrixRow(res.Result.Data, dests)
+		}
+	}
+	m.Sources = slices.Sorted(maps.Keys(sources))
+	return m
+}
+
+// matrixRow returns the results of a check keyed by the names of the global targets
+func matrixRow(data any, dests map[string]string) map[string]json.RawMessage {
+	row := make(map[string]json.RawMessage)
+	b, err := json.Marshal(data)
+	if err != nil {
+		return row
+	}
+	var byTarget map[string]json.RawMessage
+	if err = json.Unmarshal(b, &byTarget); err != nil {
+		return row
+	}
+	for target, r := range byTarget {
+		if dest, ok := dests[target]; ok {
+			row[dest] = r
+		}
+	}
+	return row
+}
+
+// handleMatrix returns the latest health and latency matrix between this sparrow and the global targets
+func (s *Sparrow) handleMatrix(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	w.Header().Add("Content-Type", applicationJSON)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(s.matrix()); err != nil {
+		log.Error("failed to encode response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err = w.Write([]byte(http.StatusText(http.StatusInternalServerError)))
+		if err != nil {
+			log.Error("Failed to write response", "error", err)
+		}
+		return
+	}
+}
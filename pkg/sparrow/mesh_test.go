@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sparrow
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telekom/sparrow/pkg/checks"
+	"github.com/telekom/sparrow/pkg/config"
+	"github.com/telekom/sparrow/pkg/db"
+	"github.com/telekom/sparrow/pkg/sparrow/aggregator"
+	managermock "github.com/telekom/sparrow/pkg/sparrow/targets/test"
+)
+
+func newMeshSparrow(d db.DB) *Sparrow {
+	return &Sparrow{
+		db: d,
+		tarMan: &managermock.MockTargetManager{
+			Targets: []checks.GlobalTarget{
+				{Url: "https://sparrow-a.example.com"},
+				{Url: "https://sparrow-b.example.com"},
+				{Url: "http://sparrow-c.example.com:8080"},
+			},
+		},
+		config: &config.Config{SparrowName: "sparrow-a.example.com"},
+	}
+}
+
+func TestSparrow_destinations(t *testing.T) {
+	s := newMeshSparrow(nil)
+	assert.Equal(t, map[string]string{
+		"https://sparrow-b.example.com":     "sparrow-b.example.com",
+		"sparrow-b.example.com":             "sparrow-b.example.com",
+		"sparrow-b.example.com:443":         "sparrow-b.example.com",
+		"http://sparrow-c.example.com:8080": "sparrow-c.example.com",
+		"sparrow-c.example.com":             "sparrow-c.example.com",
+		"sparrow-c.example.com:8080":        "sparrow-c.example.com",
+	}, s.destinations())
+
+	assert.Empty(t, (&Sparrow{config: &config.Config{}}).destinations())
+
+	s.tarMan = &managermock.MockTargetManager{Targets: []checks.GlobalTarget{{Url: "https://[2001:db8::1]:8443"}}}
+	assert.Equal(t, map[string]string{
+		"https://[2001:db8::1]:8443": "2001:db8::1",
+		"2001:db8::1":                "2001:db8::1",
+		"[2001:db8::1]:8443":         "2001:db8::1",
+	}, s.destinations())
+}
+
+func TestMeshGatherer(t *testing.T) {
+	s := newMeshSparrow(nil)
+	reg := prometheus.NewRegistry()
+	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "sparrow_test", Help: "Test metric."}, []string{checks.LabelTarget})
+	reg.MustRegister(gauge)
+	gauge.WithLabelValues("https://sparrow-b.example.com").Set(1)
+	gauge.WithLabelValues("https://example.com").Set(2)
+	// the labels of metrics which already have mesh labels must be kept
+	own := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "sparrow_own", Help: "Metric with mesh labels."}, []string{labelDestination, labelSource, checks.LabelTarget})
+	reg.MustRegister(own)
+	own.WithLabelValues("other", "peer", "https://sparrow-b.example.com").Set(3)
+	// families without global targets are kept unchanged
+	other := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "sparrow_other", Help: "Metric without global targets."}, []string{checks.LabelTarget})
+	reg.MustRegister(other)
+	other.WithLabelValues("https://example.com").Set(4)
+
+	err := testutil.GatherAndCompare(meshGatherer(reg, s.config.SparrowName, s.destinations), strings.NewReader(`
+# HELP sparrow_other Metric without global targets.
+# TYPE sparrow_other gauge
+sparrow_other{target="https://example.com"} 4
+# HELP sparrow_own Metric with mesh labels.
+# TYPE sparrow_own gauge
+sparrow_own{destination="other",source="peer",target="https://sparrow-b.example.com"} 3
+# HELP sparrow_test Test metric.
+# TYPE sparrow_test gauge
+sparrow_test{destination="",source="sparrow-a.example.com",target="https://example.com"} 2
+sparrow_test{destination="sparrow-b.example.com",source="sparrow-a.example.com",target="https://sparrow-b.example.com"} 1
+`))
+	assert.NoError(t, err)
+}
+
+func TestSparrow_handleMatrix(t *testing.T) {
+	d := db.NewInMemory(db.DefaultHistorySize)
+	d.Save(checks.ResultDTO{Name: "latency", Result: &checks.Result{Timestamp: time.Now(), Data: map[string]any{
+		"https://sparrow-b.example.com": map[string]any{"total": 0.1},
+		"https://example.com":           map[string]any{"total": 0.2},
+	}}})
+	s := newMeshSparrow(d)
+
+	w := httptest.NewRecorder()
+	s.handleMatrix(w, httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/v1/matrix", http.NoBody))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var got meshMatrix
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, []string{"sparrow-a.example.com"}, got.Sources)
+	assert.Equal(t, []string{"sparrow-b.example.com", "sparrow-c.example.com"}, got.Destinations)
+	assert.JSONEq(t, `{"total": 0.1}`, string(got.Results["latency"]["sparrow-a.example.com"]["sparrow-b.example.com"]))
+	assert.Len(t, got.Results["latency"]["sparrow-a.example.com"], 1)
+	assert.Empty(t, got.Results["health"]["sparrow-a.example.com"])
+}
+
+func TestSparrow_aggregate(t *testing.T) {
+	peer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/metrics/latency" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(checks.Result{Timestamp: time.Now(), Data: map[string]any{
+			"https://sparrow-a.example.com": map[string]any{"total": 0.3},
+		}})
+	}))
+	defer peer.Close()
+
+	d := db.NewInMemory(db.DefaultHistorySize)
+	d.Save(checks.ResultDTO{Name: "latency", Result: &checks.Result{Timestamp: time.Now(), Data: map[string]any{
+		"https://sparrow-b.example.com": map[string]any{"total": 0.1},
+	}}})
+	s := newMeshSparrow(d)
+	tarMan := s.tarMan.(*managermock.MockTargetManager)
+	tarMan.Targets = append(tarMan.Targets, checks.GlobalTarget{Url: peer.URL})
+	s.aggregator = aggregator.New(s.config.SparrowName, aggregator.Config{Interval: time.Hour, Timeout: 500 * time.Millisecond, Checks: []string{"latency"}}, s.tarMan)
+
+	go func() {
+		_ = s.aggregator.Run(t.Context())
+	}()
+	defer s.aggregator.Shutdown()
+	require.Eventually(t, func() bool {
+		return len(s.aggregator.Results("latency")) == 3
+	}, 2*time.Second, 10*time.Millisecond)
+
+	t.Run("matrix", func(t *testing.T) {
+		m := s.matrix()
+		assert.Equal(t, []string{"127.0.0.1", "sparrow-a.example.com", "sparrow-b.example.com", "sparrow-c.example.com"}, m.Sources)
+		assert.Equal(t, []string{"127.0.0.1", "sparrow-a.example.com", "sparrow-b.example.com", "sparrow-c.example.com"}, m.Destinations)
+		assert.JSONEq(t, `{"total": 0.1}`, string(m.Results["latency"]["sparrow-a.example.com"]["sparrow-b.example.com"]))
+		assert.JSONEq(t, `{"total": 0.3}`, string(m.Results["latency"]["127.0.0.1"]["sparrow-a.example.com"]))
+		assert.Empty(t, m.Results["latency"]["sparrow-b.example.com"], "unreachable peers have no results")
+	})
+
+	t.Run("handler", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.handleAggregate(w, chiRequest(httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/v1/aggregate/latency", http.NoBody), "latency"))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var got map[string]aggregator.Result
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		assert.Len(t, got, 4)
+		require.NotNil(t, got["sparrow-a.example.com"].Result)
+		require.NotNil(t, got["127.0.0.1"].Result)
+		assert.Nil(t, got["sparrow-b.example.com"].Result)
+		assert.NotNil(t, got["sparrow-b.example.com"].Error)
+
+		w = httptest.NewRecorder()
+		s.handleAggregate(w, chiRequest(httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/v1/aggregate/dns", http.NoBody), "dns"))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
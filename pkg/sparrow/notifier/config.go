@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package notifier
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"text/template"
+	"time"
+
+	"github.com/telekom/sparrow/internal/helper"
+)
+
+const (
+	// DefaultTimeout is the timeout of a webhook request if no timeout is configured
+	DefaultTimeout = 10 * time.Second
+	// DefaultDebounce is the number of consecutive runs needed for a transition if no debounce is configured
+	DefaultDebounce = 1
+)
+
+var (
+	// ErrNoWebhooks is returned if the notifier is enabled without webhooks
+	ErrNoWebhooks = errors.New("the notifier needs at least one webhook")
+	// ErrInvalidDebounce is returned if the configured debounce is negative
+	ErrInvalidDebounce = errors.New("the notifier debounce must not be negative")
+	// ErrInvalidTimeout is returned if the configured timeout is negative
+	ErrInvalidTimeout = errors.New("the notifier timeout must not be negative")
+	// ErrInvalidThreshold is returned if a configured latency threshold is not positive
+	ErrInvalidThreshold = errors.New("the notifier thresholds must be positive")
+)
+
+// Config is the configuration of the notifier
+type Config struct {
+	// Enabled enables sending notifications on state transitions of the check targets
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// Debounce is the number of consecutive runs a target must be in a new state
+	// before the transition is notified. Defaults to DefaultDebounce
+	Debounce int `yaml:"debounce" mapstructure:"debounce"`
+	// Thresholds are the maximum total durations of the targets keyed by check name.
+	// Targets above their threshold are failing
+	Thresholds map[string]time.Duration `yaml:"thresholds" mapstructure:"thresholds"`
+	// Timeout is the timeout of a single webhook request. Defaults to DefaultTimeout
+	Timeout time.Duration `yaml:"timeout" mapstructure:"timeout"`
+	// Retry defines if and how to retry failed webhook requests
+	Retry helper.RetryConfig `yaml:"retry" mapstructure:"retry"`
+	// Webhooks are the webhooks the notifications are sent to
+	Webhooks []Webhook `yaml:"webhooks" mapstructure:"webhooks"`
+}
+
+// Webhook is a receiver of the notifications
+type Webhook struct {
+	// Name is the name of the webhook used in logs
+	Name string `yaml:"name" mapstructure:"name"`
+	// Url is the url the notifications are posted to
+	Url string `yaml:"url" mapstructure:"url"`
+	// Headers are additional headers sent with every notification, e.g. for authentication
+	Headers map[string]string `yaml:"headers" mapstructure:"headers"`
+	// Checks are the names of the checks notified to this webhook. If empty, all checks are notified
+	Checks []string `yaml:"checks" mapstructure:"checks"`
+	// Template is a text/template rendering the payload from the Event.
+	// If empty, the Event is sent as JSON
+	Template string `yaml:"template" mapstructure:"template"`
+}
+
+// Validate validates the notifier configuration
+func (c *Config) Validate() (err error) {
+	if len(c.Webhooks) == 0 {
+		err = errors.Join(err, ErrNoWebhooks)
+	}
+	if c.Debounce < 0 {
+		err = errors.Join(err, ErrInvalidDebounce)
+	}
+	if c.Timeout < 0 {
+		err = errors.Join(err, ErrInvalidTimeout)
+	}
+	for check, threshold := range c.Thresholds {
+		if threshold <= 0 {
+			err = errors.Join(err, fmt.Errorf("%w: %s", ErrInvalidThreshold, check))
+		}
+	}
+	for i, wh := range c.Webhooks {
+		if u, uErr := url.ParseRequestURI(wh.Url); uErr != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			err = errors.Join(err, fmt.Errorf("webhooks[%d]: invalid url %q", i, wh.Url))
+		}
+		if _, tErr := wh.template(); tErr != nil {
+			err = errors.Join(err, fmt.Errorf("webhooks[%d]: invalid template: %w", i, tErr))
+		}
+	}
+	return err
+}
+
+// debounce returns the number of consecutive runs needed for a transition
+func (c *Config) debounce() int {
+	if c.Debounce > 0 {
+		return c.Debounce
+	}
+	return DefaultDebounce
+}
+
+// timeout returns the timeout of a single webhook request
+func (c *Config) timeout() time.Duration {
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+	return DefaultTimeout
+}
+
+// template parses the payload template of the webhook.
+// Returns nil if no template is configured
+func (w *Webhook) template() (*template.Template, error) {
+	if w.Template == "" {
+		return nil, nil
+	}
+	return template.New(w.Name).Funcs(template.FuncMap{"json": toJSON}).Parse(w.Template)
+}
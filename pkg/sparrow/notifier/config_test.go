@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package notifier
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	webhook := Webhook{Name: "ops", Url: "https://hooks.example.com/sparrow"}
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{name: "defaults", config: Config{Enabled: true, Webhooks: []Webhook{webhook}}},
+		{
+			name: "all options",
+			config: Config{
+				Enabled:    true,
+				Debounce:   3,
+				Thresholds: map[string]time.Duration{"latency": 500 * time.Millisecond},
+				Timeout:    time.Second,
+				Webhooks: []Webhook{{
+					Name:     "chat",
+					Url:      "http://chat.example.com/hook",
+					Headers:  map[string]string{"Authorization": "Bearer token"},
+					Checks:   []string{"health", "latency/internal"},
+					Template: `{"text": {{ printf "%s is %s" .Target .State | json }}}`,
+				}},
+			},
+		},
+		{name: "no webhooks", config: Config{Enabled: true}, wantErr: true},
+		{name: "negative debounce", config: Config{Enabled: true, Debounce: -1, Webhooks: []Webhook{webhook}}, wantErr: true},
+		{name: "negative timeout", config: Config{Enabled: true, Timeout: -time.Second, Webhooks: []Webhook{webhook}}, wantErr: true},
+		{name: "zero threshold", config: Config{Enabled: true, Thresholds: map[string]time.Duration{"latency": 0}, Webhooks: []Webhook{webhook}}, wantErr: true},
+		{name: "invalid url", config: Config{Enabled: true, Webhooks: []Webhook{{Url: "hooks.example.com"}}}, wantErr: true},
+		{name: "invalid scheme", config: Config{Enabled: true, Webhooks: []Webhook{{Url: "ftp://hooks.example.com"}}}, wantErr: true},
+		{name: "invalid template", config: Config{Enabled: true, Webhooks: []Webhook{{Url: webhook.Url, Template: "{{ .Target"}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Config.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
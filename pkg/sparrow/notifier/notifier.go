@@ -0,0 +1,282 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/telekom/sparrow/internal/helper"
+	"github.com/telekom/sparrow/internal/logger"
+	"github.com/telekom/sparrow/pkg/checks"
+)
+
+// eventBufferSize is the number of events buffered until they are sent.
+// Events are dropped if the webhooks do not keep up.
+const eventBufferSize = 64
+
+// Event is a state transition of a check target
+type Event struct {
+	// Sparrow is the name of the sparrow the check runs on
+	Sparrow string `json:"sparrow"`
+	// Check is the name of the check
+	Check string `json:"check"`
+	// Target is the target of the check
+	Target string `json:"target"`
+	// State is the new state of the target
+	State string `json:"state"`
+	// Previous is the previous state of the target. Empty if the target was not evaluated before
+	Previous string `json:"previous,omitempty"`
+	// Reason describes why the target is failing
+	Reason string `json:"reason,omitempty"`
+	// Timestamp is the time of the result which caused the transition
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Recovered returns true if the target recovered from failing
+func (e Event) Recovered() bool {
+	return e.State == StateOK && e.Previous == StateFailing
+}
+
+// Notifier detects state transitions of the check targets
+// and sends them to the configured webhooks
+type Notifier struct {
+	// name is the name of the sparrow
+	name   string
+	cfg    Config
+	client *http.Client
+	// templates are the parsed payload templates keyed by webhook index
+	templates map[int]*template.Template
+	// mu protects the targets
+	mu sync.Mutex
+	// targets are the states of the targets keyed by check and target
+	targets map[string]map[string]*targetState
+	// cEvents receives the events to send
+	cEvents chan Event
+	// done is used to signal the send routine to stop
+	done chan struct{}
+}
+
+// New creates a new notifier for the sparrow with the given name
+func New(name string, cfg Config) (*Notifier, error) { //nolint:gocritic // no performance concerns yet
+	templates := make(map[int]*template.Template)
+	for i, wh := range cfg.Webhooks {
+		tmpl, err := wh.template()
+		if err != nil {
+			return nil, fmt.Errorf("invalid template of webhook %q: %w", wh.Name, err)
+		}
+		if tmpl != nil {
+			templates[i] = tmpl
+		}
+	}
+
+	return &Notifier{
+		name:      name,
+		cfg:       cfg,
+		client:    &http.Client{Timeout: cfg.timeout()},
+		templates: templates,
+		targets:   make(map[string]map[string]*targetState),
+		cEvents:   make(chan Event, eventBufferSize),
+		done:      make(chan struct{}, 1),
+	}, nil
+}
+
+// Observe evaluates the result of a check and queues the state transitions of its targets.
+// It never blocks: if the queue is full, the transitions are dropped.
+func (n *Notifier) Observe(ctx context.Context, result checks.ResultDTO) {
+	if result.Result == nil {
+		return
+	}
+	log := logger.FromContext(ctx)
+
+	for _, e := range n.transitions(result) {
+		select {
+		case n.cEvents <- e:
+		default:
+			log.WarnContext(ctx, "Dropped notification, webhooks do not keep up", "check", e.Check, "target", e.Target, "state", e.State)
+		}
+	}
+}
+
+// transitions updates the states of the targets of the result and returns the state transitions
+func (n *Notifier) transitions(result checks.ResultDTO) []Event {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	statuses := evaluate(result.Result.Data, n.threshold(result.Name))
+	states, ok := n.targets[result.Name]
+	if !ok {
+		states = make(map[string]*targetState)
+		n.targets[result.Name] = states
+	}
+	// forget the targets which were removed from the check
+	for target := range states {
+		if _, ok := statuses[target]; !ok {
+			delete(states, target)
+		}
+	}
+
+	// only the targets checked in this run are evaluated, the results of the other
+	// targets are repeated from earlier runs and must not count as further runs
+	var checked map[string]bool
+	if result.Result.Checked != nil {
+		checked = make(map[string]bool, len(result.Result.Checked))
+		for _, target := range result.Result.Checked {
+			checked[target] = true
+		}
+	}
+
+	var events []Event
+	for target, s := range statuses {
+		if checked != nil && !checked[target] {
+			continue
+		}
+		ts, ok := states[target]
+		if !ok {
+			ts = &targetState{}
+			states[target] = ts
+		}
+		previous, transitioned := ts.observe(s, n.cfg.debounce())
+		// targets which are fine from the beginning are not notified
+		if !transitioned || (previous == "" && s.state == StateOK) {
+			continue
+		}
+		events = append(events, Event{
+			Sparrow:   n.name,
+			Check:     result.Name,
+			Target:    target,
+			State:     s.state,
+			Previous:  previous,
+			Reason:    s.reason,
+			Timestamp: result.Result.Timestamp,
+		})
+	}
+	slices.SortFunc(events, func(a, b Event) int {
+		return strings.Compare(a.Target, b.Target)
+	})
+	return events
+}
+
+// threshold returns the latency threshold of the check.
+// Named check instances fall back to the threshold of their check
+func (n *Notifier) threshold(name string) time.Duration {
+	if t, ok := n.cfg.Thresholds[name]; ok {
+		return t
+	}
+	check, _ := checks.SplitName(name)
+	return n.cfg.Thresholds[check]
+}
+
+// Run sends the queued events to the webhooks until the notifier is shut down
+func (n *Notifier) Run(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+	log.InfoContext(ctx, "Starting notifier", "webhooks", len(n.cfg.Webhooks))
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.ErrorContext(ctx, "Error while sending notifications", "error", ctx.Err())
+			return ctx.Err()
+		case <-n.done:
+			log.InfoContext(ctx, "Notifier stopped")
+			return nil
+		case e := <-n.cEvents:
+			n.send(ctx, e)
+		}
+	}
+}
+
+// Shutdown stops the notifier
+func (n *Notifier) Shutdown() {
+	select {
+	case n.done <- struct{}{}:
+	default:
+	}
+}
+
+// send sends the event to all webhooks subscribed to its check
+func (n *Notifier) send(ctx context.Context, e Event) {
+	log := logger.FromContext(ctx)
+	check, _ := checks.SplitName(e.Check)
+
+	var wg sync.WaitGroup
+	for i, wh := range n.cfg.Webhooks {
+		if len(wh.Checks) > 0 && !slices.Contains(wh.Checks, e.Check) && !slices.Contains(wh.Checks, check) {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			payload, err := n.payload(i, e)
+			if err != nil {
+				log.ErrorContext(ctx, "Failed to render notification", "webhook", wh.Name, "error", err)
+				return
+			}
+			err = helper.Retry(func(ctx context.Context) error {
+				return n.post(ctx, &wh, payload)
+			}, n.cfg.Retry)(ctx)
+			if err != nil {
+				log.ErrorContext(ctx, "Failed to send notification", "webhook", wh.Name, "check", e.Check, "target", e.Target, "error", err)
+				return
+			}
+			log.DebugContext(ctx, "Sent notification", "webhook", wh.Name, "check", e.Check, "target", e.Target, "state", e.State)
+		}()
+	}
+	wg.Wait()
+}
+
+// payload renders the event with the template of the webhook with the given index.
+// Without a template, the event is encoded as JSON
+func (n *Notifier) payload(webhook int, e Event) ([]byte, error) {
+	tmpl, ok := n.templates[webhook]
+	if !ok {
+		return json.Marshal(e)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, e); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// post sends the payload to the webhook
+func (n *Notifier) post(ctx context.Context, wh *Webhook, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wh.Url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range wh.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	return nil
+}
+
+// toJSON encodes the value as JSON for use in the payload templates
+func toJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
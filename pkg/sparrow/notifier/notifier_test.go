@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package notifier
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telekom/sparrow/pkg/checks"
+)
+
+// request is a notification received by the test webhook
+type request struct {
+	header http.Header
+	body   string
+}
+
+// newWebhook returns a webhook receiver which forwards the received notifications
+func newWebhook(t *testing.T) (*httptest.Server, chan request) {
+	t.Helper()
+	received := make(chan request, 16)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		received <- request{header: r.Header, body: string(b)}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, received
+}
+
+func healthResult(state string) checks.ResultDTO {
+	return checks.ResultDTO{Name: "health", Result: &checks.Result{
+		Timestamp: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		Data:      map[string]string{"https://example.com": state},
+	}}
+}
+
+func TestNotifier_Observe(t *testing.T) {
+	srv, received := newWebhook(t)
+	n, err := New("sparrow.example.com", Config{
+		Debounce: 2,
+		Webhooks: []Webhook{
+			{Name: "all", Url: srv.URL, Headers: map[string]string{"Authorization": "Bearer secret"}},
+			{Name: "latency", Url: srv.URL, Checks: []string{"latency"}},
+		},
+	})
+	require.NoError(t, err)
+	go func() {
+		_ = n.Run(t.Context())
+	}()
+	defer n.Shutdown()
+
+	ctx := t.Context()
+	// healthy from the beginning is not notified
+	n.Observe(ctx, healthResult("healthy"))
+	n.Observe(ctx, healthResult("healthy"))
+	// a single failure is debounced
+	n.Observe(ctx, healthResult("unhealthy"))
+	n.Observe(ctx, healthResult("healthy"))
+	assertNoRequest(t, received)
+
+	n.Observe(ctx, healthResult("unhealthy"))
+	n.Observe(ctx, healthResult("unhealthy"))
+	req := receive(t, received)
+	assert.Equal(t, "Bearer secret", req.header.Get("Authorization"))
+	assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+	var e Event
+	require.NoError(t, json.Unmarshal([]byte(req.body), &e))
+	assert.Equal(t, Event{
+		Sparrow:   "sparrow.example.com",
+		Check:     "health",
+		Target:    "https://example.com",
+		State:     StateFailing,
+		Previous:  StateOK,
+		Reason:    "unhealthy",
+		Timestamp: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+	}, e)
+	assertNoRequest(t, received)
+
+	// recovery
+	n.Observe(ctx, healthResult("healthy"))
+	n.Observe(ctx, healthResult("healthy"))
+	req = receive(t, received)
+	require.NoError(t, json.Unmarshal([]byte(req.body), &e))
+	assert.Equal(t, StateOK, e.State)
+	assert.True(t, e.Recovered())
+}
+
+func TestNotifier_transitions_intervals(t *testing.T) {
+	n, err := New("sparrow.example.com", Config{Debounce: 3})
+	require.NoError(t, err)
+
+	// the fast target is checked every run, the slow target only every fourth run
+	var sched checks.Scheduler[string]
+	intervals := map[string]time.Duration{"https://fast.example.com": time.Second, "https://slow.example.com": 4 * time.Second}
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	run := func(slow string) []Event {
+		results := make(map[string]string)
+		for target := range sched.Due(now, intervals) {
+			results[target] = "healthy"
+			if target == "https://slow.example.com" {
+				results[target] = slow
+			}
+		}
+		data := sched.Merge(results)
+		now = now.Add(time.Second)
+		return n.transitions(checks.ResultDTO{Name: "health", Result: &checks.Result{
+			Data:      data,
+			Timestamp: now,
+			Checked:   sched.Checked(),
+		}})
+	}
+
+	assert.Empty(t, run("healthy"))
+	// the slow target fails in each of its runs, but its results repeated in the runs of
+	// the fast target don't count, so it is only notified after its third failed run
+	var events []Event
+	runs := 0
+	for len(events) == 0 && runs < 20 {
+		events = run("unhealthy")
+		runs++
+	}
+	assert.Equal(t, 12, runs)
+	require.Len(t, events, 1)
+	assert.Equal(t, "https://slow.example.com", events[0].Target)
+	assert.Equal(t, StateFailing, events[0].State)
+}
+
+func TestNotifier_Template(t *testing.T) {
+	srv, received := newWebhook(t)
+	n, err := New("sparrow.example.com", Config{
+		Thresholds: map[string]time.Duration{"latency": 100 * time.Millisecond},
+		Webhooks: []Webhook{{
+			Name: "chat",
+			Url:  srv.URL,
+			Template: `{"text": {{ printf "%s: %s %s (%s)" .Sparrow .Check .State .Reason | json }}}` +
+				`{{ if .Recovered }} recovered{{ end }}`,
+		}},
+	})
+	require.NoError(t, err)
+	go func() {
+		_ = n.Run(t.Context())
+	}()
+	defer n.Shutdown()
+
+	n.Observe(t.Context(), checks.ResultDTO{Name: "latency/internal", Result: &checks.Result{Data: map[string]any{
+		"https://example.com": map[string]any{"code": 200, "total": 0.2},
+	}}})
+	req := receive(t, received)
+	assert.JSONEq(t, `{"text": "sparrow.example.com: latency/internal failing (total duration 200ms above threshold 100ms)"}`, req.body)
+
+	n.Observe(t.Context(), checks.ResultDTO{Name: "latency/internal", Result: &checks.Result{Data: map[string]any{
+		"https://example.com": map[string]any{"code": 200, "total": 0.05},
+	}}})
+	req = receive(t, received)
+	assert.Equal(t, `{"text": "sparrow.example.com: latency/internal ok ()"} recovered`, req.body)
+}
+
+func TestNotifier_Run(t *testing.T) {
+	n, err := New("sparrow.example.com", Config{Webhooks: []Webhook{{Url: "http://localhost"}}})
+	require.NoError(t, err)
+
+	errC := make(chan error, 1)
+	go func() {
+		errC <- n.Run(t.Context())
+	}()
+	n.Shutdown()
+	select {
+	case err := <-errC:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("notifier did not stop")
+	}
+}
+
+func receive(t *testing.T, received chan request) request {
+	t.Helper()
+	select {
+	case req := <-received:
+		return req
+	case <-time.After(time.Second):
+		t.Fatal("no notification received")
+		return request{}
+	}
+}
+
+func assertNoRequest(t *testing.T, received chan request) {
+	t.Helper()
+	select {
+	case req := <-received:
+		t.Errorf("unexpected notification: %s", req.body)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
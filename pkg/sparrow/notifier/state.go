@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package notifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	// StateOK is the state of a target whose latest results were fine
+	StateOK = "ok"
+	// StateFailing is the state of a target whose latest results failed
+	StateFailing = "failing"
+)
+
+// healthy is the result of a healthy target of the health check
+const healthy = "healthy"
+
+// status is the evaluated result of a single target
+type status struct {
+	state  string
+	reason string
+}
+
+// targetState keeps track of the state transitions of a single target
+type targetState struct {
+	// state is the notified state of the target. Empty if the target was never evaluated
+	state string
+	// pending is the state the target is transitioning to
+	pending string
+	// runs is the number of consecutive runs the target is in the pending state
+	runs int
+}
+
+// observe records the status of a run and returns true if the target transitioned
+// to the pending state, which happens after the given number of consecutive runs
+func (t *targetState) observe(s status, debounce int) (previous string, transitioned bool) {
+	if s.state == t.state {
+		t.pending, t.runs = "", 0
+		return "", false
+	}
+	if s.state != t.pending {
+		t.pending, t.runs = s.state, 0
+	}
+	t.runs++
+	if t.runs < debounce {
+		return "", false
+	}
+
+	previous = t.state
+	t.state, t.pending, t.runs = s.state, "", 0
+	return previous, true
+}
+
+// evaluate returns the status of all targets of a check result.
+// The check results are keyed by target and are either a state
+// like the results of the health check or an object, which is failing
+// if it has an error, is not successful or not serving or its total
+// duration is above the threshold.
+func evaluate(data any, threshold time.Duration) map[string]status {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil
+	}
+	var byTarget map[string]json.RawMessage
+	if err = json.Unmarshal(b, &byTarget); err != nil {
+		return nil
+	}
+
+	statuses := make(map[string]status, len(byTarget))
+	for target, raw := range byTarget {
+		var state string
+		if json.Unmarshal(raw, &state) == nil {
+			if state == healthy {
+				statuses[target] = status{state: StateOK}
+			} else {
+				statuses[target] = status{state: StateFailing, reason: state}
+			}
+			continue
+		}
+
+		var res struct {
+			Error   *string  `json:"error"`
+			Success *bool    `json:"success"`
+			Serving *bool    `json:"serving"`
+			Total   *float64 `json:"total"`
+		}
+		if json.Unmarshal(raw, &res) != nil {
+			continue
+		}
+		switch {
+		case res.Error != nil && *res.Error != "":
+			statuses[target] = status{state: StateFailing, reason: *res.Error}
+		case res.Success != nil && !*res.Success:
+			statuses[target] = status{state: StateFailing, reason: "not successful"}
+		case res.Serving != nil && !*res.Serving:
+			statuses[target] = status{state: StateFailing, reason: "not serving"}
+		case threshold > 0 && res.Total != nil && *res.Total > threshold.Seconds():
+			total := time.Duration(*res.Total * float64(time.Second))
+			statuses[target] = status{state: StateFailing, reason: fmt.Sprintf("total duration %v above threshold %v", total, threshold)}
+		default:
+			statuses[target] = status{state: StateOK}
+		}
+	}
+	return statuses
+}
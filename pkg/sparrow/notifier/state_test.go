@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package notifier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	errMsg := "connection refused"
+	tests := []struct {
+		name      string
+		data      any
+		threshold time.Duration
+		want      map[string]status
+	}{
+		{
+			name: "health",
+			data: map[string]string{"https://a.example.com": "healthy", "https://b.example.com": "unhealthy"},
+			want: map[string]status{
+				"https://a.example.com": {state: StateOK},
+				"https://b.example.com": {state: StateFailing, reason: "unhealthy"},
+			},
+		},
+		{
+			name: "dns",
+			data: map[string]any{
+				"a.example.com": map[string]any{"resolved": []string{"10.0.0.1"}, "error": nil, "total": 0.01},
+				"b.example.com": map[string]any{"resolved": nil, "error": &errMsg, "total": 0.01},
+			},
+			want: map[string]status{
+				"a.example.com": {state: StateOK},
+				"b.example.com": {state: StateFailing, reason: errMsg},
+			},
+		},
+		{
+			name:      "latency above threshold",
+			data:      map[string]any{"https://a.example.com": map[string]any{"code": 200, "total": 0.1}, "https://b.example.com": map[string]any{"code": 200, "total": 0.75}},
+			threshold: 500 * time.Millisecond,
+			want: map[string]status{
+				"https://a.example.com": {state: StateOK},
+				"https://b.example.com": {state: StateFailing, reason: "total duration 750ms above threshold 500ms"},
+			},
+		},
+		{
+			name: "latency without threshold",
+			data: map[string]any{"https://b.example.com": map[string]any{"code": 200, "total": 0.75}},
+			want: map[string]status{"https://b.example.com": {state: StateOK}},
+		},
+		{
+			name: "not successful and not serving",
+			data: map[string]any{"probe": map[string]any{"success": false}, "grpc.example.com:443": map[string]any{"serving": false}},
+			want: map[string]status{
+				"probe":                {state: StateFailing, reason: "not successful"},
+				"grpc.example.com:443": {state: StateFailing, reason: "not serving"},
+			},
+		},
+		{name: "no targets", data: 1, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, evaluate(tt.data, tt.threshold))
+		})
+	}
+}
+
+func TestTargetState_observe(t *testing.T) {
+	var ts targetState
+	ok, failing := status{state: StateOK}, status{state: StateFailing}
+
+	previous, transitioned := ts.observe(ok, 2)
+	assert.False(t, transitioned)
+	previous, transitioned = ts.observe(ok, 2)
+	assert.True(t, transitioned)
+	assert.Empty(t, previous)
+
+	// a single failing run is debounced
+	_, transitioned = ts.observe(failing, 2)
+	assert.False(t, transitioned)
+	_, transitioned = ts.observe(ok, 2)
+	assert.False(t, transitioned)
+
+	_, transitioned = ts.observe(failing, 2)
+	assert.False(t, transitioned)
+	previous, transitioned = ts.observe(failing, 2)
+	assert.True(t, transitioned)
+	assert.Equal(t, StateOK, previous)
+
+	_, transitioned = ts.observe(failing, 2)
+	assert.False(t, transitioned, "staying in a state is no transition")
+}
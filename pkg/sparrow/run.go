@@ -7,6 +7,7 @@ package sparrow
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/url"
 	"slices"
 	"strings"
@@ -15,10 +16,16 @@ import (
 
 	"github.com/telekom/sparrow/internal/logger"
 	"github.com/telekom/sparrow/pkg/api"
+	"github.com/telekom/sparrow/pkg/checks/dns"
+	"github.com/telekom/sparrow/pkg/checks/health"
+	"github.com/telekom/sparrow/pkg/checks/latency"
 	"github.com/telekom/sparrow/pkg/checks/runtime"
+	"github.com/telekom/sparrow/pkg/checks/tcp"
 	"github.com/telekom/sparrow/pkg/config"
 	"github.com/telekom/sparrow/pkg/db"
+	"github.com/telekom/sparrow/pkg/sparrow/aggregator"
 	"github.com/telekom/sparrow/pkg/sparrow/metrics"
+	"github.com/telekom/sparrow/pkg/sparrow/notifier"
 	"github.com/telekom/sparrow/pkg/sparrow/targets"
 )
 
@@ -36,6 +43,10 @@ type Sparrow struct {
 	loader config.Loader
 	// tarMan is the target manager that is used to manage global targets
 	tarMan targets.TargetManager
+	// aggregator pulls the check results of the peer sparrows
+	aggregator *aggregator.Aggregator
+	// notifier sends webhooks on state transitions of the check targets
+	notifier *notifier.Notifier
 	// metrics is used to collect metrics
 	metrics metrics.Provider
 	// controller is used to manage the checks
@@ -57,9 +68,12 @@ type Sparrow struct {
 }
 
 // New creates a new sparrow from a given configfile
-func New(cfg *config.Config) *Sparrow {
+func New(cfg *config.Config) (*Sparrow, error) {
 	m := metrics.New(cfg.Telemetry)
-	dbase := db.NewInMemory()
+	dbase, err := db.New(cfg.Db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database: %w", err)
+	}
 
 	sparrow := &Sparrow{
 		config:     cfg,
@@ -77,8 +91,19 @@ func New(cfg *config.Config) *Sparrow {
 	if cfg.HasTargetManager() {
 		gm := targets.NewManager(cfg.SparrowName, cfg.TargetManager, m, sparrow.cTargets)
 		sparrow.tarMan = gm
+		if cfg.HasAggregator() {
+			sparrow.aggregator = aggregator.New(cfg.SparrowName, cfg.Aggregator, gm)
+		}
 	}
-	sparrow.loader = config.NewLoader(cfg, sparrow.cRuntime)
+	if cfg.HasNotifier() {
+		n, err := notifier.New(cfg.SparrowName, cfg.Notifier)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create notifier: %w", err)
+		}
+		sparrow.notifier = n
+		sparrow.controller.notifier = n
+	}
+	sparrow.loader = config.NewLoader(cfg, sparrow.cRuntime, m)
 
 	// Register instance metadata as Prometheus info metric (once per instance)
 	if err := metrics.RegisterInstanceInfo(m.GetRegistry(), cfg.SparrowName, cfg.Metadata); err != nil {
@@ -88,7 +113,7 @@ func New(cfg *config.Config) *Sparrow {
 		log.Error("Failed to register sparrow_instance_info metric", "error", err)
 	}
 
-	return sparrow
+	return sparrow, nil
 }
 
 // Run starts the sparrow
@@ -111,6 +136,18 @@ func (s *Sparrow) Run(ctx context.Context) error {
 		}
 	}()
 
+	go func() {
+		if s.aggregator != nil {
+			s.cErr <- s.aggregator.Run(ctx)
+		}
+	}()
+
+	go func() {
+		if s.notifier != nil {
+			s.cErr <- s.notifier.Run(ctx)
+		}
+	}()
+
 	go func() {
 		s.cErr <- s.startupAPI(ctx)
 	}()
@@ -130,11 +167,15 @@ func (s *Sparrow) Run(ctx context.Context) error {
 			s.configMutex.Unlock()
 		// Targets changed
 		case <-s.cTargets:
-			s.configMutex.RLock()
+			// the lock is held while enriching, since the targets are added to the stored configuration
+			s.configMutex.Lock()
 			cfg := s.runtimeConfig
-			s.configMutex.RUnlock()
 			if !cfg.Empty() {
 				cfg = s.enrichTargets(ctx, cfg)
+				s.runtimeConfig = cfg
+			}
+			s.configMutex.Unlock()
+			if !cfg.Empty() {
 				s.controller.Reconcile(ctx, cfg)
 				log.DebugContext(ctx, "Reapplied configuration due to target changes")
 			}
@@ -154,6 +195,7 @@ func (s *Sparrow) Run(ctx context.Context) error {
 
 // enrichTargets updates the targets of the sparrow's checks with the
 // global targets. Per default, the two target lists are merged.
+// The targets of named check instances are enriched as well.
 func (s *Sparrow) enrichTargets(ctx context.Context, cfg runtime.Config) runtime.Config {
 	l := logger.FromContext(ctx)
 	if cfg.Empty() || s.tarMan == nil {
@@ -173,20 +215,44 @@ func (s *Sparrow) enrichTargets(ctx context.Context, cfg runtime.Config) runtime
 			continue
 		}
 
-		if cfg.HasHealthCheck() && !slices.Contains(cfg.Health.Targets, u.String()) {
-			cfg.Health.Targets = append(cfg.Health.Targets, u.String())
-		}
-		if cfg.HasLatencyCheck() && !slices.Contains(cfg.Latency.Targets, u.String()) {
-			cfg.Latency.Targets = append(cfg.Latency.Targets, u.String())
-		}
-		if cfg.HasDNSCheck() && !slices.Contains(cfg.Dns.Targets, hostWithoutPort) {
-			cfg.Dns.Targets = append(cfg.Dns.Targets, hostWithoutPort)
+		for _, c := range cfg.Iter() {
+			switch c := c.(type) {
+			case *health.Config:
+				if !slices.ContainsFunc(c.Targets, func(t health.Target) bool { return t.URL == u.String() }) {
+					c.Targets = append(c.Targets, health.Target{URL: u.String()})
+				}
+			case *latency.Config:
+				if !slices.ContainsFunc(c.Targets, func(t latency.Target) bool { return t.URL == u.String() }) {
+					c.Targets = append(c.Targets, latency.Target{URL: u.String()})
+				}
+			case *dns.Config:
+				if !slices.ContainsFunc(c.Targets, func(t dns.Target) bool { return t.Name == hostWithoutPort }) {
+					c.Targets = append(c.Targets, dns.Target{Name: hostWithoutPort})
+				}
+			case *tcp.Config:
+				addr := net.JoinHostPort(hostWithoutPort, portFromURL(u))
+				if !slices.ContainsFunc(c.Targets, func(t tcp.Target) bool { return t.Address == addr }) {
+					c.Targets = append(c.Targets, tcp.Target{Address: addr})
+				}
+			}
 		}
 	}
 
 	return cfg
 }
 
+// portFromURL returns the port of the given URL.
+// If the URL has no explicit port, the default port of its scheme is returned.
+func portFromURL(u *url.URL) string {
+	if p := u.Port(); p != "" {
+		return p
+	}
+	if u.Scheme == "http" {
+		return "80"
+	}
+	return "443"
+}
+
 // shutdown shuts down the sparrow and all managed components gracefully.
 // It returns an error if one is present in the context or if any of the
 // components fail to shut down.
@@ -202,10 +268,19 @@ func (s *Sparrow) shutdown(ctx context.Context) {
 		if s.tarMan != nil {
 			sErrs.errTarMan = s.tarMan.Shutdown(ctx)
 		}
+		if s.aggregator != nil {
+			s.aggregator.Shutdown()
+		}
+		if s.notifier != nil {
+			s.notifier.Shutdown()
+		}
+		// Result streams are closed first, otherwise the api server waits for them until the shutdown times out
+		s.controller.CloseStreams()
 		sErrs.errAPI = s.api.Shutdown(ctx)
 		sErrs.errMetrics = s.metrics.Shutdown(ctx)
 		s.loader.Shutdown(ctx)
 		s.controller.Shutdown(ctx)
+		sErrs.errDB = s.db.Close()
 
 		if sErrs.HasError() {
 			log.ErrorContext(ctx, "Failed to shutdown gracefully", "contextError", errC, "errors", sErrs)
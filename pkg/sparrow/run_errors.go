@@ -10,9 +10,10 @@ type ErrShutdown struct {
 	errAPI     error
 	errTarMan  error
 	errMetrics error
+	errDB      error
 }
 
 // HasError returns true if any of the errors are set
 func (e ErrShutdown) HasError() bool {
-	return e.errAPI != nil || e.errTarMan != nil || e.errMetrics != nil
+	return e.errAPI != nil || e.errTarMan != nil || e.errMetrics != nil || e.errDB != nil
 }
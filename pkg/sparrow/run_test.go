@@ -16,6 +16,7 @@ import (
 	"github.com/telekom/sparrow/pkg/checks/health"
 	"github.com/telekom/sparrow/pkg/checks/latency"
 	"github.com/telekom/sparrow/pkg/checks/runtime"
+	"github.com/telekom/sparrow/pkg/checks/tcp"
 	"github.com/telekom/sparrow/pkg/config"
 	"github.com/telekom/sparrow/pkg/sparrow/targets"
 	"github.com/telekom/sparrow/pkg/sparrow/targets/interactor"
@@ -51,7 +52,10 @@ func TestSparrow_Run_FullComponentStart(t *testing.T) {
 		},
 	}
 
-	s := New(c)
+	s, err := New(c)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
 	ctx := context.Background()
 	errCh := make(chan error, 1)
 	go func() {
@@ -84,7 +88,10 @@ func TestSparrow_Run_ContextCancel(t *testing.T) {
 		},
 	}
 
-	s := New(c)
+	s, err := New(c)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
 	s.tarMan = &managermock.MockTargetManager{}
 	ctx, cancel := context.WithCancel(context.Background())
 	go func() {
@@ -129,116 +136,133 @@ func TestSparrow_enrichTargets(t *testing.T) {
 		},
 		{
 			name: "config with no targets",
-			config: runtime.Config{
-				Health: &health.Config{
+			config: runtime.Config{Checks: map[string]checks.Runtime{
+				"health": &health.Config{
 					Targets: nil,
 				},
-				Latency: &latency.Config{
+				"latency": &latency.Config{
 					Targets: nil,
 				},
-			},
+			}},
 			globalTargets: gt,
-			expected: runtime.Config{
-				Health: &health.Config{
-					Targets: []string{testTarget},
+			expected: runtime.Config{Checks: map[string]checks.Runtime{
+				"health": &health.Config{
+					Targets: []health.Target{{URL: testTarget}},
 				},
-				Latency: &latency.Config{
-					Targets: []string{testTarget},
+				"latency": &latency.Config{
+					Targets: []latency.Target{{URL: testTarget}},
 				},
-			},
+			}},
 		},
 		{
 			name: "config with empty targets",
-			config: runtime.Config{
-				Health: &health.Config{
+			config: runtime.Config{Checks: map[string]checks.Runtime{
+				"health": &health.Config{
 					Targets: nil,
 				},
-				Latency: &latency.Config{
+				"latency": &latency.Config{
 					Targets: nil,
 				},
-			},
+			}},
 			globalTargets: gt,
-			expected: runtime.Config{
-				Health: &health.Config{
-					Targets: []string{testTarget},
+			expected: runtime.Config{Checks: map[string]checks.Runtime{
+				"health": &health.Config{
+					Targets: []health.Target{{URL: testTarget}},
 				},
-				Latency: &latency.Config{
-					Targets: []string{testTarget},
+				"latency": &latency.Config{
+					Targets: []latency.Target{{URL: testTarget}},
 				},
-			},
+			}},
 		},
 		{
 			name: "config with targets (health + latency)",
-			config: runtime.Config{
-				Health: &health.Config{
-					Targets: []string{"https://gitlab.com"},
+			config: runtime.Config{Checks: map[string]checks.Runtime{
+				"health": &health.Config{
+					Targets: []health.Target{{URL: "https://gitlab.com"}},
 				},
-				Latency: &latency.Config{
-					Targets: []string{"https://gitlab.com"},
+				"latency": &latency.Config{
+					Targets: []latency.Target{{URL: "https://gitlab.com"}},
 				},
-			},
+			}},
 			globalTargets: gt,
-			expected: runtime.Config{
-				Health: &health.Config{
-					Targets: []string{"https://gitlab.com", testTarget},
+			expected: runtime.Config{Checks: map[string]checks.Runtime{
+				"health": &health.Config{
+					Targets: []health.Target{{URL: "https://gitlab.com"}, {URL: testTarget}},
 				},
-				Latency: &latency.Config{
-					Targets: []string{"https://gitlab.com", testTarget},
+				"latency": &latency.Config{
+					Targets: []latency.Target{{URL: "https://gitlab.com"}, {URL: testTarget}},
 				},
-			},
+			}},
 		},
 		{
 			name: "config with targets (dns)",
-			config: runtime.Config{
-				Dns: &dns.Config{
-					Targets: []string{"gitlab.com"},
+			config: runtime.Config{Checks: map[string]checks.Runtime{
+				"dns": &dns.Config{
+					Targets: []dns.Target{{Name: "gitlab.com"}},
 				},
-			},
+			}},
 			globalTargets: gt,
-			expected: runtime.Config{
-				Dns: &dns.Config{
-					Targets: []string{"gitlab.com", "localhost.de"},
+			expected: runtime.Config{Checks: map[string]checks.Runtime{
+				"dns": &dns.Config{
+					Targets: []dns.Target{{Name: "gitlab.com"}, {Name: "localhost.de"}},
 				},
-			},
+			}},
+		},
+		{
+			name: "config with targets (tcp)",
+			config: runtime.Config{Checks: map[string]checks.Runtime{
+				"tcp": &tcp.Config{
+					Targets: []tcp.Target{{Address: "gitlab.com:22"}},
+				},
+			}},
+			globalTargets: append(gt, checks.GlobalTarget{
+				Url:      "http://az1.sparrow.com:8080",
+				LastSeen: now,
+			}),
+			expected: runtime.Config{Checks: map[string]checks.Runtime{
+				"tcp": &tcp.Config{
+					Targets: []tcp.Target{{Address: "gitlab.com:22"}, {Address: "localhost.de:443"}, {Address: "az1.sparrow.com:8080"}},
+				},
+			}},
 		},
 		{
 			name: "config has a target already present in global targets - no duplicates",
-			config: runtime.Config{
-				Health: &health.Config{
-					Targets: []string{testTarget},
+			config: runtime.Config{Checks: map[string]checks.Runtime{
+				"health": &health.Config{
+					Targets: []health.Target{{URL: testTarget}},
 				},
-			},
+			}},
 			globalTargets: gt,
-			expected: runtime.Config{
-				Health: &health.Config{
-					Targets: []string{testTarget},
+			expected: runtime.Config{Checks: map[string]checks.Runtime{
+				"health": &health.Config{
+					Targets: []health.Target{{URL: testTarget}},
 				},
-			},
+			}},
 		},
 		{
 			name: "global targets contains self - do not add to config",
-			config: runtime.Config{
-				Health: &health.Config{
-					Targets: []string{testTarget},
+			config: runtime.Config{Checks: map[string]checks.Runtime{
+				"health": &health.Config{
+					Targets: []health.Target{{URL: testTarget}},
 				},
-			},
+			}},
 			globalTargets: append(gt, checks.GlobalTarget{
 				Url:      "https://sparrow.com",
 				LastSeen: now,
 			}),
-			expected: runtime.Config{
-				Health: &health.Config{
-					Targets: []string{testTarget},
+			expected: runtime.Config{Checks: map[string]checks.Runtime{
+				"health": &health.Config{
+					Targets: []health.Target{{URL: testTarget}},
 				},
-			},
+			}},
 		},
 		{
 			name: "global targets contains http and https - dns validation still works does not fail and splits off scheme",
-			config: runtime.Config{
-				Dns: &dns.Config{
-					Targets: []string{},
+			config: runtime.Config{Checks: map[string]checks.Runtime{
+				"dns": &dns.Config{
+					Targets: []dns.Target{},
 				},
-			},
+			}},
 			globalTargets: []checks.GlobalTarget{
 				{
 					Url:      "http://az1.sparrow.com",
@@ -248,11 +272,11 @@ func TestSparrow_enrichTargets(t *testing.T) {
 					Url: "https://az2.sparrow.com",
 				},
 			},
-			expected: runtime.Config{
-				Dns: &dns.Config{
-					Targets: []string{"az1.sparrow.com", "az2.sparrow.com"},
+			expected: runtime.Config{Checks: map[string]checks.Runtime{
+				"dns": &dns.Config{
+					Targets: []dns.Target{{Name: "az1.sparrow.com"}, {Name: "az2.sparrow.com"}},
 				},
-			},
+			}},
 		},
 	}
 
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sparrow
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/telekom/sparrow/pkg/checks"
+)
+
+// subscriptionBufferSize is the number of results buffered per subscription.
+// Results are dropped for subscribers that do not keep up.
+const subscriptionBufferSize = 16
+
+// resultBroker fans out the check results to all subscribers
+type resultBroker struct {
+	mu     sync.RWMutex
+	subs   map[*subscription]struct{}
+	closed bool
+}
+
+// subscription receives the results of the subscribed checks
+type subscription struct {
+	// checks are the names of the subscribed checks. If empty, all results are received
+	checks []string
+	// results receives the results. It is closed when the broker is closed
+	results chan checks.ResultDTO
+}
+
+// newResultBroker creates a new resultBroker
+func newResultBroker() *resultBroker {
+	return &resultBroker{
+		subs: map[*subscription]struct{}{},
+	}
+}
+
+// Subscribe creates a subscription for the results of the given checks.
+// If no checks are given, the results of all checks are received.
+// The subscription must be removed with Unsubscribe when it is no longer needed.
+func (b *resultBroker) Subscribe(names ...string) *subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub := &subscription{
+		checks:  names,
+		results: make(chan checks.ResultDTO, subscriptionBufferSize),
+	}
+	if b.closed {
+		close(sub.results)
+		return sub
+	}
+	b.subs[sub] = struct{}{}
+	return sub
+}
+
+// Unsubscribe removes the subscription and closes its results channel
+func (b *resultBroker) Unsubscribe(sub *subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subs[sub]; ok {
+		delete(b.subs, sub)
+		close(sub.results)
+	}
+}
+
+// Publish sends the result to all subscribers of the result's check.
+// It never blocks: if a subscriber's buffer is full, the result is dropped for it.
+// Returns the number of subscribers the result was dropped for.
+func (b *resultBroker) Publish(result checks.ResultDTO) (dropped int) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subs {
+		if len(sub.checks) > 0 && !slices.Contains(sub.checks, result.Name) {
+			continue
+		}
+		select {
+		case sub.results <- result:
+		default:
+			dropped++
+		}
+	}
+	return dropped
+}
+
+// Close removes all subscriptions and closes their results channels.
+// Subscriptions created afterwards are closed immediately.
+func (b *resultBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subs {
+		close(sub.results)
+	}
+	b.subs = map[*subscription]struct{}{}
+	b.closed = true
+}
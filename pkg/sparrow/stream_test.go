@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2025 Deutsche Telekom IT GmbH
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sparrow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telekom/sparrow/pkg/checks"
+)
+
+func TestResultBroker_Publish(t *testing.T) {
+	b := newResultBroker()
+	all := b.Subscribe()
+	health := b.Subscribe("health", "dns")
+	defer b.Unsubscribe(all)
+	defer b.Unsubscribe(health)
+
+	b.Publish(checks.ResultDTO{Name: "health"})
+	b.Publish(checks.ResultDTO{Name: "latency"})
+
+	assert.Len(t, all.results, 2)
+	assert.Len(t, health.results, 1)
+	assert.Equal(t, "health", (<-health.results).Name)
+}
+
+func TestResultBroker_DropsForSlowSubscribers(t *testing.T) {
+	b := newResultBroker()
+	sub := b.Subscribe()
+	defer b.Unsubscribe(sub)
+
+	for range subscriptionBufferSize {
+		assert.Equal(t, 0, b.Publish(checks.ResultDTO{Name: "health"}))
+	}
+	assert.Equal(t, 1, b.Publish(checks.ResultDTO{Name: "health"}), "result should be dropped if the buffer is full")
+	assert.Len(t, sub.results, subscriptionBufferSize)
+}
+
+func TestResultBroker_Close(t *testing.T) {
+	b := newResultBroker()
+	sub := b.Subscribe()
+
+	b.Close()
+	_, ok := <-sub.results
+	assert.False(t, ok, "subscriptions should be closed")
+
+	// Unsubscribing after close must not close the channel twice
+	b.Unsubscribe(sub)
+
+	late := b.Subscribe()
+	_, ok = <-late.results
+	assert.False(t, ok, "subscriptions after close should be closed immediately")
+	assert.Equal(t, 0, b.Publish(checks.ResultDTO{Name: "health"}))
+}
@@ -55,6 +55,9 @@ type TargetManagerConfig struct {
 	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
 	// Type defines which target manager to use
 	Type interactor.Type `yaml:"type" mapstructure:"type"`
+	// MeshLabels adds the source and destination labels to the
+	// metrics of the checks for the global targets
+	MeshLabels bool `yaml:"meshLabels" mapstructure:"meshLabels"`
 	// General is the general configuration of the target manager
 	General `yaml:",inline" mapstructure:",squash"`
 	// Config is the configuration for the Config target manager